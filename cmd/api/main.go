@@ -1,12 +1,52 @@
 package main
 
 import (
+	"context"
 	"github-api/pkg/api/v1"
+	"github-api/pkg/auth"
+	"github-api/pkg/events"
+	"github-api/pkg/mergequeue"
+	"github-api/pkg/scaffold"
+	"github-api/pkg/workspace"
 	"github.com/gin-gonic/gin"
 	"log"
 )
 
 func main() {
+	bus, err := events.NewBusFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure event bus: %v", err)
+	}
+	events.SetDefault(bus)
+	bus.Start(context.Background())
+	defer bus.Close()
+
+	workspace.SetDefault(workspace.FromEnv())
+	scaffold.SetDefault(scaffold.FromEnv())
+
+	app, err := auth.AppFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure GitHub App: %v", err)
+	}
+	auth.SetDefaultApp(app)
+
+	queueConfig, err := mergequeue.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to configure merge queue: %v", err)
+	}
+	if queueConfig.Enabled() {
+		client, err := auth.GetClient(queueConfig.Token)
+		if err != nil {
+			log.Fatalf("Failed to authenticate merge queue: %v", err)
+		}
+		runner, err := mergequeue.NewRunner(client, queueConfig)
+		if err != nil {
+			log.Fatalf("Failed to create merge queue: %v", err)
+		}
+		runner.Start(context.Background(), bus)
+		defer runner.Close()
+	}
+
 	router := gin.Default()
 	v1.RegisterRoutes(router)
 	log.Println("Server started at :8080")
@@ -0,0 +1,397 @@
+package controllers
+
+import (
+	"errors"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+	"strconv"
+)
+
+// ListWorkflows handles the retrieval of the Actions workflows of a repository.
+// It expects the token, username and repoName parameters. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the workflows.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListWorkflows(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	workflows, resp, err := client.ListWorkflows(c, params["username"], params["repoName"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the workflows of the repository
+	response.StatusOKPage(c, workflows.Workflows, resp)
+}
+
+// DispatchWorkflow handles triggering a workflow with a workflow_dispatch event.
+// It expects the token, username and repoName parameters and the file name or ID of the workflow,
+// and accepts a models.DispatchRequest body with the ref to run on and the inputs of the workflow.
+// GitHub queues the run in the background and does not return it.
+//
+// Responses:
+//   - 202 Accepted: With the workflow and the ref it runs on.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the workflow does not exist.
+//   - 422 Unprocessable Entity: If the workflow has no workflow_dispatch trigger, or the ref or an input is invalid.
+func DispatchWorkflow(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "workflow")
+	if !ok {
+		return
+	}
+	var req models.DispatchRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	ref, err := models.DispatchWorkflow(c, client, params["username"], params["repoName"], params["workflow"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 202 Accepted once the workflow run is queued
+	response.StatusAccepted(c, gin.H{"workflow": params["workflow"], "ref": ref})
+}
+
+// ListWorkflowRuns handles the retrieval of the workflow runs of a repository.
+// It expects the token, username and repoName parameters and accepts the workflow (file name or
+// ID), branch, event, status, actor, head_sha and created query parameters. Results are paginated
+// with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the workflow runs, newest first.
+//   - 400 Bad Request: If a parameter is missing or invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the workflow does not exist.
+func ListWorkflowRuns(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	var filter models.WorkflowRunFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		if fields, ok := validation.Fields(err); ok {
+			// Response: 400 Bad Request listing the invalid filters
+			response.StatusBadRequestFields(c, fields)
+			return
+		}
+		// Response: 400 Bad Request if the query string cannot be parsed
+		response.StatusBadRequest(c)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	runs, resp, err := models.ListWorkflowRuns(c, client, params["username"], params["repoName"], filter, page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the workflow runs
+	response.StatusOKPage(c, runs, resp)
+}
+
+// GetWorkflowRun handles the retrieval of a workflow run.
+// It expects the token, username and repoName parameters and the run ID.
+//
+// Responses:
+//   - 200 OK: With the workflow run.
+//   - 400 Bad Request: If a parameter is missing or the run ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the run does not exist.
+func GetWorkflowRun(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	runID, ok := intParam(c, "runID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	run, _, err := client.GetWorkflowRunByID(c, params["username"], params["repoName"], runID)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the workflow run
+	response.StatusOK(c, run)
+}
+
+// ListWorkflowJobs handles the retrieval of the jobs of a workflow run.
+// It expects the token, username and repoName parameters and the run ID, and accepts the filter
+// query parameter: latest (the default) for the jobs of the latest attempt, or all. Results are
+// paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the jobs and their steps.
+//   - 400 Bad Request: If a parameter is missing or invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the run does not exist.
+func ListWorkflowJobs(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	runID, ok := intParam(c, "runID")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	filter := c.DefaultQuery("filter", "latest")
+	if filter != "latest" && filter != "all" {
+		// Response: 400 Bad Request if the filter is invalid
+		response.StatusBadRequestFields(c, []validation.FieldError{{Field: "filter", Rule: "oneof", Message: "must be one of latest, all"}})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	jobs, resp, err := client.ListWorkflowJobs(c, params["username"], params["repoName"], runID,
+		&github.ListWorkflowJobsOptions{Filter: filter, ListOptions: page})
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the jobs of the workflow run
+	response.StatusOKPage(c, jobs.Jobs, resp)
+}
+
+// DownloadJobLogs handles downloading the logs of a workflow job.
+// It expects the token, username and repoName parameters and the job ID. The plain-text logs are streamed.
+//
+// Responses:
+//   - 200 OK: With the logs.
+//   - 400 Bad Request: If a parameter is missing or the job ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the job does not exist, or its logs were deleted.
+func DownloadJobLogs(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	jobID, ok := intParam(c, "jobID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	logs, err := models.DownloadJobLogs(c, client, params["username"], params["repoName"], jobID)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	defer logs.Body.Close()
+
+	contentType := logs.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain; charset=utf-8"
+	}
+	// Response: 200 OK with the logs of the job
+	c.DataFromReader(http.StatusOK, logs.ContentLength, contentType, logs.Body, nil)
+}
+
+// RerunWorkflowRun handles re-running a workflow run.
+// It expects the token, username and repoName parameters and the run ID, and accepts a
+// models.RerunRequest body; with failed_only, only the failed jobs and their dependents are re-run.
+//
+// Responses:
+//   - 202 Accepted: With the run ID, once the run is queued again.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is malformed.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the run cannot be re-run, e.g. because it is too old.
+//   - 404 Not Found: If the repository or the run does not exist.
+func RerunWorkflowRun(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	runID, ok := intParam(c, "runID")
+	if !ok {
+		return
+	}
+	var req models.RerunRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if err := models.RerunWorkflowRun(c, client, params["username"], params["repoName"], runID, req); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 202 Accepted once the workflow run is queued again
+	response.StatusAccepted(c, gin.H{"run_id": runID, "failed_only": req.FailedOnly})
+}
+
+// CancelWorkflowRun handles cancelling a workflow run.
+// It expects the token, username and repoName parameters and the run ID.
+//
+// Responses:
+//   - 202 Accepted: With the run ID, once the cancellation is requested.
+//   - 400 Bad Request: If a parameter is missing or the run ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the run does not exist.
+//   - 409 Conflict: If the run is already completed.
+func CancelWorkflowRun(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	runID, ok := intParam(c, "runID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	_, err := client.CancelWorkflowRunByID(c, params["username"], params["repoName"], runID)
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 202 Accepted once the cancellation is requested
+	response.StatusAccepted(c, gin.H{"run_id": runID})
+}
+
+// ListArtifacts handles the retrieval of the artifacts of a repository, or of a workflow run
+// when the run ID is given. It expects the token, username and repoName parameters. Results are
+// paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the artifacts.
+//   - 400 Bad Request: If a parameter is missing or invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the run does not exist.
+func ListArtifacts(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var runID int64
+	if c.Param("runID") != "" {
+		if runID, ok = intParam(c, "runID"); !ok {
+			return
+		}
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	var artifacts *github.ArtifactList
+	var resp *github.Response
+	var err error
+	if runID != 0 {
+		artifacts, resp, err = client.ListWorkflowRunArtifacts(c, params["username"], params["repoName"], runID, &opts)
+	} else {
+		artifacts, resp, err = client.ListArtifacts(c, params["username"], params["repoName"], &opts)
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the artifacts
+	response.StatusOKPage(c, artifacts.Artifacts, resp)
+}
+
+// DownloadArtifact handles downloading an artifact.
+// It expects the token, username and repoName parameters and the artifact ID. The zip archive
+// of the artifact is streamed as an attachment.
+//
+// Responses:
+//   - 200 OK: With the archive.
+//   - 400 Bad Request: If a parameter is missing or the artifact ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the artifact does not exist.
+//   - 422 Unprocessable Entity: If the artifact has expired.
+func DownloadArtifact(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	artifactID, ok := intParam(c, "artifactID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	artifact, archive, err := models.DownloadArtifact(c, client, params["username"], params["repoName"], artifactID)
+	if errors.Is(err, models.ErrArtifactExpired) {
+		// Response: 422 Unprocessable Entity if the artifact has expired
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	defer archive.Body.Close()
+
+	// Response: 200 OK with the archive of the artifact
+	c.DataFromReader(http.StatusOK, archive.ContentLength, "application/zip", archive.Body, map[string]string{
+		"Content-Disposition": "attachment; filename=" + strconv.Quote(artifact.GetName()+".zip"),
+	})
+}
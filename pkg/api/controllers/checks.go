@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"github-api/pkg/auth"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"strings"
+	"time"
+)
+
+// CreateStatus handles creating a commit status as the GitHub App.
+// It expects the username, repoName and sha parameters and a models.StatusRequest body.
+// The request must carry GITHUB_APP_API_KEY as a bearer token.
+//
+// Responses:
+//   - 201 Created: With the status.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the API key is missing or wrong, or none is configured.
+//   - 404 Not Found: If the App is not installed on the repository.
+//   - 422 Unprocessable Entity: If no App is configured or the commit does not exist.
+func CreateStatus(c *gin.Context) {
+	params, ok := requireParams(c, "username", "repoName", "sha")
+	if !ok {
+		return
+	}
+	var req models.StatusRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := appClient(c, params["username"], params["repoName"])
+	if !ok {
+		return
+	}
+
+	status, err := models.CreateStatus(c, client, params["username"], params["repoName"], params["sha"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the status is successfully created
+	response.StatusCreated(c, status)
+}
+
+// CreateCheckRun handles creating a check run as the GitHub App.
+// It expects the username and repoName parameters and a models.CheckRunRequest body. Annotations
+// beyond the 50 GitHub accepts per request are added in further requests. A concluded check run
+// without completed_at completes now. The request must carry GITHUB_APP_API_KEY as a bearer token.
+//
+// Responses:
+//   - 201 Created: With the check run.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the API key is missing or wrong, or none is configured.
+//   - 404 Not Found: If the App is not installed on the repository.
+//   - 422 Unprocessable Entity: If no App is configured, the status contradicts the conclusion, or the commit does not exist.
+//   - 500 Internal Server Error: If a batch of annotations fails after the check run is created, with
+//     the check run, its id and the number of annotations added in annotations_sent.
+func CreateCheckRun(c *gin.Context) {
+	params, ok := requireParams(c, "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.CheckRunRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := appClient(c, params["username"], params["repoName"])
+	if !ok {
+		return
+	}
+
+	run, err := models.CreateCheckRun(c, client, params["username"], params["repoName"], req, time.Now())
+	if err != nil {
+		handleCheckRunError(c, run, err)
+		return
+	}
+
+	// Response: 201 Created if the check run is successfully created
+	response.StatusCreated(c, run)
+}
+
+// UpdateCheckRun handles updating a check run as the GitHub App.
+// It expects the username, repoName and id parameters and a models.CheckRunUpdate body.
+// Annotations are added to the ones of the check run, in batches of 50. The request must carry
+// GITHUB_APP_API_KEY as a bearer token.
+//
+// Responses:
+//   - 200 OK: With the check run.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the API key is missing or wrong, or none is configured.
+//   - 404 Not Found: If the App is not installed on the repository or the check run does not exist.
+//   - 422 Unprocessable Entity: If no App is configured or the status contradicts the conclusion.
+//   - 500 Internal Server Error: If a batch of annotations fails after the check run is updated, with
+//     the check run, its id and the number of annotations added in annotations_sent.
+func UpdateCheckRun(c *gin.Context) {
+	params, ok := requireParams(c, "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	var req models.CheckRunUpdate
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := appClient(c, params["username"], params["repoName"])
+	if !ok {
+		return
+	}
+
+	run, err := models.UpdateCheckRun(c, client, params["username"], params["repoName"], id, req, time.Now())
+	if err != nil {
+		handleCheckRunError(c, run, err)
+		return
+	}
+
+	// Response: 200 OK if the check run is successfully updated
+	response.StatusOK(c, run)
+}
+
+// appClient creates a client acting as the installation of the GitHub App on a repository, after
+// checking the API key of the request. Without a configured API key, every request is refused. If
+// no App is configured, the key is wrong or the App is not installed on the repository, the
+// response is sent.
+func appClient(c *gin.Context, owner, repo string) (interfaces.GitHubClient, bool) {
+	app := auth.DefaultApp()
+	if app == nil {
+		// Response: 422 Unprocessable Entity if no GitHub App is configured
+		response.StatusUnprocessableEntity(c, errors.New("publishing checks requires GITHUB_APP_ID and a private key"))
+		return nil, false
+	}
+	key, _ := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if app.APIKey == "" || subtle.ConstantTimeCompare([]byte(key), []byte(app.APIKey)) != 1 {
+		// Response: 401 Unauthorized if the API key is missing or wrong, or none is configured
+		response.StatusUnauthorized(c)
+		return nil, false
+	}
+	client, err := app.InstallationClient(c, owner, repo)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return nil, false
+	}
+	return client, true
+}
+
+// handleCheckRunError sends the response matching an error returned by models.CreateCheckRun or
+// models.UpdateCheckRun, with the check run they returned.
+func handleCheckRunError(c *gin.Context, run *github.CheckRun, err error) {
+	if errors.Is(err, models.ErrInvalidCheckRun) {
+		// Response: 422 Unprocessable Entity if the status contradicts the conclusion
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	var partial *models.AnnotationsError
+	if errors.As(err, &partial) {
+		// Response: 500 Internal Server Error with the check run ID and the annotations added, if a batch of annotations failed
+		response.StatusInternalServerErrorData(c, err, gin.H{"id": run.GetID(), "annotations_sent": partial.Sent, "check_run": run})
+		return
+	}
+	response.HandleGithubErrors(c, err)
+}
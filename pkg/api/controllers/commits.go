@@ -0,0 +1,276 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github-api/pkg/workspace"
+	"github.com/gin-gonic/gin"
+)
+
+// ListCommits handles the retrieval of the commits of a repository.
+// It expects the token, username and repoName parameters and accepts the sha (branch, tag or
+// commit to start from), path, author, since and until query parameters. since and until accept
+// an RFC 3339 timestamp or a YYYY-MM-DD date. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the commits are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the starting ref does not exist.
+//   - 422 Unprocessable Entity: If a date or the range is invalid.
+func ListCommits(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	var filter models.CommitFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		// Response: 400 Bad Request if the query string cannot be parsed
+		response.StatusBadRequest(c)
+		return
+	}
+	opt, err := filter.ToOptions(page)
+	if err != nil {
+		// Response: 422 Unprocessable Entity if a date or the range is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	commits, resp, err := client.ListCommits(c, params["username"], params["repoName"], opt)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the commits are successfully retrieved
+	response.StatusOKPage(c, commits, resp)
+}
+
+// GetCommit handles the retrieval of a single commit with its stats and changed files.
+// It expects the token, username and repoName parameters and the sha of the commit, which
+// may also be a branch or a tag. The changed files are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the commit is successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the commit does not exist.
+func GetCommit(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "sha")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	commit, _, err := client.GetCommit(c, params["username"], params["repoName"], params["sha"], &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the commit is successfully retrieved
+	response.StatusOK(c, commit)
+}
+
+// CreateCommit handles creating a single commit that adds, modifies and deletes several files.
+// It expects the token, username and repoName parameters and a JSON body with the branch, the
+// commit message, the files and optionally the author and committer. Each file has a path and
+// either a content (plain text, or base64 with "encoding": "base64") or "delete": true. The
+// branch is only fast-forwarded; if it moves meanwhile, the commit is rebuilt on its new head.
+//
+// Responses:
+//   - 201 Created: With the created commit.
+//   - 400 Bad Request: If a parameter, the branch, the message or the files are missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the branch does not exist.
+//   - 409 Conflict: If the branch kept moving and the commit could not be applied.
+//   - 422 Unprocessable Entity: If a path, encoding, mode or content is invalid, or a deleted file does not exist.
+func CreateCommit(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.CommitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if missing := req.Missing(); len(missing) > 0 {
+		// Response: 400 Bad Request if the branch, the message or the files are missing
+		response.StatusBadRequestMissingParams(c, missing)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if a file is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	commit, err := models.CreateCommit(c, client, params["username"], params["repoName"], req)
+	if errors.Is(err, models.ErrBranchMoved) {
+		// Response: 409 Conflict if the branch kept moving
+		response.StatusConflictError(c, err)
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the commit is successfully created
+	response.StatusCreated(c, commit)
+}
+
+// CompareCommits handles comparing two refs.
+// It expects the token, username and repoName parameters and the base and head query
+// parameters, each a branch, tag or commit. The comparison includes the ahead and behind
+// counts, the commits and the changed files; commits are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the comparison is successfully retrieved.
+//   - 400 Bad Request: If a parameter, base or head is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or a ref does not exist.
+func CompareCommits(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	base, head, ok := compareRange(c)
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	comparison, resp, err := client.CompareCommits(c, params["username"], params["repoName"], base, head, &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the comparison is successfully retrieved
+	response.StatusOKPage(c, comparison, resp)
+}
+
+// Changelog handles generating the changelog of the commits between two refs.
+// It expects the token, username and repoName parameters and the base and head query parameters.
+// The format query parameter selects markdown (default) or json. The source query parameter
+// selects api, which links each commit to its pull request through the REST API, or git, which
+// reads a local clone kept in the workspace configured by GIT_WORKSPACE_DIR and costs no API
+// request per commit. source defaults to git when a workspace is configured.
+//
+// Responses:
+//   - 200 OK: With the changelog, as Markdown or JSON.
+//   - 400 Bad Request: If a parameter, base or head is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or a ref does not exist.
+//   - 422 Unprocessable Entity: If the format or the source is invalid, or no workspace is configured.
+func Changelog(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	base, head, ok := compareRange(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "markdown")
+	if format != "markdown" && format != "json" {
+		// Response: 422 Unprocessable Entity if the format is invalid
+		response.StatusUnprocessableEntity(c, fmt.Errorf("invalid format %q: must be one of markdown, json", format))
+		return
+	}
+	ws := workspace.Default()
+	source := models.ChangelogSourceAPI
+	if ws != nil {
+		source = models.ChangelogSourceGit
+	}
+	source = c.DefaultQuery("source", source)
+	switch {
+	case source != models.ChangelogSourceAPI && source != models.ChangelogSourceGit:
+		// Response: 422 Unprocessable Entity if the source is invalid
+		response.StatusUnprocessableEntity(c, fmt.Errorf("invalid source %q: must be one of api, git", source))
+		return
+	case source == models.ChangelogSourceGit && ws == nil:
+		// Response: 422 Unprocessable Entity if no workspace is configured
+		response.StatusUnprocessableEntity(c, errors.New("the git source requires GIT_WORKSPACE_DIR"))
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	var changelog models.Changelog
+	var err error
+	if source == models.ChangelogSourceGit {
+		clone, syncErr := ws.Sync(c, params["username"], params["repoName"], params["token"])
+		if syncErr != nil {
+			// Response: 500 Internal Server Error if the clone cannot be updated
+			response.StatusInternalServerError(c, syncErr)
+			return
+		}
+		changelog, err = models.BuildChangelogFromClone(clone, params["username"], params["repoName"], base, head)
+	} else {
+		changelog, err = models.BuildChangelog(c, client, params["username"], params["repoName"], base, head)
+	}
+	if errors.Is(err, models.ErrUnknownRevision) {
+		// Response: 404 Not Found if a ref does not exist in the clone
+		response.StatusNotFound(c)
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	if format == "json" {
+		// Response: 200 OK with the changelog as JSON
+		response.StatusOK(c, changelog)
+		return
+	}
+	// Response: 200 OK with the changelog as Markdown
+	response.StatusOKMarkdown(c, changelog.Markdown())
+}
+
+// compareRange reads the base and head query parameters of a comparison.
+// If either is missing, a 400 Bad Request response is sent.
+func compareRange(c *gin.Context) (string, string, bool) {
+	base, head := c.Query("base"), c.Query("head")
+	if base == "" || head == "" {
+		// Response: 400 Bad Request if the range is missing
+		response.StatusBadRequestMissingParams(c, []string{"base", "head"})
+		return "", "", false
+	}
+	return base, head, true
+}
@@ -0,0 +1,256 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GetContents handles reading a file or a directory of a repository.
+// It expects the token, username and repoName parameters and the path of the file or directory,
+// empty for the root directory. The ref query parameter selects the branch, tag or commit to read
+// and defaults to the default branch. A file is returned with its base64 content and blob SHA,
+// which is needed to update or delete it; with raw=true its content is returned as is instead.
+//
+// Responses:
+//   - 200 OK: With the file, the entries of the directory, or the raw content of the file.
+//   - 400 Bad Request: If a parameter is missing or raw is not a boolean.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository, the ref or the path does not exist.
+func GetContents(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	path := strings.Trim(c.Param("path"), "/")
+	raw, err := strconv.ParseBool(c.DefaultQuery("raw", "false"))
+	if err != nil {
+		// Response: 400 Bad Request if raw is not a boolean
+		response.StatusBadRequestMissingParams(c, []string{"raw"})
+		return
+	}
+	opts := &github.RepositoryContentGetOptions{Ref: c.Query("ref")}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if raw {
+		content, _, err := client.DownloadContents(c, params["username"], params["repoName"], path, opts)
+		if err != nil {
+			response.HandleGithubErrors(c, err)
+			return
+		}
+		defer content.Close()
+		// Response: 200 OK with the raw content of the file
+		c.DataFromReader(http.StatusOK, -1, "application/octet-stream", content, nil)
+		return
+	}
+
+	file, dir, _, err := client.GetContents(c, params["username"], params["repoName"], path, opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	if file != nil {
+		// Response: 200 OK with the file
+		response.StatusOK(c, file)
+		return
+	}
+	// Response: 200 OK with the entries of the directory
+	response.StatusOK(c, dir)
+}
+
+// CreateFile handles creating a file with a new commit.
+// It expects the token, username and repoName parameters, the path of the file and a JSON body
+// with the commit message, the content (plain text, or base64 with "encoding": "base64") and
+// optionally the branch, author and committer. An existing file is never overwritten.
+//
+// Responses:
+//   - 201 Created: With the created file and commit.
+//   - 400 Bad Request: If a parameter, the path or the message is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the branch does not exist.
+//   - 409 Conflict: If a file already exists at the path.
+//   - 422 Unprocessable Entity: If the encoding or the content is invalid.
+func CreateFile(c *gin.Context) {
+	params, path, req, ok := bindFileChange(c, false)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, err := models.CreateFile(c, client, params["username"], params["repoName"], path, req)
+	if err != nil {
+		handleContentError(c, err)
+		return
+	}
+
+	// Response: 201 Created if the file is successfully created
+	response.StatusCreated(c, created)
+}
+
+// UpdateFile handles replacing the content of a file with a new commit.
+// It expects the token, username and repoName parameters, the path of the file and a JSON body
+// with the commit message, the new content, the sha of the file as last read and optionally the
+// encoding, branch, author and committer. The update is refused if the file has changed since.
+//
+// Responses:
+//   - 200 OK: With the updated file and commit.
+//   - 400 Bad Request: If a parameter, the path, the message or the sha is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository, the branch or the file does not exist.
+//   - 409 Conflict: If the sha is stale because the file has changed.
+//   - 422 Unprocessable Entity: If the encoding or the content is invalid, or the path is a directory.
+func UpdateFile(c *gin.Context) {
+	params, path, req, ok := bindFileChange(c, true)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, err := models.UpdateFile(c, client, params["username"], params["repoName"], path, req)
+	if err != nil {
+		handleContentError(c, err)
+		return
+	}
+
+	// Response: 200 OK if the file is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// DeleteFile handles deleting a file with a new commit.
+// It expects the token, username and repoName parameters, the path of the file and a JSON body
+// with the commit message, the sha of the file as last read and optionally the branch, author
+// and committer. The deletion is refused if the file has changed since.
+//
+// Responses:
+//   - 200 OK: With the commit deleting the file.
+//   - 400 Bad Request: If a parameter, the path, the message or the sha is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository, the branch or the file does not exist.
+//   - 409 Conflict: If the sha is stale because the file has changed.
+//   - 422 Unprocessable Entity: If the path is a directory.
+func DeleteFile(c *gin.Context) {
+	params, path, req, ok := bindFileChange(c, true)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	deleted, err := models.DeleteFile(c, client, params["username"], params["repoName"], path, req)
+	if err != nil {
+		handleContentError(c, err)
+		return
+	}
+
+	// Response: 200 OK if the file is successfully deleted
+	response.StatusOK(c, deleted)
+}
+
+// DownloadArchive handles downloading an archive of a repository.
+// It expects the token, username and repoName parameters and the format, tarball or zipball.
+// The ref query parameter selects the branch, tag or commit to archive and defaults to the
+// default branch. The archive is streamed as an attachment.
+//
+// Responses:
+//   - 200 OK: With the archive.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the ref does not exist.
+//   - 422 Unprocessable Entity: If the format is invalid.
+func DownloadArchive(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "format")
+	if !ok {
+		return
+	}
+	format := github.ArchiveFormat(params["format"])
+	if format != github.Tarball && format != github.Zipball {
+		// Response: 422 Unprocessable Entity if the format is invalid
+		response.StatusUnprocessableEntity(c, fmt.Errorf("invalid format %q: must be one of tarball, zipball", format))
+		return
+	}
+	ref := c.Query("ref")
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	archive, err := models.DownloadArchive(c, client, params["username"], params["repoName"], format, ref)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	defer archive.Body.Close()
+
+	contentType := archive.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	filename := models.ArchiveFilename(params["repoName"], format, ref)
+	// Response: 200 OK with the archive
+	c.DataFromReader(http.StatusOK, archive.ContentLength, contentType, archive.Body, map[string]string{
+		"Content-Disposition": "attachment; filename=" + strconv.Quote(filename),
+	})
+}
+
+// bindFileChange reads the path parameters and the JSON body of a file change.
+// If a parameter or a required field is missing, or the body is invalid, a response is sent.
+func bindFileChange(c *gin.Context, requireSHA bool) (map[string]string, string, models.FileChangeRequest, bool) {
+	var req models.FileChangeRequest
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return nil, "", req, false
+	}
+	path := strings.Trim(c.Param("path"), "/")
+	if path == "" {
+		// Response: 400 Bad Request if the path is missing
+		response.StatusBadRequestMissingParams(c, []string{"path"})
+		return nil, "", req, false
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return nil, "", req, false
+	}
+	if missing := req.Missing(requireSHA); len(missing) > 0 {
+		// Response: 400 Bad Request if the message or the sha is missing
+		response.StatusBadRequestMissingParams(c, missing)
+		return nil, "", req, false
+	}
+	if _, err := req.Options(); err != nil {
+		// Response: 422 Unprocessable Entity if the encoding or the content is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return nil, "", req, false
+	}
+	return params, path, req, true
+}
+
+// handleContentError sends the response of a failed file change.
+func handleContentError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrStaleContent), errors.Is(err, models.ErrContentExists):
+		// Response: 409 Conflict if the file has changed or already exists
+		response.StatusConflictError(c, err)
+	case errors.Is(err, models.ErrNotAFile):
+		// Response: 422 Unprocessable Entity if the path is not a file
+		response.StatusUnprocessableEntity(c, err)
+	default:
+		response.HandleGithubErrors(c, err)
+	}
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"bytes"
 	"errors"
+	"github-api/pkg/auth"
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
@@ -145,3 +146,38 @@ func TestCreateRepo(t *testing.T) {
 		})
 	}
 }
+
+// TestAppEndpointsRequireAPIKey tests that the endpoints acting as the GitHub App refuse every
+// request when no API key is configured, and requests without the configured key otherwise.
+func TestAppEndpointsRequireAPIKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/statuses/:username/:repoName/:sha", CreateStatus)
+	defer auth.SetDefaultApp(auth.DefaultApp())
+
+	tests := []struct {
+		name          string
+		apiKey        string
+		authorization string
+	}{
+		{name: "No API key configured", apiKey: "", authorization: ""},
+		{name: "No API key configured, empty bearer", apiKey: "", authorization: "Bearer "},
+		{name: "Missing API key", apiKey: "secret", authorization: ""},
+		{name: "Wrong API key", apiKey: "secret", authorization: "Bearer wrong"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auth.SetDefaultApp(&auth.App{ID: 42, APIKey: tt.apiKey})
+			req, _ := http.NewRequest(http.MethodPost, "/statuses/octocat/hello/6dcb09b5b57875f334f61aebed695e2e4193db5e", bytes.NewBufferString(`{"state": "success"}`))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"errors"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+)
+
+// ListDeployKeys handles the retrieval of the deploy keys of a repository.
+// It expects the token, username and repoName parameters. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the deploy keys.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListDeployKeys(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	keys, resp, err := client.ListKeys(c, params["username"], params["repoName"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the deploy keys of the repository
+	response.StatusOKPage(c, keys, resp)
+}
+
+// AddDeployKey handles adding a deploy key to a repository.
+// It expects the token, username and repoName parameters and a models.DeployKeyRequest body with
+// either a public key or generate. A generated private key is returned in this response only; it
+// is not stored, so the response is marked as not cacheable.
+//
+// Responses:
+//   - 201 Created: With the deploy key, and its private key if it was generated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the public key is invalid or already in use.
+func AddDeployKey(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.DeployKeyRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	key, err := models.AddDeployKey(c, client, params["username"], params["repoName"], req)
+	if errors.Is(err, models.ErrInvalidDeployKey) {
+		// Response: 422 Unprocessable Entity if the public key is missing or invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	// Response: 201 Created if the deploy key is successfully added
+	response.StatusCreated(c, key)
+}
+
+// DeleteDeployKey handles removing a deploy key from a repository.
+// It expects the token, username, repoName and id parameters.
+//
+// Responses:
+//   - 204 No Content: If the deploy key is successfully removed.
+//   - 400 Bad Request: If a parameter is missing or invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the deploy key does not exist.
+func DeleteDeployKey(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteKey(c, params["username"], params["repoName"], id); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the deploy key is successfully removed
+	response.StatusNoContent(c)
+}
+
+// RotateDeployKeys handles rotating a deploy key across repositories.
+// It expects the token parameter and a models.RotateKeysRequest body with the repos and the title
+// of the key. Each repository receives a new generated key, then loses its previous keys with the
+// same title. The private keys are returned in this response only, including for repositories
+// where removing a previous key failed after the new key was added.
+//
+// Responses:
+//   - 200 OK: With the rotation of each repository.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+func RotateDeployKeys(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	var req models.RotateKeysRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	rotations := req.Apply(c, client)
+
+	c.Header("Cache-Control", "no-store")
+	// Response: 200 OK with the rotation of each repository
+	response.StatusOK(c, rotations)
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"errors"
+	"github-api/pkg/events"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+)
+
+// CreateFork handles forking a repository.
+// It expects the token, username and repoName parameters of the upstream repository and accepts
+// a JSON body with the organization to fork into, the name of the fork and default_branch_only.
+// GitHub creates forks in the background; the response is sent once the fork is reachable.
+//
+// Responses:
+//   - 201 Created: With the fork.
+//   - 202 Accepted: With the fork as announced by GitHub, if it is not reachable yet.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the repository or the organization does not allow forking.
+//   - 404 Not Found: If the repository does not exist.
+func CreateFork(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.ForkRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	fork, err := models.CreateFork(c, client, params["username"], params["repoName"], req)
+	if errors.Is(err, models.ErrMovePending) {
+		// Response: 202 Accepted if the fork is not reachable yet
+		response.StatusAccepted(c, fork)
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	events.Publish(events.RepoCreated, fork.GetOwner().GetLogin(), fork.GetName(), fork)
+
+	// Response: 201 Created if the fork is successfully created
+	response.StatusCreated(c, fork)
+}
+
+// ListForks handles the retrieval of the forks of a repository.
+// It expects the token, username and repoName parameters and accepts the sort query parameter
+// (newest, oldest, stargazers or watchers). With divergence=true, the default branch of each fork
+// is compared with the default branch of the repository. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the forks.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListForks(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	opts := &github.RepositoryListForksOptions{Sort: c.Query("sort"), ListOptions: page}
+	forks, resp, err := models.ListForks(c, client, params["username"], params["repoName"], opts, c.Query("divergence") == "true")
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the forks are successfully retrieved
+	response.StatusOKPage(c, forks, resp)
+}
+
+// ForkDivergence handles comparing a branch of a fork with its upstream repository.
+// It expects the token, username and repoName parameters of the fork and accepts the branch
+// query parameter; the default branches of the fork and its upstream are compared otherwise.
+//
+// Responses:
+//   - 200 OK: With the status, ahead_by and behind_by of the branch.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the branch does not exist.
+//   - 422 Unprocessable Entity: If the repository is not a fork.
+func ForkDivergence(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	divergence, err := models.CompareFork(c, client, params["username"], params["repoName"], c.Query("branch"))
+	if err != nil {
+		handleForkError(c, err)
+		return
+	}
+
+	// Response: 200 OK with the divergence of the fork
+	response.StatusOK(c, divergence)
+}
+
+// SyncFork handles syncing a branch of a fork with its upstream repository.
+// It expects the token, username and repoName parameters of the fork and accepts a JSON body
+// with the branch to sync, the default branch of the fork otherwise. A branch behind its upstream
+// is fast-forwarded, and one with commits of its own is merged with the upstream branch.
+//
+// Responses:
+//   - 200 OK: With the merge type of the sync and the divergence that remains.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the branch does not exist.
+//   - 409 Conflict: If the branch cannot be synced without conflicts, with its divergence.
+//   - 422 Unprocessable Entity: If the repository is not a fork.
+func SyncFork(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.SyncRequest
+	if c.Request.ContentLength > 0 && !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	sync, err := models.SyncFork(c, client, params["username"], params["repoName"], req.Branch)
+	if err != nil {
+		handleForkError(c, err)
+		return
+	}
+
+	// Response: 200 OK if the branch is synced
+	response.StatusOK(c, sync)
+}
+
+// handleForkError sends the response matching an error returned by models.CompareFork or models.SyncFork.
+func handleForkError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrNotAFork):
+		// Response: 422 Unprocessable Entity if the repository is not a fork
+		response.StatusUnprocessableEntity(c, err)
+	case errors.Is(err, models.ErrSyncConflict):
+		// Response: 409 Conflict if the branch cannot be synced without conflicts
+		response.StatusConflictError(c, err)
+	default:
+		response.HandleGithubErrors(c, err)
+	}
+}
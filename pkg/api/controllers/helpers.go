@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"github-api/pkg/auth"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/response"
+	"github-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"strconv"
+)
+
+// maxPerPage is the largest page size accepted by the GitHub API.
+const maxPerPage = 100
+
+// bindJSON decodes the JSON payload of the request into obj and validates it against its binding tags.
+// If the payload is malformed, a 400 Bad Request response is sent; if fields are invalid, the
+// 400 Bad Request response lists them.
+//
+// Parameters:
+//   - c: The Gin context.
+//   - obj: A pointer to the request to decode.
+//
+// Returns:
+//   - bool: False if the payload is invalid and a response was already sent.
+func bindJSON(c *gin.Context, obj any) bool {
+	err := c.ShouldBindJSON(obj)
+	if err == nil {
+		return true
+	}
+	if fields, ok := validation.Fields(err); ok {
+		// Response: 400 Bad Request listing the invalid fields
+		response.StatusBadRequestFields(c, fields)
+		return false
+	}
+	// Response: 400 Bad Request if the payload is malformed
+	response.StatusBadRequest(c)
+	return false
+}
+
+// requireParams extracts the given path parameters from the request.
+// If any of them is missing, a 400 Bad Request response listing them is sent.
+//
+// Parameters:
+//   - c: The Gin context.
+//   - keys: The names of the required path parameters.
+//
+// Returns:
+//   - map[string]string: The parameter values by name.
+//   - bool: False if a parameter is missing and a response was already sent.
+func requireParams(c *gin.Context, keys ...string) (map[string]string, bool) {
+	params := make(map[string]string, len(keys))
+	var missingParams []string
+	for _, key := range keys {
+		params[key] = c.Param(key)
+		if params[key] == "" {
+			missingParams = append(missingParams, key)
+		}
+	}
+	if len(missingParams) > 0 {
+		// Response: 400 Bad Request if the token or parameters are missing
+		response.StatusBadRequestMissingParams(c, missingParams)
+		return nil, false
+	}
+	return params, true
+}
+
+// githubClient creates a GitHub client for the given token.
+// If the token is invalid, the GitHub error is sent as the response.
+//
+// Parameters:
+//   - c: The Gin context.
+//   - token: The GitHub access token.
+//
+// Returns:
+//   - interfaces.GitHubClient: The authenticated client.
+//   - bool: False if authentication failed and a response was already sent.
+func githubClient(c *gin.Context, token string) (interfaces.GitHubClient, bool) {
+	client, err := auth.GetClient(token)
+	if err != nil {
+		// Response: 401 Unauthorized if the token is invalid
+		response.HandleGithubErrors(c, err)
+		return nil, false
+	}
+	return client, true
+}
+
+// intParam parses a numeric path parameter such as an issue number.
+// If the value is not a positive integer, a 400 Bad Request response is sent.
+//
+// Parameters:
+//   - c: The Gin context.
+//   - key: The name of the path parameter.
+//
+// Returns:
+//   - int64: The parsed value.
+//   - bool: False if the value is invalid and a response was already sent.
+func intParam(c *gin.Context, key string) (int64, bool) {
+	value, err := strconv.ParseInt(c.Param(key), 10, 64)
+	if err != nil || value <= 0 {
+		// Response: 400 Bad Request if the parameter is not a valid number
+		response.StatusBadRequestMissingParams(c, []string{key})
+		return 0, false
+	}
+	return value, true
+}
+
+// listOptions reads the page and per_page query parameters used by every list endpoint.
+// If either value is not a positive integer, a 400 Bad Request response is sent.
+// per_page is capped at the GitHub maximum of 100.
+//
+// Parameters:
+//   - c: The Gin context.
+//
+// Returns:
+//   - github.ListOptions: The pagination options to pass to the GitHub API.
+//   - bool: False if a value is invalid and a response was already sent.
+func listOptions(c *gin.Context) (github.ListOptions, bool) {
+	var opt github.ListOptions
+	for key, target := range map[string]*int{"page": &opt.Page, "per_page": &opt.PerPage} {
+		value := c.Query(key)
+		if value == "" {
+			continue
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			// Response: 400 Bad Request if the pagination parameters are invalid
+			response.StatusBadRequestMissingParams(c, []string{key})
+			return github.ListOptions{}, false
+		}
+		*target = n
+	}
+	if opt.PerPage > maxPerPage {
+		opt.PerPage = maxPerPage
+	}
+	return opt, true
+}
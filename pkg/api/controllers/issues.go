@@ -0,0 +1,444 @@
+package controllers
+
+import (
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+)
+
+// ListIssues handles the retrieval of the issues of a repository.
+// It expects the following parameters:
+//   - token: The authentication token for the GitHub API.
+//   - username: The GitHub username who owns the repository.
+//   - repoName: The name of the repository whose issues are to be listed.
+//
+// The following query parameters filter the issues: state (open, closed, all), labels
+// (comma-separated), assignee, milestone, creator, mentioned, since, sort and direction.
+// Results are paginated with page and per_page. Pull requests are not included.
+//
+// Responses:
+//   - 200 OK: If the issues are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the specified repository does not exist.
+//   - 422 Unprocessable Entity: If a filter value is invalid.
+func ListIssues(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	filter, err := models.IssueFilterFromContext(c)
+	if err != nil {
+		// Response: 400 Bad Request if the query string cannot be parsed
+		response.StatusBadRequest(c)
+		return
+	}
+	opt, err := filter.ToOptions(page)
+	if err != nil {
+		// Response: 422 Unprocessable Entity if a filter value is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+	issues, resp, err := client.ListIssuesByRepo(c, params["username"], params["repoName"], opt)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the issues are successfully retrieved
+	response.StatusOKPage(c, models.OnlyIssues(issues), resp)
+}
+
+// GetIssue handles the retrieval of a single issue.
+// It expects the token, username and repoName parameters and the issue number.
+//
+// Responses:
+//   - 200 OK: If the issue is successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the issue number is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the issue does not exist.
+func GetIssue(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	issue, _, err := client.GetIssue(c, params["username"], params["repoName"], int(number))
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the issue is successfully retrieved
+	response.StatusOK(c, issue)
+}
+
+// CreateIssue handles the creation of an issue.
+// It expects the token, username and repoName parameters and a JSON body with the title
+// and optionally the body, labels, assignees and milestone of the issue.
+//
+// Responses:
+//   - 201 Created: If the issue is successfully created.
+//   - 400 Bad Request: If a parameter or the title is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the user cannot create issues in the repository.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If GitHub rejects the issue, e.g. for an unknown assignee.
+func CreateIssue(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req github.IssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if req.GetTitle() == "" {
+		// Response: 400 Bad Request if the title is missing
+		response.StatusBadRequestMissingParams(c, []string{"title"})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	issue, _, err := client.CreateIssue(c, params["username"], params["repoName"], &req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the issue is successfully created
+	response.StatusCreated(c, issue)
+}
+
+// UpdateIssue handles editing an issue.
+// It expects the token, username and repoName parameters, the issue number and a JSON body
+// with the fields to change. Fields that are omitted are left unchanged.
+//
+// Responses:
+//   - 200 OK: If the issue is successfully updated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the issue does not exist.
+//   - 422 Unprocessable Entity: If GitHub rejects the changes.
+func UpdateIssue(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	var req github.IssueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	issue, _, err := client.EditIssue(c, params["username"], params["repoName"], int(number), &req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the issue is successfully updated
+	response.StatusOK(c, issue)
+}
+
+// CloseIssue handles closing an issue.
+// An optional JSON body may set state_reason to completed or not_planned.
+//
+// Responses:
+//   - 200 OK: If the issue is successfully closed.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the issue does not exist.
+//   - 422 Unprocessable Entity: If the state reason is invalid.
+func CloseIssue(c *gin.Context) {
+	setIssueState(c, "closed")
+}
+
+// ReopenIssue handles reopening a closed issue.
+// An optional JSON body may set state_reason to reopened.
+//
+// Responses:
+//   - 200 OK: If the issue is successfully reopened.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the issue does not exist.
+//   - 422 Unprocessable Entity: If the state reason is invalid.
+func ReopenIssue(c *gin.Context) {
+	setIssueState(c, "open")
+}
+
+// setIssueState moves an issue to the given state, shared by CloseIssue and ReopenIssue.
+func setIssueState(c *gin.Context, state string) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	var body models.IssueStateRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			// Response: 400 Bad Request if the payload is invalid
+			response.StatusBadRequest(c)
+			return
+		}
+	}
+	req, err := body.ToIssueRequest(state)
+	if err != nil {
+		// Response: 422 Unprocessable Entity if the state reason is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	issue, _, err := client.EditIssue(c, params["username"], params["repoName"], int(number), req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the issue state is successfully changed
+	response.StatusOK(c, issue)
+}
+
+// ListIssueComments handles the retrieval of the comments on an issue.
+// It expects the token, username and repoName parameters and the issue number.
+// Results are paginated with page and per_page, and the since query parameter
+// only returns comments updated after the given time.
+//
+// Responses:
+//   - 200 OK: If the comments are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the issue does not exist.
+//   - 422 Unprocessable Entity: If since is not a valid time.
+func ListIssueComments(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	opt := &github.IssueListCommentsOptions{ListOptions: page}
+	if since := c.Query("since"); since != "" {
+		t, err := models.ParseTime(since)
+		if err != nil {
+			// Response: 422 Unprocessable Entity if since is not a valid time
+			response.StatusUnprocessableEntity(c, err)
+			return
+		}
+		opt.Since = &t
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	comments, resp, err := client.ListIssueComments(c, params["username"], params["repoName"], int(number), opt)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the comments are successfully retrieved
+	response.StatusOKPage(c, comments, resp)
+}
+
+// GetIssueComment handles the retrieval of a single issue comment.
+// It expects the token, username and repoName parameters and the comment ID.
+//
+// Responses:
+//   - 200 OK: If the comment is successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the comment ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the comment does not exist.
+func GetIssueComment(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	commentID, ok := intParam(c, "commentID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	comment, _, err := client.GetIssueComment(c, params["username"], params["repoName"], commentID)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the comment is successfully retrieved
+	response.StatusOK(c, comment)
+}
+
+// CreateIssueComment handles adding a comment to an issue.
+// It expects the token, username and repoName parameters, the issue number and a JSON
+// body with the comment body.
+//
+// Responses:
+//   - 201 Created: If the comment is successfully created.
+//   - 400 Bad Request: If a parameter or the comment body is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the issue is locked or the user cannot comment on it.
+//   - 404 Not Found: If the repository or the issue does not exist.
+func CreateIssueComment(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	comment, ok := bindComment(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, _, err := client.CreateIssueComment(c, params["username"], params["repoName"], int(number), comment)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the comment is successfully created
+	response.StatusCreated(c, created)
+}
+
+// UpdateIssueComment handles editing an issue comment.
+// It expects the token, username and repoName parameters, the comment ID and a JSON body
+// with the new comment body.
+//
+// Responses:
+//   - 200 OK: If the comment is successfully updated.
+//   - 400 Bad Request: If a parameter or the comment body is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the user cannot edit the comment.
+//   - 404 Not Found: If the repository or the comment does not exist.
+func UpdateIssueComment(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	commentID, ok := intParam(c, "commentID")
+	if !ok {
+		return
+	}
+	comment, ok := bindComment(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, _, err := client.EditIssueComment(c, params["username"], params["repoName"], commentID, comment)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the comment is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// DeleteIssueComment handles deleting an issue comment.
+// It expects the token, username and repoName parameters and the comment ID.
+//
+// Responses:
+//   - 204 No Content: If the comment is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing or the comment ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the user cannot delete the comment.
+//   - 404 Not Found: If the repository or the comment does not exist.
+func DeleteIssueComment(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	commentID, ok := intParam(c, "commentID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteIssueComment(c, params["username"], params["repoName"], commentID); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the comment is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// bindComment binds an issue comment from the request body, requiring a non-empty body field.
+func bindComment(c *gin.Context) (*github.IssueComment, bool) {
+	var comment github.IssueComment
+	if err := c.ShouldBindJSON(&comment); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return nil, false
+	}
+	if comment.GetBody() == "" {
+		// Response: 400 Bad Request if the comment body is missing
+		response.StatusBadRequestMissingParams(c, []string{"body"})
+		return nil, false
+	}
+	return &github.IssueComment{Body: comment.Body}, true
+}
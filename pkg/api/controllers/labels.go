@@ -0,0 +1,250 @@
+package controllers
+
+import (
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+)
+
+// ListLabels handles the retrieval of the labels of a repository.
+// It expects the token, username and repoName parameters.
+// Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the labels are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListLabels(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	labels, resp, err := client.ListLabels(c, params["username"], params["repoName"], &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the labels are successfully retrieved
+	response.StatusOKPage(c, labels, resp)
+}
+
+// CreateLabel handles the creation of a label.
+// It expects the token, username and repoName parameters and a JSON body with the
+// name, color and optional description of the label.
+//
+// Responses:
+//   - 201 Created: If the label is successfully created.
+//   - 400 Bad Request: If a parameter, the name or the color is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the label already exists or the color is invalid.
+func CreateLabel(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var label github.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	var missingParams []string
+	if label.GetName() == "" {
+		missingParams = append(missingParams, "name")
+	}
+	if label.GetColor() == "" {
+		missingParams = append(missingParams, "color")
+	}
+	if len(missingParams) > 0 {
+		// Response: 400 Bad Request if the name or color is missing
+		response.StatusBadRequestMissingParams(c, missingParams)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, _, err := client.CreateLabel(c, params["username"], params["repoName"], &github.Label{
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+	})
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the label is successfully created
+	response.StatusCreated(c, created)
+}
+
+// UpdateLabel handles editing a label.
+// It expects the token, username and repoName parameters, the current label name and a
+// JSON body with any of name, color and description. A different name renames the label.
+//
+// Responses:
+//   - 200 OK: If the label is successfully updated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the label does not exist.
+//   - 422 Unprocessable Entity: If the new name is taken or the color is invalid.
+func UpdateLabel(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok {
+		return
+	}
+	var label github.Label
+	if err := c.ShouldBindJSON(&label); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, _, err := client.EditLabel(c, params["username"], params["repoName"], params["name"], &github.Label{
+		Name:        label.Name,
+		Color:       label.Color,
+		Description: label.Description,
+	})
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the label is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// DeleteLabel handles deleting a label.
+// It expects the token, username and repoName parameters and the label name.
+//
+// Responses:
+//   - 204 No Content: If the label is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the label does not exist.
+func DeleteLabel(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteLabel(c, params["username"], params["repoName"], params["name"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the label is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// SyncLabels handles applying a canonical label set to a single repository.
+// It expects the token, username and repoName parameters and a models.LabelSyncRequest body.
+// With dry_run set, the planned changes are returned without being applied.
+//
+// Responses:
+//   - 200 OK: With the planned or applied changes; failed changes carry an error.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the label set is invalid.
+func SyncLabels(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	req, ok := bindLabelSyncRequest(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	// Check if repository exists
+	if _, _, err := client.GetRepositories(c, params["username"], params["repoName"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the planned or applied changes
+	response.StatusOK(c, req.Sync(c, client, params["username"], params["repoName"]))
+}
+
+// SyncAllLabels handles applying a canonical label set to every repository owned by the
+// authenticated user, as returned by ListRepos. Archived repositories are skipped.
+// It expects the token parameter and a models.LabelSyncRequest body.
+//
+// Responses:
+//   - 200 OK: With the planned or applied changes per repository.
+//   - 400 Bad Request: If the token is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If the label set is invalid.
+func SyncAllLabels(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	req, ok := bindLabelSyncRequest(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	repos, err := models.ListAllRepos(c, client, "", &github.RepositoryListOptions{Type: "owner"})
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	results := make([]models.LabelSyncResult, 0, len(repos))
+	for _, repo := range repos {
+		if repo.GetArchived() {
+			continue
+		}
+		results = append(results, req.Sync(c, client, repo.GetOwner().GetLogin(), repo.GetName()))
+	}
+
+	// Response: 200 OK with the planned or applied changes per repository
+	response.StatusOK(c, results)
+}
+
+// bindLabelSyncRequest binds and validates the canonical label set from the request body.
+func bindLabelSyncRequest(c *gin.Context) (*models.LabelSyncRequest, bool) {
+	var req models.LabelSyncRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return nil, false
+	}
+	if err := req.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the label set is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return nil, false
+	}
+	return &req, true
+}
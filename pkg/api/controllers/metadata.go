@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// GetTopics handles the retrieval of the topics of a repository.
+// It expects the token, username and repoName parameters.
+//
+// Responses:
+//   - 200 OK: With the topics.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func GetTopics(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	topics, _, err := client.ListAllTopics(c, params["username"], params["repoName"])
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the topics of the repository
+	response.StatusOK(c, topics)
+}
+
+// ReplaceTopics handles replacing the topics of a repository.
+// It expects the token, username and repoName parameters and a models.TopicsRequest body with
+// the topics; an empty list removes all topics.
+//
+// Responses:
+//   - 200 OK: With the topics of the repository.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ReplaceTopics(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.TopicsRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	topics, _, err := client.ReplaceAllTopics(c, params["username"], params["repoName"], req.Topics)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the topics are successfully replaced
+	response.StatusOK(c, topics)
+}
+
+// GetCustomProperties handles the retrieval of the organization custom property values of a repository.
+// It expects the token, username and repoName parameters.
+//
+// Responses:
+//   - 200 OK: With the values of the set properties, by property name.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist or is not owned by an organization.
+func GetCustomProperties(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	values, _, err := client.GetCustomPropertyValues(c, params["username"], params["repoName"])
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the custom property values of the repository
+	response.StatusOK(c, models.PropertyMap(values))
+}
+
+// UpdateCustomProperties handles writing organization custom property values of a repository.
+// It expects the token, username and repoName parameters and a models.PropertiesRequest body
+// with the values by property name; a null value unsets the property and properties not listed
+// keep their value.
+//
+// Responses:
+//   - 200 OK: With the values of the set properties, by property name.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist or is not owned by an organization.
+//   - 422 Unprocessable Entity: If a property is not defined or a value is not allowed by it.
+func UpdateCustomProperties(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.PropertiesRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	values, err := req.Values()
+	if err != nil {
+		// Response: 400 Bad Request if a property value has an invalid type
+		response.StatusBadRequestFields(c, []validation.FieldError{{Field: "properties", Rule: "type", Message: err.Error()}})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.UpdateCustomPropertyValues(c, params["username"], params["repoName"], values); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	values, _, err = client.GetCustomPropertyValues(c, params["username"], params["repoName"])
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the custom property values are successfully written
+	response.StatusOK(c, models.PropertyMap(values))
+}
+
+// Catalog handles listing the metadata catalog of the repositories of a user or organization.
+// It expects the token and username parameters. Every query parameter filters the catalog:
+// topic, language, visibility, archived and fork select repository fields, and any other key a
+// custom property, as does a key prefixed with "property.". Values are comma-separated and compared
+// ignoring case; a repository matches if, for every key, one of its values is listed.
+//
+// Responses:
+//   - 200 OK: With the matching repositories, their settings, topics and custom property values.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the user or organization does not exist.
+func Catalog(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username")
+	if !ok {
+		return
+	}
+	filter := models.CatalogFilter{}
+	for key := range c.Request.URL.Query() {
+		filter[key] = queryList(c, key)
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	catalog, err := models.BuildCatalog(c, client, params["username"], filter)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the catalog entries
+	response.StatusOK(c, catalog)
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"time"
+)
+
+// ListMilestones handles the retrieval of the milestones of a repository.
+// It expects the token, username and repoName parameters and accepts the state
+// (open, closed, all), sort (due_on, completeness) and direction query parameters.
+// Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the milestones are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If a filter value is invalid.
+func ListMilestones(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	var filter models.MilestoneFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		// Response: 400 Bad Request if the query string cannot be parsed
+		response.StatusBadRequest(c)
+		return
+	}
+	opt, err := filter.ToOptions(page)
+	if err != nil {
+		// Response: 422 Unprocessable Entity if a filter value is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	milestones, resp, err := client.ListMilestones(c, params["username"], params["repoName"], opt)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the milestones are successfully retrieved
+	response.StatusOKPage(c, milestones, resp)
+}
+
+// GetMilestone handles the retrieval of a single milestone.
+// It expects the token, username and repoName parameters and the milestone number.
+//
+// Responses:
+//   - 200 OK: If the milestone is successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the milestone number is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the milestone does not exist.
+func GetMilestone(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	milestone, _, err := client.GetMilestone(c, params["username"], params["repoName"], int(number))
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the milestone is successfully retrieved
+	response.StatusOK(c, milestone)
+}
+
+// CreateMilestone handles the creation of a milestone.
+// It expects the token, username and repoName parameters and a JSON body with the title
+// and optionally the description, state and due_on date of the milestone.
+//
+// Responses:
+//   - 201 Created: If the milestone is successfully created.
+//   - 400 Bad Request: If a parameter or the title is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If a milestone with the same title already exists.
+func CreateMilestone(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	milestone, ok := bindMilestone(c)
+	if !ok {
+		return
+	}
+	if milestone.GetTitle() == "" {
+		// Response: 400 Bad Request if the title is missing
+		response.StatusBadRequestMissingParams(c, []string{"title"})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, _, err := client.CreateMilestone(c, params["username"], params["repoName"], milestone)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the milestone is successfully created
+	response.StatusCreated(c, created)
+}
+
+// UpdateMilestone handles editing a milestone.
+// It expects the token, username and repoName parameters, the milestone number and a JSON
+// body with any of title, description, state and due_on.
+//
+// Responses:
+//   - 200 OK: If the milestone is successfully updated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the milestone does not exist.
+//   - 422 Unprocessable Entity: If GitHub rejects the changes.
+func UpdateMilestone(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	milestone, ok := bindMilestone(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, _, err := client.EditMilestone(c, params["username"], params["repoName"], int(number), milestone)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the milestone is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// DeleteMilestone handles deleting a milestone.
+// It expects the token, username and repoName parameters and the milestone number.
+//
+// Responses:
+//   - 204 No Content: If the milestone is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing or the milestone number is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the milestone does not exist.
+func DeleteMilestone(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteMilestone(c, params["username"], params["repoName"], int(number)); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the milestone is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// MilestoneReport handles the progress report of the milestones of a set of repositories.
+// It expects the token parameter, the repos query parameter listing repositories as
+// owner/name (repeatable or comma-separated) and an optional state (open, closed, all).
+//
+// For each milestone, the report includes the open and closed issue and pull request counts,
+// the percentage complete, the due date and whether it is overdue. Milestones sharing a title
+// across repositories are also aggregated in the summary.
+//
+// Responses:
+//   - 200 OK: With the report; repositories that could not be read are listed in its errors.
+//   - 400 Bad Request: If the token or the repos query parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If the state is invalid.
+func MilestoneReport(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	repos := queryList(c, "repos")
+	if len(repos) == 0 {
+		// Response: 400 Bad Request if no repository is given
+		response.StatusBadRequestMissingParams(c, []string{"repos"})
+		return
+	}
+	state := c.DefaultQuery("state", "open")
+	if _, err := (models.MilestoneFilter{State: state}).ToOptions(github.ListOptions{}); err != nil {
+		// Response: 422 Unprocessable Entity if the state is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	// Response: 200 OK with the milestone progress report
+	response.StatusOK(c, models.BuildMilestoneReport(c, client, repos, state, time.Now()))
+}
+
+// bindMilestone binds the writable fields of a milestone from the request body.
+func bindMilestone(c *gin.Context) (*github.Milestone, bool) {
+	var milestone github.Milestone
+	if err := c.ShouldBindJSON(&milestone); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return nil, false
+	}
+	return &github.Milestone{
+		Title:       milestone.Title,
+		Description: milestone.Description,
+		State:       milestone.State,
+		DueOn:       milestone.DueOn,
+	}, true
+}
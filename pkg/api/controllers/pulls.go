@@ -0,0 +1,438 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"github-api/pkg/events"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"strconv"
+	"time"
+)
+
+// GetPullRequest handles retrieving a single pull request with optional expansions.
+// It expects the token, username and repoName parameters and the pull request number.
+// The repeatable, comma-separated expand query parameter selects the expansions among files,
+// commits, reviews, review_comments, status and checks, or all of them with "all".
+// Expansions are fetched concurrently and follow pagination.
+//
+// Responses:
+//   - 200 OK: If the pull request and its expansions are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the number is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the pull request does not exist.
+//   - 422 Unprocessable Entity: If an expansion is unknown.
+func GetPullRequest(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	expand, err := models.ParseExpansions(queryList(c, "expand"))
+	if err != nil {
+		// Response: 422 Unprocessable Entity if an expansion is unknown
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	detail, err := models.GetPullRequestDetail(c, client, params["username"], params["repoName"], int(number), expand)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the pull request is successfully retrieved
+	response.StatusOK(c, detail)
+}
+
+// PullRequestMetrics handles computing the delivery metrics of the pull requests of several repositories.
+// It expects the token parameter and either the repeatable, comma-separated repos query parameter
+// ("owner/name") or the org and team query parameters to report on the repositories of a team.
+// The since and until query parameters bound the range and default to the last 30 days.
+// The format query parameter selects json (default) or csv; the CSV lists one pull request per row.
+//
+// Responses:
+//   - 200 OK: With the metrics, as JSON or CSV.
+//   - 400 Bad Request: If neither repos nor org and team are given.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the team does not exist.
+//   - 422 Unprocessable Entity: If the range or the format is invalid.
+func PullRequestMetrics(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	repos := queryList(c, "repos")
+	org, team := c.Query("org"), c.Query("team")
+	if len(repos) == 0 && (org == "" || team == "") {
+		// Response: 400 Bad Request if no repository is given
+		response.StatusBadRequestMissingParams(c, []string{"repos", "org", "team"})
+		return
+	}
+	since, until, err := models.ParseDateRange(c.Query("since"), c.Query("until"), time.Now())
+	if err != nil {
+		// Response: 422 Unprocessable Entity if the range is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "csv" {
+		// Response: 422 Unprocessable Entity if the format is invalid
+		response.StatusUnprocessableEntity(c, fmt.Errorf("invalid format %q: must be one of json, csv", format))
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if org != "" && team != "" {
+		teamRepos, err := models.ListTeamRepositories(c, client, org, team)
+		if err != nil {
+			response.HandleGithubErrors(c, err)
+			return
+		}
+		repos = append(repos, teamRepos...)
+	}
+	metrics := models.BuildPullRequestMetrics(c, client, repos, since, until)
+
+	if format == "csv" {
+		// Response: 200 OK with one pull request per CSV row
+		response.StatusOKCSV(c, "pull-request-metrics.csv", metrics.CSV())
+		return
+	}
+	// Response: 200 OK with the pull request metrics
+	response.StatusOK(c, metrics)
+}
+
+// StalePullRequests handles reporting the stale pull requests of several repositories.
+// It expects the token parameter and the repeatable, comma-separated repos query parameter
+// ("owner/name"). The days query parameter sets the inactivity threshold (default 14),
+// exempt_labels lists labels whose pull requests are skipped and include_drafts includes drafts.
+// Open pull requests are flagged when inactive, when checks fail on their head commit, or
+// when reviews are pending.
+//
+// Responses:
+//   - 200 OK: With the flagged pull requests.
+//   - 400 Bad Request: If no repository is given or a parameter is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If the policy is invalid.
+func StalePullRequests(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	repos := queryList(c, "repos")
+	if len(repos) == 0 {
+		// Response: 400 Bad Request if no repository is given
+		response.StatusBadRequestMissingParams(c, []string{"repos"})
+		return
+	}
+	policy := models.StalePolicy{ExemptLabels: queryList(c, "exempt_labels")}
+	days, err := strconv.Atoi(c.DefaultQuery("days", "0"))
+	if err != nil {
+		// Response: 400 Bad Request if days is not a number
+		response.StatusBadRequest(c)
+		return
+	}
+	policy.Days = days
+	policy.IncludeDrafts = c.Query("include_drafts") == "true"
+	if err := policy.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the policy is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	// Response: 200 OK with the stale pull requests
+	response.StatusOK(c, models.FindStalePullRequests(c, client, repos, policy, time.Now()))
+}
+
+// NudgeStalePullRequests handles applying a stale policy to the pull requests of several repositories.
+// It expects the token parameter and a models.StaleRequest body with the repos, the policy and
+// dry_run. Inactive pull requests are commented and labeled, and labeled ones closed after
+// close_days, according to the actions of the policy. With dry_run, the actions are only planned.
+// Every action taken is published as a pr.stale_action event, which the event sinks record as
+// the audit trail.
+//
+// Responses:
+//   - 200 OK: With the flagged pull requests and the actions planned or taken.
+//   - 400 Bad Request: If the payload is invalid or no repository is given.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If the policy is invalid.
+func NudgeStalePullRequests(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	var req models.StaleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if len(req.Repos) == 0 {
+		// Response: 400 Bad Request if no repository is given
+		response.StatusBadRequestMissingParams(c, []string{"repos"})
+		return
+	}
+	if err := req.Policy.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the policy is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	report := req.Apply(c, client, time.Now())
+	for _, action := range report.Actions {
+		if action.Status == models.StaleActionPlanned {
+			continue
+		}
+		owner, repo, _ := models.SplitFullName(action.Repository)
+		events.Publish(events.PullRequestStaleAction, owner, repo, action)
+	}
+
+	// Response: 200 OK with the stale pull requests and the actions
+	response.StatusOK(c, report)
+}
+
+// CreatePullRequest handles opening a pull request.
+// It expects the token, username and repoName parameters and a JSON body with the head
+// and base branches, the title and optionally the body, draft and maintainer_can_modify
+// fields. An existing issue number may be given instead of a title to convert the issue.
+//
+// Responses:
+//   - 201 Created: If the pull request is successfully opened.
+//   - 400 Bad Request: If a parameter, head, base or title is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the user cannot open pull requests in the repository.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If a branch does not exist, there is nothing to merge or the pull request already exists.
+func CreatePullRequest(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var pull github.NewPullRequest
+	if err := c.ShouldBindJSON(&pull); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if missing := models.MissingPullRequestFields(&pull); len(missing) > 0 {
+		// Response: 400 Bad Request if head, base or title is missing
+		response.StatusBadRequestMissingParams(c, missing)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, _, err := client.CreatePullRequest(c, params["username"], params["repoName"], &pull)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	events.Publish(events.PullRequestOpened, params["username"], params["repoName"], created)
+
+	// Response: 201 Created if the pull request is successfully opened
+	response.StatusCreated(c, created)
+}
+
+// UpdatePullRequest handles editing a pull request.
+// It expects the token, username and repoName parameters, the pull request number and a JSON
+// body with any of title, body, state (open, closed), base and maintainer_can_modify.
+//
+// Responses:
+//   - 200 OK: If the pull request is successfully updated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the pull request does not exist.
+//   - 422 Unprocessable Entity: If the state is invalid or GitHub rejects the changes.
+func UpdatePullRequest(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	var req models.PullRequestUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	pull, err := req.ToPullRequest()
+	if err != nil {
+		// Response: 422 Unprocessable Entity if the state is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, _, err := client.EditPullRequest(c, params["username"], params["repoName"], int(number), pull)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	eventType := events.PullRequestEdited
+	switch {
+	case req.State == nil:
+	case *req.State == "closed":
+		eventType = events.PullRequestClosed
+	case *req.State == "open":
+		eventType = events.PullRequestReopened
+	}
+	events.Publish(eventType, params["username"], params["repoName"], updated)
+
+	// Response: 200 OK if the pull request is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// RequestReviewers handles requesting reviews on a pull request.
+// It expects the token, username and repoName parameters, the pull request number and a JSON
+// body with the reviewers (user logins) and team_reviewers (team slugs) to request.
+//
+// Responses:
+//   - 201 Created: If the reviews are successfully requested.
+//   - 400 Bad Request: If a parameter is missing or no reviewer is given.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the pull request does not exist.
+//   - 422 Unprocessable Entity: If a reviewer is not a collaborator or is the author.
+func RequestReviewers(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	var req github.ReviewersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if len(req.Reviewers) == 0 && len(req.TeamReviewers) == 0 {
+		// Response: 400 Bad Request if no reviewer is given
+		response.StatusBadRequestMissingParams(c, []string{"reviewers", "team_reviewers"})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	pull, _, err := client.RequestReviewers(c, params["username"], params["repoName"], int(number), github.ReviewersRequest{
+		Reviewers:     req.Reviewers,
+		TeamReviewers: req.TeamReviewers,
+	})
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the reviews are successfully requested
+	response.StatusCreated(c, pull)
+}
+
+// MergePullRequest handles merging a pull request.
+// It expects the token, username and repoName parameters, the pull request number and a
+// models.PullRequestMergeRequest body with the expected head sha, the merge_method (merge,
+// squash or rebase) and optionally the commit_title and commit_message.
+//
+// The pull request is checked before merging, so a head that moved, merge conflicts and
+// unmergeable states are reported without attempting the merge.
+//
+// Responses:
+//   - 200 OK: If the pull request is successfully merged.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the user cannot merge the pull request.
+//   - 404 Not Found: If the repository or the pull request does not exist.
+//   - 405 Method Not Allowed: If the pull request is closed, a draft, or otherwise not mergeable.
+//   - 409 Conflict: If the head no longer matches sha or the pull request has merge conflicts.
+//   - 422 Unprocessable Entity: If the sha or merge method is invalid.
+func MergePullRequest(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	number, ok := intParam(c, "number")
+	if !ok {
+		return
+	}
+	var req models.PullRequestMergeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the sha or merge method is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	pull, _, err := client.GetPullRequest(c, params["username"], params["repoName"], int(number))
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	if err := models.CheckMergeable(pull, req.SHA); err != nil {
+		handleMergeError(c, err)
+		return
+	}
+
+	result, _, err := client.MergePullRequest(c, params["username"], params["repoName"], int(number), req.CommitMessage, req.Options())
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	pull.Merged = github.Bool(true)
+	pull.MergeCommitSHA = result.SHA
+	events.Publish(events.PullRequestMerged, params["username"], params["repoName"], pull)
+
+	// Response: 200 OK if the pull request is successfully merged
+	response.StatusOK(c, result)
+}
+
+// handleMergeError sends the response matching an error returned by models.CheckMergeable.
+func handleMergeError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, models.ErrHeadChanged), errors.Is(err, models.ErrMergeConflict):
+		// Response: 409 Conflict if the head moved or the pull request has conflicts
+		response.StatusConflictError(c, err)
+	case errors.Is(err, models.ErrNotMergeable):
+		// Response: 405 Method Not Allowed if the pull request cannot be merged
+		response.StatusMethodNotAllowed(c, err)
+	default:
+		response.HandleGithubErrors(c, err)
+	}
+}
@@ -0,0 +1,520 @@
+package controllers
+
+import (
+	"fmt"
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+// ListReleases handles the retrieval of the releases of a repository.
+// It expects the token, username and repoName parameters.
+// Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the releases are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListReleases(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	releases, resp, err := client.ListReleases(c, params["username"], params["repoName"], &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the releases are successfully retrieved
+	response.StatusOKPage(c, releases, resp)
+}
+
+// GetRelease handles the retrieval of a single release.
+// It expects the token, username and repoName parameters and the release ID.
+//
+// Responses:
+//   - 200 OK: If the release is successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the release ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the release does not exist.
+func GetRelease(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	release, _, err := client.GetRelease(c, params["username"], params["repoName"], id)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the release is successfully retrieved
+	response.StatusOK(c, release)
+}
+
+// CreateRelease handles the creation of a release.
+// It expects the token, username and repoName parameters and a JSON body with the tag_name
+// and optionally the target_commitish, name, body, draft, prerelease, make_latest and
+// generate_release_notes fields. When the body is omitted and previous_tag_name is given, the
+// body is generated from the pull requests merged since that tag, grouped by the labels listed
+// in note_groups.
+//
+// Responses:
+//   - 201 Created: If the release is successfully created.
+//   - 400 Bad Request: If a parameter or the tag name is missing, or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the previous tag does not exist.
+//   - 422 Unprocessable Entity: If make_latest is invalid or the release already exists.
+func CreateRelease(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.ReleaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if req.GetTagName() == "" {
+		// Response: 400 Bad Request if the tag name is missing
+		response.StatusBadRequestMissingParams(c, []string{"tag_name"})
+		return
+	}
+	release, err := req.ToRelease()
+	if err != nil {
+		// Response: 422 Unprocessable Entity if make_latest is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if from, to, ok := req.NotesRange(); ok {
+		opts := models.ReleaseNotesOptions{From: from, To: to, Groups: req.NoteGroups}
+		notes, err := models.GenerateReleaseNotes(c, client, params["username"], params["repoName"], opts)
+		if err != nil {
+			response.HandleGithubErrors(c, err)
+			return
+		}
+		release.Body = github.String(notes.Body)
+	}
+	created, _, err := client.CreateRelease(c, params["username"], params["repoName"], release)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the release is successfully created
+	response.StatusCreated(c, created)
+}
+
+// UpdateRelease handles editing a release.
+// It expects the token, username and repoName parameters, the release ID and a JSON body
+// with any of tag_name, target_commitish, name, body, draft, prerelease and make_latest.
+//
+// Responses:
+//   - 200 OK: If the release is successfully updated.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the release does not exist.
+//   - 422 Unprocessable Entity: If make_latest is invalid or GitHub rejects the changes.
+func UpdateRelease(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	var req models.ReleaseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	release, err := req.ToRelease()
+	if err != nil {
+		// Response: 422 Unprocessable Entity if make_latest is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	// GitHub only generates release notes on creation.
+	release.GenerateReleaseNotes = nil
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	updated, _, err := client.EditRelease(c, params["username"], params["repoName"], id, release)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the release is successfully updated
+	response.StatusOK(c, updated)
+}
+
+// DeleteRelease handles deleting a release. The tag of the release is kept.
+// It expects the token, username and repoName parameters and the release ID.
+//
+// Responses:
+//   - 204 No Content: If the release is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing or the release ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the release does not exist.
+func DeleteRelease(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteRelease(c, params["username"], params["repoName"], id); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the release is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// ReleaseNotes handles generating release notes from the pull requests merged between two tags.
+// It expects the token, username and repoName parameters and the from and to query parameters,
+// which name the tags (or any commit or branch) bounding the range. The repeatable,
+// comma-separated groups query parameter lists labels in order: a pull request is listed under
+// the first group whose label it carries, or under Other. Without groups, every label forms a group.
+//
+// Responses:
+//   - 200 OK: With the grouped pull requests and their Markdown rendering.
+//   - 400 Bad Request: If a parameter, from or to is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or a tag does not exist.
+func ReleaseNotes(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	opts := models.ReleaseNotesOptions{From: c.Query("from"), To: c.Query("to"), Groups: queryList(c, "groups")}
+	if opts.From == "" || opts.To == "" {
+		// Response: 400 Bad Request if the range is missing
+		response.StatusBadRequestMissingParams(c, []string{"from", "to"})
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	notes, err := models.GenerateReleaseNotes(c, client, params["username"], params["repoName"], opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the release notes
+	response.StatusOK(c, notes)
+}
+
+// ListReleaseAssets handles the retrieval of the assets of a release.
+// It expects the token, username and repoName parameters and the release ID.
+// Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the assets are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the release does not exist.
+func ListReleaseAssets(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	assets, resp, err := client.ListReleaseAssets(c, params["username"], params["repoName"], id, &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the assets are successfully retrieved
+	response.StatusOKPage(c, assets, resp)
+}
+
+// UploadReleaseAsset handles uploading an asset to a release.
+// It expects the token, username and repoName parameters, the release ID and a multipart
+// form with the file and optionally the name (defaulting to the file name) and label of the asset.
+// The media type is taken from the file part, or guessed from the name of the asset.
+//
+// Responses:
+//   - 201 Created: If the asset is successfully uploaded.
+//   - 400 Bad Request: If a parameter or the file is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the release does not exist.
+//   - 422 Unprocessable Entity: If an asset with the same name already exists.
+func UploadReleaseAsset(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "id")
+	if !ok {
+		return
+	}
+	header, err := c.FormFile("file")
+	if err != nil {
+		// Response: 400 Bad Request if the file is missing
+		response.StatusBadRequestMissingParams(c, []string{"file"})
+		return
+	}
+	opts := &github.UploadOptions{
+		Name:      c.DefaultPostForm("name", header.Filename),
+		Label:     c.PostForm("label"),
+		MediaType: header.Header.Get("Content-Type"),
+	}
+	if opts.MediaType == "application/octet-stream" {
+		opts.MediaType = ""
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	// The GitHub client uploads from a file, so the part is spooled to a temporary one.
+	file, err := os.CreateTemp("", "release-asset-*")
+	if err != nil {
+		// Response: 500 Internal Server Error if the upload cannot be buffered
+		response.StatusInternalServerError(c, err)
+		return
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if err := spool(header, file); err != nil {
+		// Response: 500 Internal Server Error if the upload cannot be buffered
+		response.StatusInternalServerError(c, err)
+		return
+	}
+
+	asset, _, err := client.UploadReleaseAsset(c, params["username"], params["repoName"], id, opts, file)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the asset is successfully uploaded
+	response.StatusCreated(c, asset)
+}
+
+// DownloadReleaseAsset handles downloading the content of a release asset.
+// It expects the token, username and repoName parameters and the asset ID.
+// The content is streamed as an attachment under the name of the asset.
+//
+// Responses:
+//   - 200 OK: With the content of the asset.
+//   - 400 Bad Request: If a parameter is missing or the asset ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the asset does not exist.
+func DownloadReleaseAsset(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "assetID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	asset, _, err := client.GetReleaseAsset(c, params["username"], params["repoName"], id)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	content, err := client.DownloadReleaseAsset(c, params["username"], params["repoName"], id)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	defer content.Close()
+
+	contentType := asset.GetContentType()
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	// Response: 200 OK with the content of the asset
+	c.DataFromReader(http.StatusOK, int64(asset.GetSize()), contentType, content, map[string]string{
+		"Content-Disposition": "attachment; filename=" + strconv.Quote(asset.GetName()),
+	})
+}
+
+// DeleteReleaseAsset handles deleting a release asset.
+// It expects the token, username and repoName parameters and the asset ID.
+//
+// Responses:
+//   - 204 No Content: If the asset is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing or the asset ID is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the asset does not exist.
+func DeleteReleaseAsset(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	id, ok := intParam(c, "assetID")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteReleaseAsset(c, params["username"], params["repoName"], id); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the asset is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// ListTags handles the retrieval of the tags of a repository.
+// It expects the token, username and repoName parameters.
+// Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: If the tags are successfully retrieved.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListTags(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	tags, resp, err := client.ListTags(c, params["username"], params["repoName"], &page)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK if the tags are successfully retrieved
+	response.StatusOKPage(c, tags, resp)
+}
+
+// CreateTag handles the creation of a tag.
+// It expects the token, username and repoName parameters and a JSON body with the tag name
+// and the full sha of the tagged commit. When a message is given, an annotated tag is created;
+// otherwise the tag is lightweight.
+//
+// Responses:
+//   - 201 Created: With the reference of the tag.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the tag or the sha is invalid, or the tag already exists.
+func CreateTag(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		// Response: 400 Bad Request if the payload is invalid
+		response.StatusBadRequest(c)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the tag or the sha is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	ref, err := req.Create(c, client, params["username"], params["repoName"])
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 201 Created if the tag is successfully created
+	response.StatusCreated(c, ref)
+}
+
+// spool copies an uploaded file to dst and rewinds dst for reading.
+func spool(header *multipart.FileHeader, dst *os.File) error {
+	src, err := header.Open()
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return fmt.Errorf("buffering upload: %w", err)
+	}
+	_, err = dst.Seek(0, io.SeekStart)
+	return err
+}
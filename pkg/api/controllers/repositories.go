@@ -1,27 +1,45 @@
 package controllers
 
 import (
+	"context"
+	"errors"
 	"github-api/pkg/auth"
+	"github-api/pkg/events"
+	"github-api/pkg/interfaces"
 	"github-api/pkg/models"
 	"github-api/pkg/response"
+	"github-api/pkg/scaffold"
+	"github-api/pkg/validation"
+	"github-api/pkg/workspace"
 	"github.com/gin-gonic/gin"
 	"github.com/google/go-github/v50/github"
+	"log"
+	"strconv"
+	"time"
 )
 
 // CreateRepo handles the creation of a new repository.
 // It expects the following parameters:
 //   - token: The authentication token for the GitHub API.
 //
-// The function validates the repository model and token, and if valid,
-// it creates a new repository using the provided token.
+// The function validates the creation request and token, and if valid,
+// it creates a new repository using the provided token. The repository is
+// generated from a template repository when template is set, initialized by
+// GitHub with auto_init, gitignore_template and license_template, or given
+// an initial commit rendered from a skeleton of the scaffold directory
+// configured by SCAFFOLD_DIR when scaffold is set. The topics, the default
+// branch and the settings GitHub does not accept on creation are applied
+// once the repository exists.
 //
 // Responses:
 //   - 201 Created: If the repository is successfully created.
-//   - 400 Bad Request: If the repository model is invalid or cannot be created.
+//   - 400 Bad Request: If the request is malformed, or with the invalid fields, such as a missing or invalid name.
 //   - 401 Unauthorized: If the provided token is invalid or authentication fails.
-//   - 404 Not Found: If the specified user does not exist or has no repositories.
+//   - 404 Not Found: If the specified user or the template does not exist.
 //   - 409 Conflict: If the repository already exists.
-//   - 500 Internal Server Error: If an error occurs while creating the repository.
+//   - 422 Unprocessable Entity: If a field is unsupported, fields conflict, or the skeleton is unknown or cannot be rendered.
+//   - 500 Internal Server Error: If the repository was created but a follow-up step failed, with the
+//     created repository and the failed step in the error.
 func CreateRepo(c *gin.Context) {
 	// Extract the token from the request parameters
 	var token = c.Param("token")
@@ -31,12 +49,28 @@ func CreateRepo(c *gin.Context) {
 		return
 	}
 
-	var repo, err = models.ConvertFromContext(c)
-	if (repo == models.RepositoryModel{}) || err != nil {
-		// Response: 400 Bad Request if the repository model is invalid
-		response.StatusBadRequest(c)
+	var req models.CreateRepositoryRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if err := req.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if a field is unsupported or fields conflict
+		response.StatusUnprocessableEntity(c, err)
 		return
 	}
+	scaffolder := scaffold.Default()
+	if req.Scaffold != nil {
+		if scaffolder == nil {
+			// Response: 422 Unprocessable Entity if no scaffold directory is configured
+			response.StatusUnprocessableEntity(c, errors.New("scaffold requires SCAFFOLD_DIR"))
+			return
+		}
+		if err := scaffolder.Check(req.Scaffold.Skeleton, req.Scaffold.Variables); err != nil {
+			// Response: 422 Unprocessable Entity if the skeleton is unknown or cannot be rendered
+			response.StatusUnprocessableEntity(c, err)
+			return
+		}
+	}
 	// Check if the token is valid
 	client, err := auth.GetClient(c.Param("token"))
 	if err != nil {
@@ -45,19 +79,34 @@ func CreateRepo(c *gin.Context) {
 		return
 	}
 
-	// Check if the repository model already exists
+	// Check if the repository already exists
+	repo := models.RepositoryModel{Repository: &github.Repository{Name: github.String(req.Name)}}
 	exists, err := repo.RepoExists(client)
-	if exists || err != nil {
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	if exists {
 		// Response: 409 Conflict if the repository already exists
 		response.StatusConflict(c)
 		return
 	}
 
-	if err := repo.CreateNew(client); err != nil {
-		// Response: 403 Forbidden if the user does not have permission to create the repository
-		response.StatusForbidden(c)
+	created, err := req.Create(c, client, scaffolder, token)
+	if errors.Is(err, models.ErrPartiallyCreated) {
+		events.Publish(events.RepoCreated, created.GetOwner().GetLogin(), created.GetName(), created)
+		// Response: 500 Internal Server Error with the created repository if a follow-up step failed
+		response.StatusInternalServerErrorData(c, err, created)
 		return
 	}
+	if err != nil {
+		// Response: 403 Forbidden, 422 Unprocessable Entity, etc. as reported by GitHub
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	repo.Repository = created
+	events.Publish(events.RepoCreated, created.GetOwner().GetLogin(), created.GetName(), created)
+
 	// Response: 201 Created if the repository is successfully created
 	response.StatusCreated(c, repo)
 }
@@ -72,7 +121,7 @@ func CreateRepo(c *gin.Context) {
 // The function also checks if the repository exists before attempting to delete it.
 // Responses:
 //   - 204 No Content: If the repository is successfully deleted.
-//   - 400 Bad Request: If the repository model is invalid, cannot be deleted or if there are no request parameters.
+//   - 400 Bad Request: If the repository model is invalid, with its invalid fields, cannot be deleted or if there are no request parameters.
 //   - 401 Unauthorized: If the provided token is invalid or authentication fails.
 //   - 404 Not Found: If the repository does not exist.
 //   - 500 Internal Server Error: If an error occurs while deleting the repository.
@@ -88,6 +137,11 @@ func DeleteRepo(c *gin.Context) {
 	var repo, err = models.ConvertFromContext(c)
 
 	// Check if the repository model is valid
+	if fields, ok := validation.Fields(err); ok {
+		// Response: 400 Bad Request listing the invalid fields of the repository model
+		response.StatusBadRequestFields(c, fields)
+		return
+	}
 	if (repo == models.RepositoryModel{}) || err != nil {
 		// Response: 400 Bad Request if the repository model is invalid
 		response.StatusBadRequest(c)
@@ -104,16 +158,23 @@ func DeleteRepo(c *gin.Context) {
 
 	// Check if the repository exists
 	exists, err := repo.RepoExists(client)
-	if exists || err != nil {
-		// Response: 404 Not Found if the repository does not exist
+	if err != nil {
 		response.HandleGithubErrors(c, err)
+		return
+	}
+	if !exists {
+		// Response: 404 Not Found if the repository does not exist
+		response.StatusNotFound(c)
+		return
 	}
 
 	// Delete the repository
 	if err := repo.DeleteRepo(client); err != nil {
 		// Response: 500 Internal Server Error if an error occurs while deleting the repository
 		response.HandleGithubErrors(c, err)
+		return
 	}
+	events.Publish(events.RepoDeleted, repo.GetOwner().GetLogin(), repo.GetName(), repo.Repository)
 
 	// If the repository was deleted successfully, return a 204 No Content response
 	response.StatusNoContent(c)
@@ -127,7 +188,8 @@ func DeleteRepo(c *gin.Context) {
 //
 // The function authenticates the user using the provided token and retrieves
 // the list of open pull requests for the specified repository, sorted by
-// the creation date in descending order.
+// the creation date in descending order. Results are paginated with the
+// page and per_page query parameters.
 //
 // Responses:
 //   - 200 OK: If the pull requests are successfully retrieved.
@@ -157,6 +219,11 @@ func PullRequests(c *gin.Context) {
 		return
 	}
 
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+
 	// Check if the token is valid
 	client, err := auth.GetClient(params["token"])
 	if err != nil {
@@ -173,15 +240,16 @@ func PullRequests(c *gin.Context) {
 		return
 	}
 
-	opt := &github.PullRequestListOptions{State: "open", Sort: "created", Direction: "desc"}
-	pullRequests, _, err := client.ListPullRequests(c, params["username"], params["repoName"], opt)
+	opt := &github.PullRequestListOptions{State: "open", Sort: "created", Direction: "desc", ListOptions: page}
+	pullRequests, resp, err := client.ListPullRequests(c, params["username"], params["repoName"], opt)
 	if err != nil {
 		// Response: 403 Forbidden if the user does not have permission to access the repository
-		response.StatusForbidden(c)
+		response.HandleGithubErrors(c, err)
+		return
 	}
 
 	// 200 OK: if the pull requests are successfully retrieved
-	response.StatusOK(c, pullRequests)
+	response.StatusOKPage(c, pullRequests, resp)
 }
 
 // ListRepos handles the retrieval of repositories for a user.
@@ -191,13 +259,19 @@ func PullRequests(c *gin.Context) {
 //
 // The function authenticates the user using the provided token and retrieves
 // the list of repositories owned by the specified username, sorted by the
-// last updated time in descending order.
+// last updated time in descending order. Results are paginated with the
+// page and per_page query parameters.
 //
 // Responses:
 //   - 200 OK: If the repositories are successfully retrieved.
 //   - 401 Unauthorized: If the provided token is invalid or authentication fails.
 //   - 500 Internal Server Error: If an error occurs while retrieving the repositories.
 func ListRepos(c *gin.Context) {
+	page, ok := listOptions(c)
+	if !ok {
+		return
+	}
+
 	token := c.Param("token")
 	client, err := auth.GetClient(token)
 	// Check if the token is valid
@@ -214,8 +288,8 @@ func ListRepos(c *gin.Context) {
 		return
 	}
 
-	opt := &github.RepositoryListOptions{Type: "owner", Sort: "updated", Direction: "desc"}
-	repos, _, err := client.ListRepos(c, *user.Login, opt)
+	opt := &github.RepositoryListOptions{Type: "owner", Sort: "updated", Direction: "desc", ListOptions: page}
+	repos, resp, err := client.ListRepos(c, *user.Login, opt)
 
 	// Check if the request to list repositories was successful
 	if err != nil {
@@ -224,7 +298,235 @@ func ListRepos(c *gin.Context) {
 		return
 	}
 	// Response: 200 OK if the repositories are successfully retrieved
-	response.StatusOK(c, repos)
+	response.StatusOKPage(c, repos, resp)
+}
+
+// TransferRepo handles the transfer of a repository to another user or organization.
+// It expects the token, username and repoName parameters and a JSON body with the new_owner and,
+// for an organization, optionally the team_ids given access. The transfer runs in the background
+// on GitHub; the response is sent once the repository is reachable under its new owner, and the
+// local clone of the repository, if any, is moved along. A transfer still pending is followed in
+// the background for up to a day, and the clone is moved and the move published once it completes.
+//
+// Responses:
+//   - 200 OK: With the repository at its new location.
+//   - 202 Accepted: If the transfer is still pending, e.g. until the new owner accepts it.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 403 Forbidden: If the token may not transfer the repository.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the new owner already has a repository with the same name.
+func TransferRepo(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.TransferRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	moved, err := models.TransferRepository(c, client, params["username"], params["repoName"], req)
+	if errors.Is(err, models.ErrMovePending) {
+		followMove(events.RepoTransferred, client, params["username"], params["repoName"], req.NewOwner, params["repoName"])
+		// Response: 202 Accepted if the repository is not reachable under its new owner yet
+		response.StatusAccepted(c, gin.H{"owner": req.NewOwner, "name": params["repoName"]})
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	repositoryMoved(events.RepoTransferred, params["username"], params["repoName"], moved)
+
+	// Response: 200 OK if the repository is transferred
+	response.StatusOK(c, moved)
+}
+
+// RenameRepo handles renaming a repository.
+// It expects the token, username and repoName parameters and a JSON body with the new name.
+// The response is sent once the repository is reachable under its new name, and the local clone
+// of the repository, if any, is moved along. A rename not visible yet is followed in the
+// background like a pending transfer.
+//
+// Responses:
+//   - 200 OK: With the renamed repository.
+//   - 202 Accepted: If the repository is not reachable under its new name yet.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+//   - 422 Unprocessable Entity: If the owner already has a repository with the new name.
+func RenameRepo(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	var req models.RenameRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	moved, err := models.RenameRepository(c, client, params["username"], params["repoName"], req)
+	if errors.Is(err, models.ErrMovePending) {
+		followMove(events.RepoRenamed, client, params["username"], params["repoName"], params["username"], req.Name)
+		// Response: 202 Accepted if the repository is not reachable under its new name yet
+		response.StatusAccepted(c, gin.H{"owner": params["username"], "name": req.Name})
+		return
+	}
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	repositoryMoved(events.RepoRenamed, params["username"], params["repoName"], moved)
+
+	// Response: 200 OK if the repository is renamed
+	response.StatusOK(c, moved)
+}
+
+// repositoryMoved moves the local clone of a renamed or transferred repository to its new
+// location and publishes the move. A clone that cannot be moved is only logged, since the
+// next use of the repository clones it again at its new location.
+func repositoryMoved(typ events.Type, owner, repo string, moved *github.Repository) {
+	newOwner, newRepo := moved.GetOwner().GetLogin(), moved.GetName()
+	if ws := workspace.Default(); ws != nil {
+		if _, err := ws.Move(owner, repo, newOwner, newRepo); err != nil {
+			log.Printf("workspace: moving %s/%s to %s/%s: %v", owner, repo, newOwner, newRepo, err)
+		}
+	}
+	events.Publish(typ, newOwner, newRepo, events.RepoMove{FromOwner: owner, FromRepo: repo, Repository: moved})
+}
+
+// followMove waits in the background for a repository whose move is pending to be reachable at
+// its new location, then moves its local clone and publishes the move like repositoryMoved. A
+// move that does not complete, such as a declined transfer, is only logged.
+func followMove(typ events.Type, client interfaces.GitHubClient, owner, repo, newOwner, newRepo string) {
+	go func() {
+		moved, err := models.AwaitMove(context.Background(), client, newOwner, newRepo)
+		if err != nil {
+			log.Printf("following the move of %s/%s to %s/%s: %v", owner, repo, newOwner, newRepo, err)
+			return
+		}
+		repositoryMoved(typ, owner, repo, moved)
+	}()
+}
+
+// ArchiveCandidates handles reporting the repositories an archive policy would archive.
+// It expects the token parameter. The policy is read from the query parameters: inactive_months
+// (default 12), the comma-separated allowlist ("owner/name" patterns), exempt_topics and owners,
+// max_open_pull_requests and max_open_issues (default 0), and include_forks. The repositories the
+// authenticated user administers are evaluated.
+//
+// Responses:
+//   - 200 OK: With the candidates, the exempt inactive repositories and the archived ones.
+//   - 400 Bad Request: If a parameter is missing or invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If an allowlist pattern is invalid.
+func ArchiveCandidates(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	policy := models.ArchivePolicy{
+		Allowlist:    queryList(c, "allowlist"),
+		ExemptTopics: queryList(c, "exempt_topics"),
+		Owners:       queryList(c, "owners"),
+		IncludeForks: c.Query("include_forks") == "true",
+	}
+	for key, target := range map[string]*int{
+		"inactive_months":        &policy.InactiveMonths,
+		"max_open_pull_requests": &policy.MaxOpenPullRequests,
+		"max_open_issues":        &policy.MaxOpenIssues,
+	} {
+		value, err := strconv.Atoi(c.DefaultQuery(key, "0"))
+		if err != nil {
+			// Response: 400 Bad Request if a number is invalid
+			response.StatusBadRequestFields(c, []validation.FieldError{{Field: key, Rule: "type", Message: "must be a number"}})
+			return
+		}
+		*target = value
+	}
+	if fields, ok := validation.Fields(validation.Struct(policy)); ok {
+		// Response: 400 Bad Request listing the invalid fields of the policy
+		response.StatusBadRequestFields(c, fields)
+		return
+	}
+	if err := policy.Validate(); err != nil {
+		// Response: 422 Unprocessable Entity if the policy is invalid
+		response.StatusUnprocessableEntity(c, err)
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	report, err := models.FindArchiveCandidates(c, client, policy, time.Now())
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the archive candidates
+	response.StatusOK(c, report)
+}
+
+// ArchiveRepos handles archiving or unarchiving selected repositories.
+// It expects the token parameter and a models.ArchiveRequest body with the repos, the action
+// (archive or unarchive), and optionally the policy the repositories to archive must still
+// satisfy, dry_run and rollback. With rollback, the first failure reverts the repositories
+// already changed. Every change applied, and its rollback, is published as a repo.archived or
+// repo.unarchived event.
+//
+// Responses:
+//   - 200 OK: With the change of each repository.
+//   - 400 Bad Request: If a parameter is missing or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 422 Unprocessable Entity: If the policy is invalid.
+func ArchiveRepos(c *gin.Context) {
+	params, ok := requireParams(c, "token")
+	if !ok {
+		return
+	}
+	var req models.ArchiveRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	if req.Policy != nil {
+		if err := req.Policy.Validate(); err != nil {
+			// Response: 422 Unprocessable Entity if the policy is invalid
+			response.StatusUnprocessableEntity(c, err)
+			return
+		}
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	result := req.Apply(c, client, time.Now())
+	types := map[bool]events.Type{true: events.RepoArchived, false: events.RepoUnarchived}
+	archived := req.Action == models.ArchiveAction
+	for _, change := range result.Changes {
+		if change.Status != models.ArchiveApplied && change.Status != models.ArchiveRolledBack {
+			continue
+		}
+		owner, repo, _ := models.SplitFullName(change.Repository)
+		events.Publish(types[archived], owner, repo, change)
+		if change.Status == models.ArchiveRolledBack {
+			events.Publish(types[!archived], owner, repo, change)
+		}
+	}
+
+	// Response: 200 OK with the changes
+	response.StatusOK(c, result)
 }
 
 // Index handles the root endpoint of the API.
@@ -0,0 +1,398 @@
+package controllers
+
+import (
+	"github-api/pkg/models"
+	"github-api/pkg/response"
+	"github-api/pkg/validation"
+	"github.com/gin-gonic/gin"
+)
+
+// ListRepoSecrets handles the retrieval of the Actions secrets of a repository.
+// It expects the token, username and repoName parameters. Only the names and dates of the secrets
+// are returned, never their values. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the secrets.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListRepoSecrets(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	secrets, resp, err := client.ListRepoSecrets(c, params["username"], params["repoName"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the secrets of the repository
+	response.StatusOKPage(c, secrets.Secrets, resp)
+}
+
+// SetRepoSecret handles creating or updating an Actions secret of a repository.
+// It expects the token, username, repoName and name parameters and a models.SecretRequest body.
+// The value is encrypted with the public key of the repository before it is sent to GitHub.
+//
+// Responses:
+//   - 201 Created: With the name of the secret, if it is created.
+//   - 204 No Content: If the secret is updated.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func SetRepoSecret(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok || !actionsName(c, params["name"]) {
+		return
+	}
+	var req models.SecretRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, err := models.SetRepoSecret(c, client, params["username"], params["repoName"], params["name"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	respondSet(c, params["name"], created)
+}
+
+// DeleteRepoSecret handles deleting an Actions secret of a repository.
+// It expects the token, username, repoName and name parameters.
+//
+// Responses:
+//   - 204 No Content: If the secret is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the secret does not exist.
+func DeleteRepoSecret(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteRepoSecret(c, params["username"], params["repoName"], params["name"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the secret is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// ListOrgSecrets handles the retrieval of the Actions secrets of an organization.
+// It expects the token and org parameters. Only the names, dates and visibility of the secrets
+// are returned, never their values. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the secrets.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization does not exist.
+func ListOrgSecrets(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	secrets, resp, err := client.ListOrgSecrets(c, params["org"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the secrets of the organization
+	response.StatusOKPage(c, secrets.Secrets, resp)
+}
+
+// SetOrgSecret handles creating or updating an Actions secret of an organization.
+// It expects the token, org and name parameters and a models.OrgSecretRequest body.
+// The value is encrypted with the public key of the organization before it is sent to GitHub.
+//
+// Responses:
+//   - 201 Created: With the name of the secret, if it is created.
+//   - 204 No Content: If the secret is updated.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization does not exist.
+func SetOrgSecret(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org", "name")
+	if !ok || !actionsName(c, params["name"]) {
+		return
+	}
+	var req models.OrgSecretRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, err := models.SetOrgSecret(c, client, params["org"], params["name"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	respondSet(c, params["name"], created)
+}
+
+// DeleteOrgSecret handles deleting an Actions secret of an organization.
+// It expects the token, org and name parameters.
+//
+// Responses:
+//   - 204 No Content: If the secret is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization or the secret does not exist.
+func DeleteOrgSecret(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org", "name")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteOrgSecret(c, params["org"], params["name"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the secret is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// ListRepoVariables handles the retrieval of the Actions variables of a repository.
+// It expects the token, username and repoName parameters. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the variables and their values.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func ListRepoVariables(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	variables, resp, err := client.ListRepoVariables(c, params["username"], params["repoName"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the variables of the repository
+	response.StatusOKPage(c, variables.Variables, resp)
+}
+
+// SetRepoVariable handles creating or updating an Actions variable of a repository.
+// It expects the token, username, repoName and name parameters and a models.VariableRequest body.
+//
+// Responses:
+//   - 201 Created: With the name of the variable, if it is created.
+//   - 204 No Content: If the variable is updated.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository does not exist.
+func SetRepoVariable(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok || !actionsName(c, params["name"]) {
+		return
+	}
+	var req models.VariableRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, err := models.SetRepoVariable(c, client, params["username"], params["repoName"], params["name"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	respondSet(c, params["name"], created)
+}
+
+// DeleteRepoVariable handles deleting an Actions variable of a repository.
+// It expects the token, username, repoName and name parameters.
+//
+// Responses:
+//   - 204 No Content: If the variable is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the repository or the variable does not exist.
+func DeleteRepoVariable(c *gin.Context) {
+	params, ok := requireParams(c, "token", "username", "repoName", "name")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteRepoVariable(c, params["username"], params["repoName"], params["name"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the variable is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// ListOrgVariables handles the retrieval of the Actions variables of an organization.
+// It expects the token and org parameters. Results are paginated with page and per_page.
+//
+// Responses:
+//   - 200 OK: With the variables, their values and visibility.
+//   - 400 Bad Request: If a parameter is missing or the pagination is invalid.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization does not exist.
+func ListOrgVariables(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org")
+	if !ok {
+		return
+	}
+	opts, ok := listOptions(c)
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	variables, resp, err := client.ListOrgVariables(c, params["org"], &opts)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 200 OK with the variables of the organization
+	response.StatusOKPage(c, variables.Variables, resp)
+}
+
+// SetOrgVariable handles creating or updating an Actions variable of an organization.
+// It expects the token, org and name parameters and a models.OrgVariableRequest body.
+//
+// Responses:
+//   - 201 Created: With the name of the variable, if it is created.
+//   - 204 No Content: If the variable is updated.
+//   - 400 Bad Request: If a parameter is missing or invalid, or the payload is invalid, with its invalid fields.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization does not exist.
+func SetOrgVariable(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org", "name")
+	if !ok || !actionsName(c, params["name"]) {
+		return
+	}
+	var req models.OrgVariableRequest
+	if !bindJSON(c, &req) {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	created, err := models.SetOrgVariable(c, client, params["org"], params["name"], req)
+	if err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+	respondSet(c, params["name"], created)
+}
+
+// DeleteOrgVariable handles deleting an Actions variable of an organization.
+// It expects the token, org and name parameters.
+//
+// Responses:
+//   - 204 No Content: If the variable is successfully deleted.
+//   - 400 Bad Request: If a parameter is missing.
+//   - 401 Unauthorized: If the provided token is invalid or authentication fails.
+//   - 404 Not Found: If the organization or the variable does not exist.
+func DeleteOrgVariable(c *gin.Context) {
+	params, ok := requireParams(c, "token", "org", "name")
+	if !ok {
+		return
+	}
+	client, ok := githubClient(c, params["token"])
+	if !ok {
+		return
+	}
+
+	if _, err := client.DeleteOrgVariable(c, params["org"], params["name"]); err != nil {
+		response.HandleGithubErrors(c, err)
+		return
+	}
+
+	// Response: 204 No Content if the variable is successfully deleted
+	response.StatusNoContent(c)
+}
+
+// actionsName checks the name of a secret or variable taken from the path.
+// If it is invalid, a 400 Bad Request response listing it is sent.
+func actionsName(c *gin.Context, name string) bool {
+	fields, invalid := validation.Fields(validation.Struct(struct {
+		Name string `json:"name" binding:"actionsname"`
+	}{name}))
+	if invalid {
+		// Response: 400 Bad Request if the name is invalid
+		response.StatusBadRequestFields(c, fields)
+		return false
+	}
+	return true
+}
+
+// respondSet sends the response to creating or updating a secret or variable, which never
+// includes its value.
+func respondSet(c *gin.Context, name string, created bool) {
+	if created {
+		// Response: 201 Created if the secret or variable is created
+		response.StatusCreated(c, gin.H{"name": name})
+		return
+	}
+	// Response: 204 No Content if the secret or variable is updated
+	response.StatusNoContent(c)
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"github-api/pkg/events"
+	"github-api/pkg/response"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// streamKeepAlive is the interval between keep-alive comments on idle event streams.
+const streamKeepAlive = 15 * time.Second
+
+// EventStream streams repository and pull request events as Server-Sent Events.
+// It accepts the following optional query parameters, each repeatable or comma-separated:
+//   - owner: Only stream events for repositories of these owners.
+//   - repo: Only stream events for repositories with these names.
+//   - type: Only stream events of these types, e.g. pr.opened.
+//
+// Clients resume an interrupted stream by sending the Last-Event-ID header (or the
+// last_event_id query parameter). Missed events still held in the replay buffer are
+// sent first; if some were already evicted, or the ID is newer than every buffered
+// event because the IDs restarted, a "gap" event is sent before them so the client
+// knows to refresh its state. A client too slow to keep up also receives a "gap"
+// event, with the ID of the last event it received, before the next event.
+//
+// Responses:
+//   - 200 OK: An event stream that stays open until the client disconnects.
+//   - 400 Bad Request: If Last-Event-ID is not a valid event ID.
+func EventStream(c *gin.Context) {
+	filter := events.Filter{
+		Owners: queryList(c, "owner"),
+		Repos:  queryList(c, "repo"),
+	}
+	for _, t := range queryList(c, "type") {
+		filter.Types = append(filter.Types, events.Type(t))
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	var backlog []events.Event
+	var ch <-chan events.Event
+	var unsubscribe func()
+	if lastEventID != "" {
+		lastID, err := strconv.ParseUint(lastEventID, 10, 64)
+		if err != nil {
+			// Response: 400 Bad Request if the last event ID is not a number
+			response.StatusBadRequest(c)
+			return
+		}
+		var complete bool
+		backlog, complete, ch, unsubscribe = events.Default().SubscribeSince(lastID, 64)
+		if !complete {
+			backlog = append([]events.Event{{ID: lastID, Type: events.Gap}}, backlog...)
+		}
+	} else {
+		ch, unsubscribe = events.Default().Subscribe(64)
+	}
+	defer unsubscribe()
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(200)
+	c.Writer.Flush()
+
+	for _, e := range backlog {
+		if e.Type == events.Gap || filter.Match(e) {
+			renderEvent(c, e)
+		}
+	}
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case e, ok := <-ch:
+			if !ok {
+				return false
+			}
+			if e.Type == events.Gap || filter.Match(e) {
+				renderEvent(c, e)
+			}
+			return true
+		case <-keepAlive.C:
+			_, err := io.WriteString(w, ": keep-alive\n\n")
+			return err == nil
+		}
+	})
+}
+
+// renderEvent writes a single event in the Server-Sent Events format.
+func renderEvent(c *gin.Context, e events.Event) {
+	c.Render(-1, sse.Event{
+		Id:    strconv.FormatUint(e.ID, 10),
+		Event: string(e.Type),
+		Data:  e,
+	})
+}
+
+// queryList returns the values of a repeatable query parameter, splitting comma-separated values.
+func queryList(c *gin.Context, key string) []string {
+	var values []string
+	for _, value := range c.QueryArray(key) {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				values = append(values, v)
+			}
+		}
+	}
+	return values
+}
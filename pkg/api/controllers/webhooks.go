@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"github-api/pkg/events"
+	"github-api/pkg/response"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"os"
+)
+
+// GithubWebhook handles inbound GitHub webhook deliveries.
+// The payload signature is validated against the GITHUB_WEBHOOK_SECRET environment
+// variable when it is set. Supported deliveries are translated into events and
+// published on the event bus.
+//
+// Responses:
+//   - 204 No Content: If the delivery was accepted, whether or not it produced events.
+//   - 400 Bad Request: If the payload cannot be parsed.
+//   - 401 Unauthorized: If the payload signature is invalid.
+func GithubWebhook(c *gin.Context) {
+	payload, err := github.ValidatePayload(c.Request, []byte(os.Getenv("GITHUB_WEBHOOK_SECRET")))
+	if err != nil {
+		// Response: 401 Unauthorized if the signature does not match the secret
+		response.StatusUnauthorized(c)
+		return
+	}
+
+	received, err := events.FromWebhook(github.WebHookType(c.Request), payload)
+	if err != nil {
+		// Response: 400 Bad Request if the payload cannot be parsed
+		response.StatusBadRequest(c)
+		return
+	}
+	for _, e := range received {
+		if _, err := events.Default().Publish(e); err != nil {
+			response.StatusInternalServerError(c, err)
+			return
+		}
+	}
+
+	// Response: 204 No Content once the delivery has been processed
+	response.StatusNoContent(c)
+}
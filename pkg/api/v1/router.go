@@ -9,6 +9,118 @@ func RegisterRoutes(router *gin.Engine) {
 	router.POST("/repositories/:token", controllers.CreateRepo)
 	router.DELETE("/repositories/:token", controllers.DeleteRepo)
 	router.GET("/repositories/:token", controllers.ListRepos)
+	router.GET("/repositories/archive/:token", controllers.ArchiveCandidates)
+	router.POST("/repositories/archive/:token", controllers.ArchiveRepos)
+	router.POST("/repositories/transfer/:username/:repoName/:token", controllers.TransferRepo)
+	router.POST("/repositories/rename/:username/:repoName/:token", controllers.RenameRepo)
 	router.GET("/pull-requests/:username/:repoName/:token", controllers.PullRequests)
+	router.POST("/pull-requests/:username/:repoName/:token", controllers.CreatePullRequest)
+	router.GET("/pull-requests/metrics/:token", controllers.PullRequestMetrics)
+	router.GET("/pull-requests/stale/:token", controllers.StalePullRequests)
+	router.POST("/pull-requests/stale/:token", controllers.NudgeStalePullRequests)
+	router.GET("/pull-requests/:username/:repoName/:token/:number", controllers.GetPullRequest)
+	router.PATCH("/pull-requests/:username/:repoName/:token/:number", controllers.UpdatePullRequest)
+	router.POST("/pull-requests/:username/:repoName/:token/:number/reviewers", controllers.RequestReviewers)
+	router.PUT("/pull-requests/:username/:repoName/:token/:number/merge", controllers.MergePullRequest)
+
+	router.GET("/issues/:username/:repoName/:token", controllers.ListIssues)
+	router.POST("/issues/:username/:repoName/:token", controllers.CreateIssue)
+	router.GET("/issues/:username/:repoName/:token/:number", controllers.GetIssue)
+	router.PATCH("/issues/:username/:repoName/:token/:number", controllers.UpdateIssue)
+	router.POST("/issues/:username/:repoName/:token/:number/close", controllers.CloseIssue)
+	router.POST("/issues/:username/:repoName/:token/:number/reopen", controllers.ReopenIssue)
+	router.GET("/issues/:username/:repoName/:token/:number/comments", controllers.ListIssueComments)
+	router.POST("/issues/:username/:repoName/:token/:number/comments", controllers.CreateIssueComment)
+	router.GET("/issues/:username/:repoName/:token/comments/:commentID", controllers.GetIssueComment)
+	router.PATCH("/issues/:username/:repoName/:token/comments/:commentID", controllers.UpdateIssueComment)
+	router.DELETE("/issues/:username/:repoName/:token/comments/:commentID", controllers.DeleteIssueComment)
+
+	router.GET("/labels/:username/:repoName/:token", controllers.ListLabels)
+	router.POST("/labels/:username/:repoName/:token", controllers.CreateLabel)
+	router.PATCH("/labels/:username/:repoName/:token/:name", controllers.UpdateLabel)
+	router.DELETE("/labels/:username/:repoName/:token/:name", controllers.DeleteLabel)
+	router.POST("/labels/:username/:repoName/:token/sync", controllers.SyncLabels)
+	router.POST("/labels/sync/:token", controllers.SyncAllLabels)
+
+	router.GET("/milestones/:username/:repoName/:token", controllers.ListMilestones)
+	router.POST("/milestones/:username/:repoName/:token", controllers.CreateMilestone)
+	router.GET("/milestones/:username/:repoName/:token/:number", controllers.GetMilestone)
+	router.PATCH("/milestones/:username/:repoName/:token/:number", controllers.UpdateMilestone)
+	router.DELETE("/milestones/:username/:repoName/:token/:number", controllers.DeleteMilestone)
+	router.GET("/milestones/report/:token", controllers.MilestoneReport)
+
+	router.GET("/commits/:username/:repoName/:token", controllers.ListCommits)
+	router.POST("/commits/:username/:repoName/:token", controllers.CreateCommit)
+	router.GET("/commits/:username/:repoName/:token/compare", controllers.CompareCommits)
+	router.GET("/commits/:username/:repoName/:token/changelog", controllers.Changelog)
+	router.GET("/commits/:username/:repoName/:token/:sha", controllers.GetCommit)
+
+	router.GET("/contents/:username/:repoName/:token/*path", controllers.GetContents)
+	router.POST("/contents/:username/:repoName/:token/*path", controllers.CreateFile)
+	router.PUT("/contents/:username/:repoName/:token/*path", controllers.UpdateFile)
+	router.DELETE("/contents/:username/:repoName/:token/*path", controllers.DeleteFile)
+	router.GET("/archive/:username/:repoName/:token/:format", controllers.DownloadArchive)
+
+	router.GET("/forks/:username/:repoName/:token", controllers.ListForks)
+	router.POST("/forks/:username/:repoName/:token", controllers.CreateFork)
+	router.GET("/forks/:username/:repoName/:token/divergence", controllers.ForkDivergence)
+	router.POST("/forks/:username/:repoName/:token/sync", controllers.SyncFork)
+
+	router.GET("/topics/:username/:repoName/:token", controllers.GetTopics)
+	router.PUT("/topics/:username/:repoName/:token", controllers.ReplaceTopics)
+	router.GET("/properties/:username/:repoName/:token", controllers.GetCustomProperties)
+	router.PATCH("/properties/:username/:repoName/:token", controllers.UpdateCustomProperties)
+	router.GET("/catalog/:username/:token", controllers.Catalog)
+
+	router.GET("/secrets/:username/:repoName/:token", controllers.ListRepoSecrets)
+	router.PUT("/secrets/:username/:repoName/:token/:name", controllers.SetRepoSecret)
+	router.DELETE("/secrets/:username/:repoName/:token/:name", controllers.DeleteRepoSecret)
+	router.GET("/variables/:username/:repoName/:token", controllers.ListRepoVariables)
+	router.PUT("/variables/:username/:repoName/:token/:name", controllers.SetRepoVariable)
+	router.DELETE("/variables/:username/:repoName/:token/:name", controllers.DeleteRepoVariable)
+	router.GET("/orgs/:org/:token/secrets", controllers.ListOrgSecrets)
+	router.PUT("/orgs/:org/:token/secrets/:name", controllers.SetOrgSecret)
+	router.DELETE("/orgs/:org/:token/secrets/:name", controllers.DeleteOrgSecret)
+	router.GET("/orgs/:org/:token/variables", controllers.ListOrgVariables)
+	router.PUT("/orgs/:org/:token/variables/:name", controllers.SetOrgVariable)
+	router.DELETE("/orgs/:org/:token/variables/:name", controllers.DeleteOrgVariable)
+
+	router.GET("/actions/:username/:repoName/:token/workflows", controllers.ListWorkflows)
+	router.POST("/actions/:username/:repoName/:token/workflows/:workflow/dispatch", controllers.DispatchWorkflow)
+	router.GET("/actions/:username/:repoName/:token/runs", controllers.ListWorkflowRuns)
+	router.GET("/actions/:username/:repoName/:token/runs/:runID", controllers.GetWorkflowRun)
+	router.GET("/actions/:username/:repoName/:token/runs/:runID/jobs", controllers.ListWorkflowJobs)
+	router.GET("/actions/:username/:repoName/:token/runs/:runID/artifacts", controllers.ListArtifacts)
+	router.POST("/actions/:username/:repoName/:token/runs/:runID/rerun", controllers.RerunWorkflowRun)
+	router.POST("/actions/:username/:repoName/:token/runs/:runID/cancel", controllers.CancelWorkflowRun)
+	router.GET("/actions/:username/:repoName/:token/jobs/:jobID/logs", controllers.DownloadJobLogs)
+	router.GET("/actions/:username/:repoName/:token/artifacts", controllers.ListArtifacts)
+	router.GET("/actions/:username/:repoName/:token/artifacts/:artifactID", controllers.DownloadArtifact)
+
+	router.POST("/deploy-keys/rotate/:token", controllers.RotateDeployKeys)
+	router.GET("/deploy-keys/:username/:repoName/:token", controllers.ListDeployKeys)
+	router.POST("/deploy-keys/:username/:repoName/:token", controllers.AddDeployKey)
+	router.DELETE("/deploy-keys/:username/:repoName/:token/:id", controllers.DeleteDeployKey)
+
+	router.POST("/statuses/:username/:repoName/:sha", controllers.CreateStatus)
+	router.POST("/check-runs/:username/:repoName", controllers.CreateCheckRun)
+	router.PATCH("/check-runs/:username/:repoName/:id", controllers.UpdateCheckRun)
+
+	router.GET("/releases/:username/:repoName/:token", controllers.ListReleases)
+	router.POST("/releases/:username/:repoName/:token", controllers.CreateRelease)
+	router.GET("/releases/:username/:repoName/:token/notes", controllers.ReleaseNotes)
+	router.GET("/releases/:username/:repoName/:token/:id", controllers.GetRelease)
+	router.PATCH("/releases/:username/:repoName/:token/:id", controllers.UpdateRelease)
+	router.DELETE("/releases/:username/:repoName/:token/:id", controllers.DeleteRelease)
+	router.GET("/releases/:username/:repoName/:token/:id/assets", controllers.ListReleaseAssets)
+	router.POST("/releases/:username/:repoName/:token/:id/assets", controllers.UploadReleaseAsset)
+	router.GET("/releases/:username/:repoName/:token/assets/:assetID", controllers.DownloadReleaseAsset)
+	router.DELETE("/releases/:username/:repoName/:token/assets/:assetID", controllers.DeleteReleaseAsset)
+
+	router.GET("/tags/:username/:repoName/:token", controllers.ListTags)
+	router.POST("/tags/:username/:repoName/:token", controllers.CreateTag)
+
+	router.POST("/webhooks/github", controllers.GithubWebhook)
+	router.GET("/events/stream", controllers.EventStream)
 	router.GET("/", controllers.Index)
 }
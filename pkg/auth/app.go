@@ -0,0 +1,266 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/models"
+	"github.com/google/go-github/v50/github"
+	"golang.org/x/oauth2"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// jwtLifetime is how long an App JWT is valid; GitHub accepts at most 10 minutes.
+	jwtLifetime = 9 * time.Minute
+	// clockSkew backdates the JWTs issued by the App, in case its clock runs ahead of GitHub's.
+	clockSkew = time.Minute
+	// tokenRefresh is how long before its expiry an installation token is replaced.
+	tokenRefresh = 5 * time.Minute
+)
+
+// App authenticates as a GitHub App to act on repositories through its installations.
+// Installation tokens are cached until shortly before they expire.
+type App struct {
+	ID  int64
+	Key *rsa.PrivateKey
+	// APIKey is the bearer token callers of the endpoints acting as the App must present. Without
+	// one, the endpoints refuse every request.
+	APIKey string
+
+	// baseURL overrides the GitHub API URL, for tests.
+	baseURL string
+
+	// mu guards the caches of installation IDs, keyed by lowercase repository full name, and of
+	// tokens. They are created on first use, so that an App built as a literal works too.
+	mu            sync.Mutex
+	installations map[string]int64
+	tokens        map[int64]*github.InstallationToken
+}
+
+// NewApp creates an App from its ID and PEM-encoded private key.
+//
+// Parameters:
+//   - id: The ID of the GitHub App.
+//   - key: The private key of the App, in PKCS #1 or PKCS #8 PEM form.
+//
+// Returns:
+//   - *App: The App.
+//   - error: An error if the key is not a PEM-encoded RSA private key.
+func NewApp(id int64, key []byte) (*App, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, errors.New("github app: private key is not PEM-encoded")
+	}
+	private, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+	if err != nil {
+		parsed, pkcs8Err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if pkcs8Err != nil {
+			return nil, fmt.Errorf("github app: parsing private key: %w", err)
+		}
+		var ok bool
+		if private, ok = parsed.(*rsa.PrivateKey); !ok {
+			return nil, errors.New("github app: private key is not an RSA key")
+		}
+	}
+	return &App{ID: id, Key: private}, nil
+}
+
+// AppFromEnv creates the App configured by the GITHUB_APP_ID environment variable, with the private
+// key in GITHUB_APP_PRIVATE_KEY or in the file named by GITHUB_APP_PRIVATE_KEY_FILE, and the API key
+// of its endpoints in GITHUB_APP_API_KEY. The API key is required, since those endpoints take no
+// GitHub token and would otherwise act as the App for anyone.
+//
+// Returns:
+//   - *App: The App, or nil if GITHUB_APP_ID is unset.
+//   - error: An error if the ID or the private key is invalid, or the API key is missing.
+func AppFromEnv() (*App, error) {
+	value := os.Getenv("GITHUB_APP_ID")
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GITHUB_APP_ID %q: %w", value, err)
+	}
+	apiKey := os.Getenv("GITHUB_APP_API_KEY")
+	if apiKey == "" {
+		return nil, errors.New("GITHUB_APP_API_KEY is required when GITHUB_APP_ID is set")
+	}
+	key := []byte(os.Getenv("GITHUB_APP_PRIVATE_KEY"))
+	if path := os.Getenv("GITHUB_APP_PRIVATE_KEY_FILE"); len(key) == 0 && path != "" {
+		if key, err = os.ReadFile(path); err != nil {
+			return nil, err
+		}
+	}
+	app, err := NewApp(id, key)
+	if err != nil {
+		return nil, err
+	}
+	app.APIKey = apiKey
+	return app, nil
+}
+
+var (
+	defaultAppMu sync.RWMutex
+	defaultApp   *App
+)
+
+// DefaultApp returns the App used by the API, or nil if none is configured.
+func DefaultApp() *App {
+	defaultAppMu.RLock()
+	defer defaultAppMu.RUnlock()
+	return defaultApp
+}
+
+// SetDefaultApp replaces the App used by the API.
+func SetDefaultApp(app *App) {
+	defaultAppMu.Lock()
+	defer defaultAppMu.Unlock()
+	defaultApp = app
+}
+
+// JWT issues a JSON Web Token authenticating as the App, signed with RS256.
+//
+// Parameters:
+//   - now: The time the token is issued at.
+//
+// Returns:
+//   - string: The token, valid for 9 minutes.
+//   - error: An error if the token cannot be signed.
+func (a *App) JWT(now time.Time) (string, error) {
+	claims, err := json.Marshal(struct {
+		IssuedAt  int64  `json:"iat"`
+		ExpiresAt int64  `json:"exp"`
+		Issuer    string `json:"iss"`
+	}{now.Add(-clockSkew).Unix(), now.Add(jwtLifetime).Unix(), strconv.FormatInt(a.ID, 10)})
+	if err != nil {
+		return "", err
+	}
+	encoding := base64.RawURLEncoding
+	signed := encoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`)) + "." + encoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, a.Key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signed + "." + encoding.EncodeToString(signature), nil
+}
+
+// InstallationClient creates a GitHub client acting as the installation of the App on a repository.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - interfaces.GitHubClient: A client authenticated with an installation token.
+//   - error: A 404 GitHub error if the App is not installed on the repository, or the error
+//     creating the token.
+func (a *App) InstallationClient(ctx context.Context, owner, repo string) (interfaces.GitHubClient, error) {
+	token, err := a.installationToken(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	client := a.newClient(ctx, token)
+	return &models.GitHubClientWrapper{Client: client}, nil
+}
+
+// installationToken returns a token of the installation of the App on a repository, creating
+// one if none is cached or the cached one is about to expire. The lock only guards the caches, so
+// that requests for other installations do not wait on GitHub; concurrent requests may then each
+// create a token. A cached installation that no longer exists, because the App was reinstalled, is
+// looked up again.
+func (a *App) installationToken(ctx context.Context, owner, repo string) (string, error) {
+	key := strings.ToLower(owner + "/" + repo)
+	a.mu.Lock()
+	id, cached := a.installations[key]
+	token := a.tokens[id]
+	a.mu.Unlock()
+	if cached && token != nil && time.Until(token.GetExpiresAt().Time) > tokenRefresh {
+		return token.GetToken(), nil
+	}
+
+	jwt, err := a.JWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	appClient := a.newClient(ctx, jwt)
+	if !cached {
+		if id, err = a.findInstallation(ctx, appClient, owner, repo, key); err != nil {
+			return "", err
+		}
+	}
+	token, _, err = appClient.Apps.CreateInstallationToken(ctx, id, nil)
+	if cached && isNotFound(err) {
+		a.forget(key, id)
+		if id, err = a.findInstallation(ctx, appClient, owner, repo, key); err != nil {
+			return "", err
+		}
+		token, _, err = appClient.Apps.CreateInstallationToken(ctx, id, nil)
+	}
+	if err != nil {
+		return "", err
+	}
+	a.mu.Lock()
+	if a.tokens == nil {
+		a.tokens = map[int64]*github.InstallationToken{}
+	}
+	a.tokens[id] = token
+	a.mu.Unlock()
+	return token.GetToken(), nil
+}
+
+// findInstallation looks up the installation of the App on a repository and caches its ID.
+func (a *App) findInstallation(ctx context.Context, appClient *github.Client, owner, repo, key string) (int64, error) {
+	installation, _, err := appClient.Apps.FindRepositoryInstallation(ctx, owner, repo)
+	if err != nil {
+		return 0, err
+	}
+	a.mu.Lock()
+	if a.installations == nil {
+		a.installations = map[string]int64{}
+	}
+	a.installations[key] = installation.GetID()
+	a.mu.Unlock()
+	return installation.GetID(), nil
+}
+
+// forget removes an installation that no longer exists, and its token, from the caches.
+func (a *App) forget(key string, id int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.installations, key)
+	delete(a.tokens, id)
+}
+
+// isNotFound reports whether err is a 404 response from the GitHub API.
+func isNotFound(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
+
+// newClient creates a go-github client sending a bearer token.
+func (a *App) newClient(ctx context.Context, token string) *github.Client {
+	client := github.NewClient(oauth2.NewClient(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})))
+	if a.baseURL != "" {
+		client.BaseURL, _ = url.Parse(a.baseURL)
+	}
+	return client
+}
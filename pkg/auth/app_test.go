@@ -0,0 +1,181 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp creates an App with a freshly generated private key.
+func testApp(t *testing.T) *App {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	app, err := NewApp(42, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	require.NoError(t, err)
+	return app
+}
+
+// verifyJWT checks the RS256 signature of a JWT issued by the App and returns its claims.
+func verifyJWT(t *testing.T, app *App, token string) map[string]interface{} {
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	require.NoError(t, rsa.VerifyPKCS1v15(&app.Key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims map[string]interface{}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	return claims
+}
+
+// TestNewAppInvalidKey tests that a key that is not a PEM-encoded RSA key is rejected.
+func TestNewAppInvalidKey(t *testing.T) {
+	_, err := NewApp(42, []byte("not a key"))
+	assert.Error(t, err)
+	_, err = NewApp(42, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: []byte("garbage")}))
+	assert.Error(t, err)
+}
+
+// TestAppFromEnvRequiresAPIKey tests that an App is not configured without the API key of its endpoints.
+func TestAppFromEnvRequiresAPIKey(t *testing.T) {
+	t.Setenv("GITHUB_APP_ID", "42")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY", "")
+	t.Setenv("GITHUB_APP_PRIVATE_KEY_FILE", "")
+	t.Setenv("GITHUB_APP_API_KEY", "")
+	app, err := AppFromEnv()
+	assert.Nil(t, app)
+	assert.ErrorContains(t, err, "GITHUB_APP_API_KEY")
+}
+
+// TestAppJWT tests the claims and the signature of the App JWT.
+func TestAppJWT(t *testing.T) {
+	app := testApp(t)
+	now := time.Unix(1700000000, 0)
+	token, err := app.JWT(now)
+	require.NoError(t, err)
+
+	claims := verifyJWT(t, app, token)
+	assert.Equal(t, "42", claims["iss"])
+	assert.Equal(t, float64(now.Add(-clockSkew).Unix()), claims["iat"])
+	assert.Equal(t, float64(now.Add(jwtLifetime).Unix()), claims["exp"])
+}
+
+// TestAppInstallationClient tests that installation tokens are created with the App JWT and cached.
+func TestAppInstallationClient(t *testing.T) {
+	app := testApp(t)
+	var tokensCreated int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch {
+		case r.URL.Path == "/repos/octocat/hello/installation":
+			verifyJWT(t, app, bearer)
+			_, _ = w.Write([]byte(`{"id": 7}`))
+		case r.URL.Path == "/app/installations/7/access_tokens" && r.Method == http.MethodPost:
+			verifyJWT(t, app, bearer)
+			tokensCreated++
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      "ghs_installation",
+				"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+			})
+		case r.URL.Path == "/repos/octocat/hello" && bearer == "ghs_installation":
+			_, _ = w.Write([]byte(`{"name": "hello"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	app.baseURL = server.URL + "/"
+
+	for i := 0; i < 2; i++ {
+		client, err := app.InstallationClient(context.Background(), "octocat", "hello")
+		require.NoError(t, err)
+		repo, _, err := client.GetRepositories(context.Background(), "octocat", "hello")
+		require.NoError(t, err)
+		assert.Equal(t, "hello", repo.GetName())
+	}
+	assert.Equal(t, 1, tokensCreated)
+
+	_, err := app.InstallationClient(context.Background(), "octocat", "other")
+	assert.Error(t, err)
+}
+
+// TestAppLiteralInstallationToken tests that an App built as a literal caches installations and tokens.
+func TestAppLiteralInstallationToken(t *testing.T) {
+	key := testApp(t).Key
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octocat/hello/installation":
+			_, _ = w.Write([]byte(`{"id": 7}`))
+		case "/app/installations/7/access_tokens":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      "ghs_installation",
+				"expires_at": time.Now().Add(time.Hour).Format(time.RFC3339),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	app := &App{ID: 42, Key: key, baseURL: server.URL + "/"}
+
+	token, err := app.installationToken(context.Background(), "octocat", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_installation", token)
+	assert.Equal(t, int64(7), app.installations["octocat/hello"])
+}
+
+// TestAppInstallationClientReinstalled tests that an installation removed since it was cached is looked up again.
+func TestAppInstallationClientReinstalled(t *testing.T) {
+	app := testApp(t)
+	installation := 7
+	var lookups int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/octocat/hello/installation":
+			lookups++
+			_ = json.NewEncoder(w).Encode(map[string]int{"id": installation})
+		case fmt.Sprintf("/app/installations/%d/access_tokens", installation):
+			w.WriteHeader(http.StatusCreated)
+			// The token expires within the refresh margin, so that every call creates a new one.
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"token":      fmt.Sprintf("ghs_%d", installation),
+				"expires_at": time.Now().Add(time.Minute).Format(time.RFC3339),
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	app.baseURL = server.URL + "/"
+
+	token, err := app.installationToken(context.Background(), "octocat", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_7", token)
+
+	installation = 8
+	token, err = app.installationToken(context.Background(), "octocat", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_8", token)
+	assert.Equal(t, 2, lookups)
+	assert.Equal(t, int64(8), app.installations["octocat/hello"])
+}
@@ -0,0 +1,317 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// DefaultRetryInterval is how long a sink worker waits before retrying
+// after a failed delivery.
+const DefaultRetryInterval = 30 * time.Second
+
+// Bus is an in-process publish/subscribe event bus.
+// Published events are handed to in-process subscribers immediately, kept in a
+// bounded replay buffer and recorded in the Store for every registered Sink.
+// A worker per sink delivers pending events in order and acknowledges them, so
+// each sink sees every event at least once, even across restarts when a
+// persistent Store is used.
+type Bus struct {
+	store         Store
+	RetryInterval time.Duration
+
+	mu          sync.Mutex
+	sinks       []*sinkWorker
+	replay      *ReplayBuffer
+	subscribers map[int]*subscriber
+	nextSub     int
+	lastID      uint64
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+}
+
+// subscriber is an in-process subscription. lastID is the ID of the last event sent on ch; once
+// an event could not be sent, the subscriber is lagging until a Gap event reports the loss.
+type subscriber struct {
+	ch      chan Event
+	lastID  uint64
+	lagging bool
+}
+
+// sinkWorker owns the delivery loop for a single sink.
+type sinkWorker struct {
+	sink Sink
+	wake chan struct{}
+}
+
+// NewBus creates a bus recording deliveries in the given store.
+// Sinks are only delivered to once Start has been called.
+func NewBus(store Store) *Bus {
+	return &Bus{
+		store:         store,
+		RetryInterval: DefaultRetryInterval,
+		replay:        NewReplayBuffer(DefaultReplaySize),
+		subscribers:   map[int]*subscriber{},
+	}
+}
+
+// AddSink registers a sink on the bus.
+// Events published before a sink is added are not delivered to it.
+func (b *Bus) AddSink(s Sink) {
+	w := &sinkWorker{sink: s, wake: make(chan struct{}, 1)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.sinks = append(b.sinks, w)
+	if b.ctx != nil {
+		b.startWorker(w)
+	}
+}
+
+// Start launches the sink workers. Each worker first redelivers events left
+// pending in the store, then waits for new events until ctx is cancelled or Close is called.
+func (b *Bus) Start(ctx context.Context) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.ctx != nil {
+		return
+	}
+	b.ctx, b.cancel = context.WithCancel(ctx)
+	for _, w := range b.sinks {
+		b.startWorker(w)
+	}
+}
+
+// Close stops the sink workers and waits for in-flight deliveries to finish.
+// Subscriber channels are closed.
+func (b *Bus) Close() {
+	b.mu.Lock()
+	if b.cancel != nil {
+		b.cancel()
+	}
+	for id, sub := range b.subscribers {
+		close(sub.ch)
+		delete(b.subscribers, id)
+	}
+	b.mu.Unlock()
+	b.wg.Wait()
+}
+
+// Publish assigns an ID and timestamp to the event, records it for every sink
+// and notifies subscribers.
+//
+// Parameters:
+//   - e: The event to publish.
+//
+// Returns:
+//   - Event: The event as published, including its ID and timestamp.
+//   - error: An error if the event cannot be recorded in the store.
+func (b *Bus) Publish(e Event) (Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// IDs are reserved under the lock so subscribers and the replay buffer see them in order.
+	id, err := b.store.NextID()
+	if err != nil {
+		return Event{}, err
+	}
+	e.ID = id
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now().UTC()
+	}
+
+	names := make([]string, len(b.sinks))
+	for i, w := range b.sinks {
+		names[i] = w.sink.Name()
+	}
+	if len(names) > 0 {
+		if err := b.store.Enqueue(e, names); err != nil {
+			return Event{}, err
+		}
+	}
+
+	b.lastID = e.ID
+	b.replay.Add(e)
+	for _, w := range b.sinks {
+		w.notify()
+	}
+	for _, sub := range b.subscribers {
+		sub.send(e)
+	}
+	return e, nil
+}
+
+// send hands an event to the subscriber without blocking. Slow subscribers miss events rather
+// than blocking the publisher, but the first event they receive afterwards is preceded by a Gap
+// event carrying the ID of the last event they did receive, from which they can resume.
+func (s *subscriber) send(e Event) {
+	if s.lagging {
+		select {
+		case s.ch <- Event{ID: s.lastID, Type: Gap, Timestamp: e.Timestamp}:
+			s.lagging = false
+		default:
+			return
+		}
+	}
+	select {
+	case s.ch <- e:
+		s.lastID = e.ID
+	default:
+		s.lagging = true
+	}
+}
+
+// Subscribe registers an in-process subscriber.
+// Events are sent on the returned channel without blocking the publisher; when the
+// buffer is full the event is dropped for that subscriber, and a Gap event is sent
+// before the next event that fits.
+//
+// Parameters:
+//   - buffer: The capacity of the subscriber channel.
+//
+// Returns:
+//   - <-chan Event: The channel receiving published events.
+//   - func(): A function that unsubscribes and closes the channel.
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.subscribe(b.lastID, buffer)
+}
+
+// SubscribeSince registers an in-process subscriber resuming after the event with the given ID.
+// The buffered events published after lastID are returned together with the subscription, so
+// no event is missed or duplicated between the replay and the live channel.
+//
+// Parameters:
+//   - lastID: The ID of the last event the subscriber has seen.
+//   - buffer: The capacity of the subscriber channel.
+//
+// Returns:
+//   - []Event: The buffered events published after lastID, oldest first.
+//   - bool: False if some events after lastID were already evicted from the replay buffer.
+//   - <-chan Event: The channel receiving events published from now on.
+//   - func(): A function that unsubscribes and closes the channel.
+func (b *Bus) SubscribeSince(lastID uint64, buffer int) ([]Event, bool, <-chan Event, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	backlog, complete := b.replay.Since(lastID)
+	if len(backlog) > 0 {
+		lastID = backlog[len(backlog)-1].ID
+	}
+	ch, unsubscribe := b.subscribe(lastID, buffer)
+	return backlog, complete, ch, unsubscribe
+}
+
+// subscribe registers a subscriber channel that has seen the events up to lastID. The caller must hold b.mu.
+func (b *Bus) subscribe(lastID uint64, buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	id := b.nextSub
+	b.nextSub++
+	b.subscribers[id] = &subscriber{ch: ch, lastID: lastID}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subscribers[id]; ok {
+				close(ch)
+				delete(b.subscribers, id)
+			}
+		})
+	}
+}
+
+// startWorker runs the delivery loop of w. The caller must hold b.mu.
+func (b *Bus) startWorker(w *sinkWorker) {
+	ctx := b.ctx
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		w.notify()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-w.wake:
+			}
+			if !b.drain(ctx, w.sink) {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(b.RetryInterval):
+					w.notify()
+				}
+			}
+		}
+	}()
+}
+
+// drain delivers the pending events of a sink in order and reports whether all succeeded.
+func (b *Bus) drain(ctx context.Context, s Sink) bool {
+	pending, err := b.store.Pending(s.Name())
+	if err != nil {
+		log.Printf("events: reading pending events for %s: %v", s.Name(), err)
+		return false
+	}
+	for _, e := range pending {
+		if err := s.Deliver(ctx, e); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("events: delivering event %d to %s: %v", e.ID, s.Name(), err)
+			}
+			return false
+		}
+		if err := b.store.Ack(s.Name(), e.ID); err != nil {
+			log.Printf("events: acknowledging event %d for %s: %v", e.ID, s.Name(), err)
+			return false
+		}
+	}
+	return true
+}
+
+// notify wakes the worker without blocking if a wake-up is already queued.
+func (w *sinkWorker) notify() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultBus = NewBus(NewMemoryStore())
+)
+
+// Default returns the bus used by the package-level Publish function.
+func Default() *Bus {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBus
+}
+
+// SetDefault replaces the bus used by the package-level Publish function.
+func SetDefault(b *Bus) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultBus = b
+}
+
+// Publish builds an event and publishes it on the default bus.
+// Publishing is best effort from the caller's point of view: failures are logged
+// and never affect the response of the request that triggered the event.
+//
+// Parameters:
+//   - typ: The event type.
+//   - owner: The owner of the repository the event refers to.
+//   - repo: The name of the repository the event refers to.
+//   - data: Any JSON-serializable value describing the change, or nil.
+func Publish(typ Type, owner, repo string, data interface{}) {
+	e, err := New(typ, owner, repo, data)
+	if err == nil {
+		_, err = Default().Publish(e)
+	}
+	if err != nil {
+		log.Printf("events: publishing %s for %s/%s: %v", typ, owner, repo, err)
+	}
+}
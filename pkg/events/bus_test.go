@@ -0,0 +1,218 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingSink is a Sink that records delivered events and fails the first failures deliveries.
+type recordingSink struct {
+	mu        sync.Mutex
+	failures  int
+	delivered []Event
+}
+
+func (s *recordingSink) Name() string { return "recording" }
+
+func (s *recordingSink) Deliver(_ context.Context, e Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errors.New("sink unavailable")
+	}
+	s.delivered = append(s.delivered, e)
+	return nil
+}
+
+func (s *recordingSink) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.delivered)
+}
+
+// TestPublishNotifiesSubscribers tests that published events reach subscribers with increasing IDs.
+func TestPublishNotifiesSubscribers(t *testing.T) {
+	bus := NewBus(NewMemoryStore())
+	ch, unsubscribe := bus.Subscribe(2)
+	defer unsubscribe()
+
+	first, err := New(RepoCreated, "octocat", "hello", nil)
+	require.NoError(t, err)
+	_, err = bus.Publish(first)
+	require.NoError(t, err)
+	second, _ := New(RepoDeleted, "octocat", "hello", nil)
+	_, err = bus.Publish(second)
+	require.NoError(t, err)
+
+	e1, e2 := <-ch, <-ch
+	assert.Equal(t, RepoCreated, e1.Type)
+	assert.Equal(t, RepoDeleted, e2.Type)
+	assert.Less(t, e1.ID, e2.ID)
+	assert.False(t, e1.Timestamp.IsZero())
+}
+
+// TestPublishReportsGapToLaggingSubscriber tests that a subscriber whose buffer was full receives
+// a gap event with the ID of the last event it received before the next event.
+func TestPublishReportsGapToLaggingSubscriber(t *testing.T) {
+	bus := NewBus(NewMemoryStore())
+	ch, unsubscribe := bus.Subscribe(2)
+	defer unsubscribe()
+
+	var published []Event
+	for i := 0; i < 3; i++ {
+		e, _ := New(RepoCreated, "octocat", "hello", nil)
+		e, err := bus.Publish(e)
+		require.NoError(t, err)
+		published = append(published, e)
+	}
+	assert.Equal(t, published[0].ID, (<-ch).ID)
+	assert.Equal(t, published[1].ID, (<-ch).ID)
+
+	next, _ := New(RepoDeleted, "octocat", "hello", nil)
+	next, err := bus.Publish(next)
+	require.NoError(t, err)
+	gap := <-ch
+	assert.Equal(t, Gap, gap.Type)
+	assert.Equal(t, published[1].ID, gap.ID)
+	assert.Equal(t, next.ID, (<-ch).ID)
+
+	backlog, complete := bus.replay.Since(gap.ID)
+	assert.True(t, complete)
+	assert.Equal(t, published[2].ID, backlog[0].ID)
+}
+
+// TestSinkRetriesUntilDelivered tests that a failing sink keeps its events pending and receives them on retry.
+func TestSinkRetriesUntilDelivered(t *testing.T) {
+	store := NewMemoryStore()
+	sink := &recordingSink{failures: 2}
+	bus := NewBus(store)
+	bus.RetryInterval = 10 * time.Millisecond
+	bus.AddSink(sink)
+	bus.Start(context.Background())
+	defer bus.Close()
+
+	e, _ := New(PullRequestOpened, "octocat", "hello", map[string]int{"number": 1})
+	_, err := bus.Publish(e)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, 5*time.Millisecond)
+	pending, err := store.Pending(sink.Name())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
+
+// TestFileStoreRedeliversAfterRestart tests that pending events survive reopening the file store.
+func TestFileStoreRedeliversAfterRestart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.json")
+	store, err := NewFileStore(path)
+	require.NoError(t, err)
+
+	bus := NewBus(store)
+	bus.AddSink(&recordingSink{})
+	e, _ := New(RepoCreated, "octocat", "hello", nil)
+	published, err := bus.Publish(e)
+	require.NoError(t, err)
+
+	reopened, err := NewFileStore(path)
+	require.NoError(t, err)
+	sink := &recordingSink{}
+	restarted := NewBus(reopened)
+	restarted.AddSink(sink)
+	restarted.Start(context.Background())
+	defer restarted.Close()
+
+	assert.Eventually(t, func() bool { return sink.count() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Equal(t, published.ID, sink.delivered[0].ID)
+
+	next, err := reopened.NextID()
+	require.NoError(t, err)
+	assert.Greater(t, next, published.ID)
+}
+
+// TestWebhookSinkRetriesServerErrors tests that the webhook sink retries 5xx responses and signs its payload.
+func TestWebhookSinkRetriesServerErrors(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NotEmpty(t, r.Header.Get("X-Hub-Signature-256"))
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sink := NewWebhookSink(server.URL, "secret")
+	sink.Backoff = time.Millisecond
+
+	e, _ := New(RepoCreated, "octocat", "hello", nil)
+	assert.NoError(t, sink.Deliver(context.Background(), e))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+}
+
+// TestFromWebhookMergedPullRequest tests that a closed and merged pull request delivery becomes a pr.merged event.
+func TestFromWebhookMergedPullRequest(t *testing.T) {
+	payload := `{"action": "closed", "pull_request": {"number": 7, "merged": true},
+		"repository": {"name": "hello", "owner": {"login": "octocat"}}}`
+
+	received, err := FromWebhook("pull_request", []byte(payload))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, PullRequestMerged, received[0].Type)
+	assert.Equal(t, "octocat", received[0].Owner)
+	assert.Equal(t, "hello", received[0].Repo)
+}
+
+// TestFromWebhookChecks tests that completed check suites and final statuses become checks.completed events.
+func TestFromWebhookChecks(t *testing.T) {
+	repo := `"repository": {"name": "hello", "owner": {"login": "octocat"}}`
+
+	received, err := FromWebhook("check_suite", []byte(`{"action": "completed", "check_suite": {"conclusion": "success"}, `+repo+`}`))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, ChecksCompleted, received[0].Type)
+
+	received, err = FromWebhook("status", []byte(`{"state": "failure", "sha": "abc", `+repo+`}`))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, ChecksCompleted, received[0].Type)
+
+	received, err = FromWebhook("status", []byte(`{"state": "pending", "sha": "abc", `+repo+`}`))
+	require.NoError(t, err)
+	assert.Empty(t, received)
+}
+
+// TestFromWebhookRepositoryMoves tests that renamed and transferred repository deliveries carry their former location.
+func TestFromWebhookRepositoryMoves(t *testing.T) {
+	received, err := FromWebhook("repository", []byte(`{"action": "renamed", "changes": {"repository": {"name": {"from": "hello"}}},
+		"repository": {"name": "world", "owner": {"login": "octocat"}}}`))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, RepoRenamed, received[0].Type)
+	var move RepoMove
+	require.NoError(t, json.Unmarshal(received[0].Data, &move))
+	assert.Equal(t, "octocat", move.FromOwner)
+	assert.Equal(t, "hello", move.FromRepo)
+
+	received, err = FromWebhook("repository", []byte(`{"action": "transferred", "changes": {"owner": {"from": {"user": {"login": "octocat"}}}},
+		"repository": {"name": "hello", "owner": {"login": "octo-org"}}}`))
+	require.NoError(t, err)
+	require.Len(t, received, 1)
+	assert.Equal(t, RepoTransferred, received[0].Type)
+	assert.Equal(t, "octo-org", received[0].Owner)
+	require.NoError(t, json.Unmarshal(received[0].Data, &move))
+	assert.Equal(t, "octocat", move.FromOwner)
+	assert.Equal(t, "hello", move.FromRepo)
+}
@@ -0,0 +1,49 @@
+package events
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// NewBusFromEnv creates a bus configured from environment variables:
+//   - EVENTS_STORE: Path of the JSON file tracking pending deliveries. An in-memory store is used when unset.
+//   - EVENTS_WEBHOOK_URLS: Comma-separated list of URLs receiving events as HTTP POST requests.
+//   - EVENTS_WEBHOOK_SECRET: Secret used to sign webhook deliveries.
+//   - EVENTS_FILE: Path of an NDJSON file events are appended to.
+//   - EVENTS_STDOUT: When true, events are also written to the standard output as NDJSON.
+//
+// Returns:
+//   - *Bus: The configured bus, not yet started.
+//   - error: An error if the store cannot be opened or EVENTS_STDOUT is not a boolean.
+func NewBusFromEnv() (*Bus, error) {
+	var store Store = NewMemoryStore()
+	if path := os.Getenv("EVENTS_STORE"); path != "" {
+		fs, err := NewFileStore(path)
+		if err != nil {
+			return nil, err
+		}
+		store = fs
+	}
+	bus := NewBus(store)
+
+	secret := os.Getenv("EVENTS_WEBHOOK_SECRET")
+	for _, url := range strings.Split(os.Getenv("EVENTS_WEBHOOK_URLS"), ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			bus.AddSink(NewWebhookSink(url, secret))
+		}
+	}
+	if path := os.Getenv("EVENTS_FILE"); path != "" {
+		bus.AddSink(NewFileSink(path))
+	}
+	if value := os.Getenv("EVENTS_STDOUT"); value != "" {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			bus.AddSink(NewStdoutSink())
+		}
+	}
+	return bus, nil
+}
@@ -0,0 +1,87 @@
+package events
+
+import (
+	"encoding/json"
+	"github.com/google/go-github/v50/github"
+	"time"
+)
+
+// Type identifies the kind of state change an Event describes.
+type Type string
+
+// Event types published by the API and by inbound GitHub webhooks.
+const (
+	RepoCreated Type = "repo.created"
+	RepoDeleted Type = "repo.deleted"
+
+	// RepoRenamed and RepoTransferred are published for the new location of a repository,
+	// with a RepoMove as data.
+	RepoRenamed     Type = "repo.renamed"
+	RepoTransferred Type = "repo.transferred"
+
+	RepoArchived   Type = "repo.archived"
+	RepoUnarchived Type = "repo.unarchived"
+
+	PullRequestOpened       Type = "pr.opened"
+	PullRequestClosed       Type = "pr.closed"
+	PullRequestMerged       Type = "pr.merged"
+	PullRequestReopened     Type = "pr.reopened"
+	PullRequestSynchronized Type = "pr.synchronized"
+	PullRequestEdited       Type = "pr.edited"
+	PullRequestLabeled      Type = "pr.labeled"
+	PullRequestReviewed     Type = "pr.reviewed"
+
+	// ChecksCompleted is published when a check suite completes or a commit status
+	// leaves the pending state.
+	ChecksCompleted Type = "checks.completed"
+
+	// PullRequestStaleAction records an action taken by a stale pull request policy.
+	PullRequestStaleAction Type = "pr.stale_action"
+
+	// Gap is sent to resuming stream subscribers when events after their last seen ID
+	// are no longer available for replay, and to subscribers that fell behind and missed
+	// events. Its ID is the last event the subscriber received.
+	Gap Type = "gap"
+)
+
+// Event is a single state change emitted on the bus.
+// ID is assigned by the Bus when the event is published and increases monotonically,
+// so consumers can use it to deduplicate deliveries.
+type Event struct {
+	ID        uint64          `json:"id"`
+	Type      Type            `json:"type"`
+	Owner     string          `json:"owner,omitempty"`
+	Repo      string          `json:"repo,omitempty"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// RepoMove is the data of RepoRenamed and RepoTransferred events.
+type RepoMove struct {
+	FromOwner  string             `json:"from_owner"`
+	FromRepo   string             `json:"from_repo"`
+	Repository *github.Repository `json:"repository"`
+}
+
+// New creates an Event of the given type for a repository, encoding data as the event payload.
+//
+// Parameters:
+//   - typ: The event type.
+//   - owner: The owner of the repository the event refers to.
+//   - repo: The name of the repository the event refers to.
+//   - data: Any JSON-serializable value describing the change, or nil.
+//
+// Returns:
+//   - Event: The new event, without an ID until it is published.
+//   - error: An error if data cannot be encoded as JSON.
+func New(typ Type, owner, repo string, data interface{}) (Event, error) {
+	e := Event{Type: typ, Owner: owner, Repo: repo}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return Event{}, err
+		}
+		e.Data = raw
+	}
+	return e, nil
+}
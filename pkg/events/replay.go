@@ -0,0 +1,97 @@
+package events
+
+import (
+	"strings"
+)
+
+// DefaultReplaySize is the number of recent events a Bus keeps for resuming subscribers.
+const DefaultReplaySize = 1000
+
+// ReplayBuffer is a bounded ring buffer of the most recently published events.
+// It is not safe for concurrent use; the Bus guards it with its own lock.
+type ReplayBuffer struct {
+	events []Event
+	start  int
+	count  int
+}
+
+// NewReplayBuffer creates a buffer retaining at most size events.
+func NewReplayBuffer(size int) *ReplayBuffer {
+	if size < 1 {
+		size = 1
+	}
+	return &ReplayBuffer{events: make([]Event, size)}
+}
+
+// Add appends an event, evicting the oldest one when the buffer is full.
+func (r *ReplayBuffer) Add(e Event) {
+	if r.count < len(r.events) {
+		r.events[(r.start+r.count)%len(r.events)] = e
+		r.count++
+		return
+	}
+	r.events[r.start] = e
+	r.start = (r.start + 1) % len(r.events)
+}
+
+// Since returns the buffered events with an ID greater than id, oldest first.
+//
+// Parameters:
+//   - id: The ID of the last event the caller has seen.
+//
+// Returns:
+//   - []Event: The buffered events published after id.
+//   - bool: False if events after id have already been evicted, meaning the caller missed some, or
+//     if id is newer than every buffered event, meaning it was issued before the IDs restarted.
+func (r *ReplayBuffer) Since(id uint64) ([]Event, bool) {
+	if r.count == 0 {
+		return nil, id == 0
+	}
+	var list []Event
+	for i := 0; i < r.count; i++ {
+		e := r.events[(r.start+i)%len(r.events)]
+		if e.ID > id {
+			list = append(list, e)
+		}
+	}
+	oldest, newest := r.events[r.start].ID, r.events[(r.start+r.count-1)%len(r.events)].ID
+	complete := oldest <= id+1 && id <= newest
+	return list, complete
+}
+
+// Filter selects events by owner, repository and type.
+// Empty fields match every event; values within a field are alternatives.
+type Filter struct {
+	Owners []string
+	Repos  []string
+	Types  []Type
+}
+
+// Match reports whether the event passes the filter.
+// Owners and repositories are compared case-insensitively, as on GitHub.
+func (f Filter) Match(e Event) bool {
+	if len(f.Owners) > 0 && !containsFold(f.Owners, e.Owner) {
+		return false
+	}
+	if len(f.Repos) > 0 && !containsFold(f.Repos, e.Repo) {
+		return false
+	}
+	if len(f.Types) > 0 {
+		for _, t := range f.Types {
+			if t == e.Type {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplayBufferSince tests that the replay buffer evicts old events and reports missed ones.
+func TestReplayBufferSince(t *testing.T) {
+	buffer := NewReplayBuffer(3)
+	for id := uint64(1); id <= 5; id++ {
+		buffer.Add(Event{ID: id})
+	}
+
+	replayed, complete := buffer.Since(3)
+	assert.True(t, complete)
+	require.Len(t, replayed, 2)
+	assert.Equal(t, uint64(4), replayed[0].ID)
+	assert.Equal(t, uint64(5), replayed[1].ID)
+
+	replayed, complete = buffer.Since(1)
+	assert.False(t, complete)
+	assert.Len(t, replayed, 3)
+
+	// An ID newer than every buffered event was issued before the IDs restarted.
+	replayed, complete = buffer.Since(42)
+	assert.False(t, complete)
+	assert.Empty(t, replayed)
+
+	replayed, complete = NewReplayBuffer(3).Since(42)
+	assert.False(t, complete)
+	assert.Empty(t, replayed)
+}
+
+// TestSubscribeSinceResumesStream tests that a resuming subscriber gets the backlog and then live events.
+func TestSubscribeSinceResumesStream(t *testing.T) {
+	bus := NewBus(NewMemoryStore())
+	for _, typ := range []Type{RepoCreated, PullRequestOpened, PullRequestMerged} {
+		e, _ := New(typ, "octocat", "hello", nil)
+		_, err := bus.Publish(e)
+		require.NoError(t, err)
+	}
+
+	backlog, complete, ch, unsubscribe := bus.SubscribeSince(1, 1)
+	defer unsubscribe()
+	assert.True(t, complete)
+	require.Len(t, backlog, 2)
+	assert.Equal(t, PullRequestOpened, backlog[0].Type)
+
+	e, _ := New(RepoDeleted, "octocat", "hello", nil)
+	_, err := bus.Publish(e)
+	require.NoError(t, err)
+	live := <-ch
+	assert.Equal(t, uint64(4), live.ID)
+}
+
+// TestFilterMatch tests filtering events by owner, repository and type.
+func TestFilterMatch(t *testing.T) {
+	e := Event{Type: PullRequestOpened, Owner: "Octocat", Repo: "hello"}
+
+	assert.True(t, Filter{}.Match(e))
+	assert.True(t, Filter{Owners: []string{"octocat"}, Repos: []string{"hello"}}.Match(e))
+	assert.True(t, Filter{Types: []Type{RepoCreated, PullRequestOpened}}.Match(e))
+	assert.False(t, Filter{Repos: []string{"world"}}.Match(e))
+	assert.False(t, Filter{Types: []Type{PullRequestMerged}}.Match(e))
+}
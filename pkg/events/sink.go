@@ -0,0 +1,197 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Sink receives events published on the Bus.
+// Deliver must return nil only once the event has been durably handed over;
+// any error leaves the event pending so the Bus retries it later.
+type Sink interface {
+	// Name uniquely identifies the sink in the Store.
+	Name() string
+
+	// Deliver sends a single event to the sink.
+	Deliver(ctx context.Context, e Event) error
+}
+
+// WriterSink writes each event as a single JSON line (NDJSON) to an io.Writer.
+type WriterSink struct {
+	name string
+	mu   sync.Mutex
+	w    io.Writer
+}
+
+// NewWriterSink creates a sink writing NDJSON to w.
+//
+// Parameters:
+//   - name: The unique name of the sink.
+//   - w: The writer receiving one JSON document per line.
+//
+// Returns:
+//   - *WriterSink: The new sink.
+func NewWriterSink(name string, w io.Writer) *WriterSink {
+	return &WriterSink{name: name, w: w}
+}
+
+// NewStdoutSink creates a sink writing NDJSON to the standard output.
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink("stdout", os.Stdout)
+}
+
+// Name returns the name of the sink.
+func (s *WriterSink) Name() string {
+	return s.name
+}
+
+// Deliver writes the event as a JSON line.
+func (s *WriterSink) Deliver(_ context.Context, e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(line, '\n'))
+	return err
+}
+
+// FileSink appends each event as a JSON line to a file.
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink creates a sink appending NDJSON to the file at path.
+// The file is created on the first delivery if it does not exist.
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+// Name returns the name of the sink, derived from the file path.
+func (s *FileSink) Name() string {
+	return "file:" + s.path
+}
+
+// Deliver appends the event to the file and syncs it to disk.
+func (s *FileSink) Deliver(_ context.Context, e Event) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WebhookSink posts each event as JSON to an HTTP endpoint.
+// When Secret is set, the body is signed with HMAC-SHA256 in the
+// X-Hub-Signature-256 header, using the same scheme as GitHub webhooks.
+type WebhookSink struct {
+	URL        string
+	Secret     string
+	Client     *http.Client
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// NewWebhookSink creates a sink posting events to url with three retries
+// and an exponential backoff starting at one second.
+func NewWebhookSink(url, secret string) *WebhookSink {
+	return &WebhookSink{
+		URL:        url,
+		Secret:     secret,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		MaxRetries: 3,
+		Backoff:    time.Second,
+	}
+}
+
+// Name returns the name of the sink, derived from the target URL.
+func (s *WebhookSink) Name() string {
+	return "webhook:" + s.URL
+}
+
+// Deliver posts the event, retrying on transport errors, 429 and 5xx responses.
+// Any other non-2xx response is returned as an error without retrying.
+func (s *WebhookSink) Deliver(ctx context.Context, e Event) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	backoff := s.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := s.post(ctx, e, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt >= s.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post performs a single delivery attempt and reports whether a failure is worth retrying.
+func (s *WebhookSink) post(ctx context.Context, e Event, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Type", string(e.Type))
+	req.Header.Set("X-Event-ID", strconv.FormatUint(e.ID, 10))
+	if s.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(s.Secret))
+		mac.Write(body)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retry, fmt.Errorf("webhook %s responded with status %d", s.URL, resp.StatusCode)
+}
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store tracks event IDs and the deliveries that each sink has not yet acknowledged.
+// Events are kept until Ack is called for a sink, which gives at-least-once delivery
+// across retries and process restarts.
+type Store interface {
+	// NextID reserves and returns the next event ID.
+	NextID() (uint64, error)
+
+	// Enqueue records the event as pending for each of the named sinks.
+	Enqueue(e Event, sinks []string) error
+
+	// Pending returns the unacknowledged events for a sink, ordered by ID.
+	Pending(sink string) ([]Event, error)
+
+	// Ack marks the event with the given ID as delivered to the sink.
+	Ack(sink string, id uint64) error
+}
+
+// storeState is the serializable content shared by MemoryStore and FileStore.
+type storeState struct {
+	LastID  uint64                      `json:"last_id"`
+	Pending map[string]map[uint64]Event `json:"pending"`
+}
+
+func (s *storeState) enqueue(e Event, sinks []string) {
+	if s.Pending == nil {
+		s.Pending = map[string]map[uint64]Event{}
+	}
+	for _, name := range sinks {
+		if s.Pending[name] == nil {
+			s.Pending[name] = map[uint64]Event{}
+		}
+		s.Pending[name][e.ID] = e
+	}
+}
+
+func (s *storeState) pending(sink string) []Event {
+	list := make([]Event, 0, len(s.Pending[sink]))
+	for _, e := range s.Pending[sink] {
+		list = append(list, e)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list
+}
+
+func (s *storeState) ack(sink string, id uint64) {
+	delete(s.Pending[sink], id)
+	if len(s.Pending[sink]) == 0 {
+		delete(s.Pending, sink)
+	}
+}
+
+// MemoryStore is a Store that keeps pending deliveries in memory only.
+// Pending deliveries are lost when the process exits.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state storeState
+}
+
+// NewMemoryStore creates an empty in-memory Store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// NextID reserves and returns the next event ID.
+func (s *MemoryStore) NextID() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.LastID++
+	return s.state.LastID, nil
+}
+
+// Enqueue records the event as pending for each of the named sinks.
+func (s *MemoryStore) Enqueue(e Event, sinks []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.enqueue(e, sinks)
+	return nil
+}
+
+// Pending returns the unacknowledged events for a sink, ordered by ID.
+func (s *MemoryStore) Pending(sink string) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.pending(sink), nil
+}
+
+// Ack marks the event with the given ID as delivered to the sink.
+func (s *MemoryStore) Ack(sink string, id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.ack(sink, id)
+	return nil
+}
+
+// FileStore is a Store persisted as a JSON document on the local filesystem.
+// The file is rewritten atomically after every change so pending deliveries
+// survive a restart and are redelivered when the Bus starts again.
+type FileStore struct {
+	mu    sync.Mutex
+	path  string
+	state storeState
+}
+
+// NewFileStore opens the store at path, loading any pending deliveries it contains.
+// The file is created on the first write if it does not exist.
+//
+// Parameters:
+//   - path: The location of the JSON store file.
+//
+// Returns:
+//   - *FileStore: The opened store.
+//   - error: An error if an existing file cannot be read or decoded.
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// NextID reserves and returns the next event ID.
+func (s *FileStore) NextID() (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.LastID++
+	if err := s.save(); err != nil {
+		s.state.LastID--
+		return 0, err
+	}
+	return s.state.LastID, nil
+}
+
+// Enqueue records the event as pending for each of the named sinks.
+func (s *FileStore) Enqueue(e Event, sinks []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.enqueue(e, sinks)
+	return s.save()
+}
+
+// Pending returns the unacknowledged events for a sink, ordered by ID.
+func (s *FileStore) Pending(sink string) ([]Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state.pending(sink), nil
+}
+
+// Ack marks the event with the given ID as delivered to the sink.
+func (s *FileStore) Ack(sink string, id uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state.ack(sink, id)
+	return s.save()
+}
+
+// save writes the store to a temporary file and renames it over the original.
+func (s *FileStore) save() error {
+	data, err := json.Marshal(&s.state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,128 @@
+package events
+
+import (
+	"encoding/json"
+	"github.com/google/go-github/v50/github"
+)
+
+// transferChanges holds the former owner of a transferred repository, which the
+// webhook types of go-github do not decode.
+type transferChanges struct {
+	Changes struct {
+		Owner struct {
+			From struct {
+				User         *github.User         `json:"user"`
+				Organization *github.Organization `json:"organization"`
+			} `json:"from"`
+		} `json:"owner"`
+	} `json:"changes"`
+}
+
+// FromWebhook translates an inbound GitHub webhook delivery into bus events.
+// Deliveries that do not describe a change tracked by the bus yield no events.
+//
+// Parameters:
+//   - eventType: The value of the X-GitHub-Event header.
+//   - payload: The validated JSON payload of the delivery.
+//
+// Returns:
+//   - []Event: The events to publish, without IDs.
+//   - error: An error if the payload cannot be parsed.
+func FromWebhook(eventType string, payload []byte) ([]Event, error) {
+	parsed, err := github.ParseWebHook(eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var typ Type
+	var owner, repo string
+	var data interface{}
+
+	switch ev := parsed.(type) {
+	case *github.PullRequestEvent:
+		switch ev.GetAction() {
+		case "opened":
+			typ = PullRequestOpened
+		case "reopened":
+			typ = PullRequestReopened
+		case "synchronize":
+			typ = PullRequestSynchronized
+		case "edited":
+			typ = PullRequestEdited
+		case "labeled":
+			typ = PullRequestLabeled
+		case "closed":
+			typ = PullRequestClosed
+			if ev.GetPullRequest().GetMerged() {
+				typ = PullRequestMerged
+			}
+		default:
+			return nil, nil
+		}
+		owner, repo = ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName()
+		data = ev.GetPullRequest()
+	case *github.PullRequestReviewEvent:
+		if ev.GetAction() != "submitted" {
+			return nil, nil
+		}
+		typ = PullRequestReviewed
+		owner, repo = ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName()
+		data = ev.GetReview()
+	case *github.CheckSuiteEvent:
+		if ev.GetAction() != "completed" {
+			return nil, nil
+		}
+		typ = ChecksCompleted
+		owner, repo = ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName()
+		data = ev.GetCheckSuite()
+	case *github.StatusEvent:
+		if ev.GetState() == "pending" {
+			return nil, nil
+		}
+		typ = ChecksCompleted
+		owner, repo = ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName()
+		data = ev
+	case *github.RepositoryEvent:
+		switch ev.GetAction() {
+		case "created":
+			typ = RepoCreated
+		case "deleted":
+			typ = RepoDeleted
+		case "archived":
+			typ = RepoArchived
+		case "unarchived":
+			typ = RepoUnarchived
+		case "renamed":
+			typ = RepoRenamed
+		case "transferred":
+			typ = RepoTransferred
+		default:
+			return nil, nil
+		}
+		owner, repo = ev.GetRepo().GetOwner().GetLogin(), ev.GetRepo().GetName()
+		data = ev.GetRepo()
+		switch typ {
+		case RepoRenamed:
+			data = RepoMove{FromOwner: owner, FromRepo: ev.GetChanges().GetRepo().GetName().GetFrom(), Repository: ev.GetRepo()}
+		case RepoTransferred:
+			var changes transferChanges
+			if err := json.Unmarshal(payload, &changes); err != nil {
+				return nil, err
+			}
+			from := changes.Changes.Owner.From
+			fromOwner := from.User.GetLogin()
+			if from.Organization != nil {
+				fromOwner = from.Organization.GetLogin()
+			}
+			data = RepoMove{FromOwner: fromOwner, FromRepo: repo, Repository: ev.GetRepo()}
+		}
+	default:
+		return nil, nil
+	}
+
+	e, err := New(typ, owner, repo, data)
+	if err != nil {
+		return nil, err
+	}
+	return []Event{e}, nil
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+// CustomPropertyValue is the value of an organization custom property for a repository.
+// Value is a string, a list of strings for a multi-select property, or nil when unset.
+type CustomPropertyValue struct {
+	PropertyName string      `json:"property_name"`
+	Value        interface{} `json:"value"`
+}
+
+// RepoCustomPropertyValue holds the custom property values of a repository of an organization.
+type RepoCustomPropertyValue struct {
+	RepositoryID       int64                  `json:"repository_id"`
+	RepositoryName     string                 `json:"repository_name"`
+	RepositoryFullName string                 `json:"repository_full_name"`
+	Properties         []*CustomPropertyValue `json:"properties"`
+}
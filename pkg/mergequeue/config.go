@@ -0,0 +1,145 @@
+package mergequeue
+
+import (
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/models"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Defaults of the merge queue configuration.
+const (
+	DefaultLabel       = "merge-queue"
+	DefaultMergeMethod = "merge"
+	DefaultApprovals   = 1
+	DefaultInterval    = time.Minute
+)
+
+// Config configures the merge queues run by the service.
+type Config struct {
+	Token             string
+	Repos             []string
+	Label             string
+	MergeMethod       string
+	RequiredApprovals int
+	RequiredChecks    []string
+	Interval          time.Duration
+}
+
+// ConfigFromEnv reads the merge queue configuration from environment variables:
+//   - MERGE_QUEUE_REPOS: Comma-separated list of repositories ("owner/name") to run a queue for.
+//     The merge queue is disabled when unset.
+//   - MERGE_QUEUE_TOKEN: Access token used to read and merge pull requests.
+//   - MERGE_QUEUE_LABEL: Label queuing a pull request. Defaults to merge-queue.
+//   - MERGE_QUEUE_METHOD: Merge method: merge, squash or rebase. Defaults to merge.
+//   - MERGE_QUEUE_APPROVALS: Number of approving reviews required. Defaults to 1.
+//   - MERGE_QUEUE_CHECKS: Comma-separated list of required checks, overriding branch protection.
+//   - MERGE_QUEUE_INTERVAL: Polling interval, e.g. 30s. Defaults to 1m; 0 disables polling.
+//
+// Returns:
+//   - Config: The configuration.
+//   - error: An error if a variable is invalid or the token is missing.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Token:             os.Getenv("MERGE_QUEUE_TOKEN"),
+		Repos:             splitList(os.Getenv("MERGE_QUEUE_REPOS")),
+		Label:             DefaultLabel,
+		MergeMethod:       DefaultMergeMethod,
+		RequiredApprovals: DefaultApprovals,
+		RequiredChecks:    splitList(os.Getenv("MERGE_QUEUE_CHECKS")),
+		Interval:          DefaultInterval,
+	}
+	if label := os.Getenv("MERGE_QUEUE_LABEL"); label != "" {
+		cfg.Label = label
+	}
+	if method := os.Getenv("MERGE_QUEUE_METHOD"); method != "" {
+		cfg.MergeMethod = method
+	}
+	if value := os.Getenv("MERGE_QUEUE_APPROVALS"); value != "" {
+		approvals, err := strconv.Atoi(value)
+		if err != nil || approvals < 0 {
+			return Config{}, fmt.Errorf("invalid MERGE_QUEUE_APPROVALS %q", value)
+		}
+		cfg.RequiredApprovals = approvals
+	}
+	if value := os.Getenv("MERGE_QUEUE_INTERVAL"); value != "" {
+		interval, err := time.ParseDuration(value)
+		if err != nil {
+			return Config{}, fmt.Errorf("invalid MERGE_QUEUE_INTERVAL: %w", err)
+		}
+		cfg.Interval = interval
+	}
+	if err := cfg.validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// Enabled reports whether any queue is configured.
+func (c Config) Enabled() bool {
+	return len(c.Repos) > 0
+}
+
+// validate checks the configuration of enabled queues.
+func (c Config) validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.Token == "" {
+		return fmt.Errorf("MERGE_QUEUE_TOKEN is required by MERGE_QUEUE_REPOS")
+	}
+	switch c.MergeMethod {
+	case "merge", "squash", "rebase":
+	default:
+		return fmt.Errorf("invalid MERGE_QUEUE_METHOD %q: must be one of merge, squash, rebase", c.MergeMethod)
+	}
+	for _, repo := range c.Repos {
+		if _, _, err := models.SplitFullName(repo); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewRunner creates a runner with a queue for every configured repository.
+//
+// Parameters:
+//   - client: The GitHub client the queues act with.
+//   - cfg: The merge queue configuration.
+//
+// Returns:
+//   - *Runner: The runner, not yet started.
+//   - error: An error if a repository name is invalid.
+func NewRunner(client interfaces.GitHubClient, cfg Config) (*Runner, error) {
+	r := &Runner{Interval: cfg.Interval}
+	for _, fullName := range cfg.Repos {
+		owner, repo, err := models.SplitFullName(fullName)
+		if err != nil {
+			return nil, err
+		}
+		r.Queues = append(r.Queues, &Queue{
+			Client:            client,
+			Owner:             owner,
+			Repo:              repo,
+			Label:             cfg.Label,
+			MergeMethod:       cfg.MergeMethod,
+			RequiredApprovals: cfg.RequiredApprovals,
+			RequiredChecks:    cfg.RequiredChecks,
+		})
+	}
+	return r, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
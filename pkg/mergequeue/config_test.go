@@ -0,0 +1,35 @@
+package mergequeue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConfigFromEnv tests reading the merge queue configuration from the environment.
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("MERGE_QUEUE_REPOS", "octocat/hello, octocat/world")
+	t.Setenv("MERGE_QUEUE_TOKEN", "token")
+	t.Setenv("MERGE_QUEUE_METHOD", "squash")
+	t.Setenv("MERGE_QUEUE_INTERVAL", "30s")
+
+	cfg, err := ConfigFromEnv()
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, []string{"octocat/hello", "octocat/world"}, cfg.Repos)
+	assert.Equal(t, DefaultLabel, cfg.Label)
+	assert.Equal(t, "squash", cfg.MergeMethod)
+	assert.Equal(t, DefaultApprovals, cfg.RequiredApprovals)
+	assert.Equal(t, 30*time.Second, cfg.Interval)
+
+	t.Setenv("MERGE_QUEUE_METHOD", "fast-forward")
+	_, err = ConfigFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv("MERGE_QUEUE_METHOD", "")
+	t.Setenv("MERGE_QUEUE_TOKEN", "")
+	_, err = ConfigFromEnv()
+	assert.Error(t, err)
+}
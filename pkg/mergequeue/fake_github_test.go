@@ -0,0 +1,223 @@
+package mergequeue
+
+import (
+	"encoding/json"
+	"fmt"
+	"github-api/pkg/models"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+)
+
+// fakePull is the state of a pull request in fakeGitHub.
+type fakePull struct {
+	number    int
+	sha       string
+	state     string
+	behind    bool
+	conflicts bool
+	labels    []string
+	labeledAt time.Time
+	reviews   []*github.PullRequestReview
+	checks    map[string]string
+	comments  []string
+}
+
+// fakeGitHub is an in-memory GitHub serving the REST endpoints used by the merge queue.
+// Merging a pull request puts every other open pull request behind its base branch, and
+// updating a branch gives it a new head commit whose checks are pending.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	pulls    map[int]*fakePull
+	required []string
+	merged   []int
+	updated  []int
+	commits  int
+}
+
+// newFakeGitHub starts a fake GitHub server and returns it with a client for the octocat/hello repository.
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *models.GitHubClientWrapper) {
+	fake := &fakeGitHub{pulls: map[int]*fakePull{}}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	return fake, &models.GitHubClientWrapper{Client: client}
+}
+
+// add adds an open, queued pull request whose checks pass.
+func (f *fakeGitHub) add(number int, labeledAt time.Time, approvedBy ...string) *fakePull {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	pull := &fakePull{
+		number:    number,
+		sha:       f.nextSHA(),
+		state:     "open",
+		labels:    []string{DefaultLabel},
+		labeledAt: labeledAt,
+		checks:    map[string]string{"build": "success"},
+	}
+	for _, login := range approvedBy {
+		pull.reviews = append(pull.reviews, &github.PullRequestReview{User: &github.User{Login: github.String(login)}, State: github.String("APPROVED")})
+	}
+	f.pulls[number] = pull
+	return pull
+}
+
+// set runs fn with the lock held, to change the state of the fake between queue passes.
+func (f *fakeGitHub) set(fn func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fn()
+}
+
+// nextSHA returns a new commit SHA. The caller must hold f.mu.
+func (f *fakeGitHub) nextSHA() string {
+	f.commits++
+	return fmt.Sprintf("%040x", f.commits)
+}
+
+// ServeHTTP routes the requests of the GitHub client.
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] != "repos" || parts[1] != "octocat" || parts[2] != "hello" {
+		http.NotFound(w, r)
+		return
+	}
+	route := r.Method + " " + strings.Join(parts[3:], "/")
+	number, _ := strconv.Atoi(parts[len(parts)-1])
+	if len(parts) > 4 {
+		if n, err := strconv.Atoi(parts[4]); err == nil {
+			number = n
+		}
+	}
+	pull := f.pulls[number]
+
+	switch {
+	case route == "GET issues":
+		var issues []*github.Issue
+		for _, p := range f.pulls {
+			if p.state == "open" && p.hasLabel(r.URL.Query().Get("labels")) {
+				issues = append(issues, &github.Issue{
+					Number:           github.Int(p.number),
+					PullRequestLinks: &github.PullRequestLinks{URL: github.String("pulls/" + strconv.Itoa(p.number))},
+				})
+			}
+		}
+		writeJSON(w, http.StatusOK, issues)
+	case pull == nil && !strings.HasPrefix(route, "GET commits/") && !strings.HasPrefix(route, "GET branches/"):
+		http.NotFound(w, r)
+	case strings.HasSuffix(route, "/events"):
+		writeJSON(w, http.StatusOK, []*github.IssueEvent{{
+			Event:     github.String("labeled"),
+			Label:     &github.Label{Name: github.String(DefaultLabel)},
+			CreatedAt: &github.Timestamp{Time: pull.labeledAt},
+		}})
+	case route == "GET pulls/"+strconv.Itoa(number):
+		state := "clean"
+		switch {
+		case pull.conflicts:
+			state = "dirty"
+		case pull.behind:
+			state = "behind"
+		}
+		writeJSON(w, http.StatusOK, &github.PullRequest{
+			Number:         github.Int(pull.number),
+			State:          github.String(pull.state),
+			Merged:         github.Bool(pull.state == "merged"),
+			Mergeable:      github.Bool(!pull.conflicts),
+			MergeableState: github.String(state),
+			User:           &github.User{Login: github.String("author")},
+			Head:           &github.PullRequestBranch{SHA: github.String(pull.sha)},
+			Base:           &github.PullRequestBranch{Ref: github.String("main")},
+		})
+	case strings.HasSuffix(route, "/reviews"):
+		writeJSON(w, http.StatusOK, pull.reviews)
+	case route == "GET branches/main/protection/required_status_checks":
+		if f.required == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"message": "Branch not protected"})
+			return
+		}
+		writeJSON(w, http.StatusOK, &github.RequiredStatusChecks{Contexts: f.required})
+	case strings.HasPrefix(route, "GET commits/") && strings.HasSuffix(route, "/status"):
+		writeJSON(w, http.StatusOK, &github.CombinedStatus{})
+	case strings.HasPrefix(route, "GET commits/") && strings.HasSuffix(route, "/check-runs"):
+		var runs []*github.CheckRun
+		for _, p := range f.pulls {
+			if p.sha != parts[4] {
+				continue
+			}
+			for name, conclusion := range p.checks {
+				run := &github.CheckRun{Name: github.String(name), Status: github.String("completed"), Conclusion: github.String(conclusion)}
+				if conclusion == "" {
+					run = &github.CheckRun{Name: github.String(name), Status: github.String("in_progress")}
+				}
+				runs = append(runs, run)
+			}
+		}
+		writeJSON(w, http.StatusOK, &github.ListCheckRunsResults{Total: github.Int(len(runs)), CheckRuns: runs})
+	case strings.HasSuffix(route, "/update-branch"):
+		pull.sha = f.nextSHA()
+		pull.behind = false
+		for name := range pull.checks {
+			pull.checks[name] = ""
+		}
+		f.updated = append(f.updated, number)
+		writeJSON(w, http.StatusAccepted, map[string]string{"message": "Updating pull request branch."})
+	case strings.HasSuffix(route, "/merge"):
+		var body struct {
+			SHA string `json:"sha"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.SHA != pull.sha {
+			writeJSON(w, http.StatusConflict, map[string]string{"message": "Head branch was modified."})
+			return
+		}
+		pull.state = "merged"
+		for _, p := range f.pulls {
+			if p.state == "open" {
+				p.behind = true
+			}
+		}
+		f.merged = append(f.merged, number)
+		writeJSON(w, http.StatusOK, &github.PullRequestMergeResult{Merged: github.Bool(true), SHA: github.String(f.nextSHA())})
+	case r.Method == http.MethodDelete && strings.Contains(route, "/labels/"):
+		pull.labels = nil
+		writeJSON(w, http.StatusOK, []*github.Label{})
+	case strings.HasSuffix(route, "/comments"):
+		var comment github.IssueComment
+		_ = json.NewDecoder(r.Body).Decode(&comment)
+		pull.comments = append(pull.comments, comment.GetBody())
+		writeJSON(w, http.StatusCreated, &comment)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// hasLabel reports whether the pull request carries the label.
+func (p *fakePull) hasLabel(label string) bool {
+	for _, l := range p.labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+// writeJSON writes a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,278 @@
+// Package mergequeue merges labeled pull requests one at a time, in the order they were
+// queued, once they are approved and their required checks pass.
+package mergequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/events"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/models"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Outcomes of processing the pull request at the head of a queue.
+const (
+	// Merged means the pull request was merged.
+	Merged = "merged"
+	// Updated means the base branch was merged into the pull request, whose checks must run again.
+	Updated = "updated"
+	// Waiting means the pull request is not ready yet, e.g. its checks are still running.
+	Waiting = "waiting"
+	// Ejected means the pull request cannot be merged and was removed from the queue.
+	Ejected = "ejected"
+)
+
+// Step reports what was done with the pull request at the head of a queue.
+type Step struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Outcome    string `json:"outcome"`
+	Reason     string `json:"reason,omitempty"`
+	SHA        string `json:"sha,omitempty"`
+}
+
+// Queue is the merge queue of a repository. Open pull requests carrying Label are queued in the
+// order the label was added, and only the pull request at the head of the queue is worked on.
+// RequiredChecks names the checks that must pass before merging; when empty, the checks required
+// by the protection of the base branch are used, and failing those every reported check must pass.
+type Queue struct {
+	Client            interfaces.GitHubClient
+	Owner             string
+	Repo              string
+	Label             string
+	MergeMethod       string
+	RequiredApprovals int
+	RequiredChecks    []string
+}
+
+// entry is a queued pull request.
+type entry struct {
+	number   int
+	queuedAt time.Time
+}
+
+// Entries lists the numbers of the queued pull requests, head of the queue first.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//
+// Returns:
+//   - []int: The numbers of the queued pull requests.
+//   - error: An error if the queue cannot be read.
+func (q *Queue) Entries(ctx context.Context) ([]int, error) {
+	var entries []entry
+	opt := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      []string{q.Label},
+		Sort:        "created",
+		Direction:   "asc",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := q.Client.ListIssuesByRepo(ctx, q.Owner, q.Repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range issues {
+			if !issue.IsPullRequest() {
+				continue
+			}
+			e := entry{number: issue.GetNumber(), queuedAt: issue.GetCreatedAt().Time}
+			labeledAt, err := models.LabeledAt(ctx, q.Client, q.Owner, q.Repo, e.number, q.Label)
+			if err != nil {
+				return nil, err
+			}
+			if labeledAt != nil {
+				e.queuedAt = *labeledAt
+			}
+			entries = append(entries, e)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].queuedAt.Equal(entries[j].queuedAt) {
+			return entries[i].queuedAt.Before(entries[j].queuedAt)
+		}
+		return entries[i].number < entries[j].number
+	})
+	numbers := make([]int, len(entries))
+	for i, e := range entries {
+		numbers[i] = e.number
+	}
+	return numbers, nil
+}
+
+// Process works through the queue until a pull request has to wait. The head of the queue is
+// merged when ready, updated when behind its base branch, or ejected when it cannot be merged.
+// After a merge or an ejection the queue is read again, so the next pull request is checked
+// against the new state of the base branch.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//
+// Returns:
+//   - []Step: What was done, in order.
+//   - error: An error if the queue cannot be read or a pull request cannot be handled.
+func (q *Queue) Process(ctx context.Context) ([]Step, error) {
+	var steps []Step
+	done := map[int]bool{}
+	for {
+		numbers, err := q.Entries(ctx)
+		if err != nil {
+			return steps, err
+		}
+		head := 0
+		for _, number := range numbers {
+			// Listings may briefly lag behind a merge or a label removal.
+			if !done[number] {
+				head = number
+				break
+			}
+		}
+		if head == 0 {
+			return steps, nil
+		}
+
+		step, err := q.advance(ctx, head)
+		if err != nil {
+			return steps, err
+		}
+		steps = append(steps, step)
+		if step.Outcome != Merged && step.Outcome != Ejected {
+			return steps, nil
+		}
+		done[head] = true
+	}
+}
+
+// advance moves the pull request at the head of the queue one step forward.
+func (q *Queue) advance(ctx context.Context, number int) (Step, error) {
+	pull, _, err := q.Client.GetPullRequest(ctx, q.Owner, q.Repo, number)
+	if err != nil {
+		return Step{}, err
+	}
+	sha := pull.GetHead().GetSHA()
+	step := func(outcome, reason string) (Step, error) {
+		return Step{Repository: q.Owner + "/" + q.Repo, Number: number, Outcome: outcome, Reason: reason, SHA: sha}, nil
+	}
+
+	if pull.GetDraft() {
+		return q.eject(ctx, pull, "the pull request is a draft")
+	}
+	decision, err := models.GetReviewDecision(ctx, q.Client, q.Owner, q.Repo, pull)
+	if err != nil {
+		return Step{}, err
+	}
+	if len(decision.ChangesRequested) > 0 {
+		return q.eject(ctx, pull, "changes were requested by "+strings.Join(decision.ChangesRequested, ", "))
+	}
+	if len(decision.Approvals) < q.RequiredApprovals {
+		return q.eject(ctx, pull, fmt.Sprintf("%d approving review(s) required, %d given", q.RequiredApprovals, len(decision.Approvals)))
+	}
+
+	switch {
+	case pull.Mergeable == nil:
+		return step(Waiting, "GitHub is computing mergeability")
+	case pull.GetMergeableState() == "dirty":
+		return q.eject(ctx, pull, "the pull request has merge conflicts")
+	case pull.GetMergeableState() == "behind":
+		_, _, err := q.Client.UpdatePullRequestBranch(ctx, q.Owner, q.Repo, number, &github.PullRequestBranchUpdateOptions{ExpectedHeadSHA: github.String(sha)})
+		if err != nil {
+			return Step{}, err
+		}
+		return step(Updated, "the branch was behind "+pull.GetBase().GetRef())
+	}
+
+	required, err := q.requiredChecks(ctx, pull.GetBase().GetRef())
+	if err != nil {
+		return Step{}, err
+	}
+	states, err := models.CommitCheckStates(ctx, q.Client, q.Owner, q.Repo, sha)
+	if err != nil {
+		return Step{}, err
+	}
+	if required == nil {
+		for name := range states {
+			required = append(required, name)
+		}
+		sort.Strings(required)
+	}
+	for _, name := range required {
+		if states[name] == models.CheckFailure {
+			return q.eject(ctx, pull, "the required check "+name+" failed")
+		}
+	}
+	for _, name := range required {
+		if states[name] != models.CheckSuccess {
+			return step(Waiting, "waiting for the required check "+name)
+		}
+	}
+
+	result, _, err := q.Client.MergePullRequest(ctx, q.Owner, q.Repo, number, "", &github.PullRequestOptions{SHA: sha, MergeMethod: q.MergeMethod})
+	var ghErr *github.ErrorResponse
+	switch {
+	case errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusConflict:
+		return step(Waiting, "the head of the pull request changed")
+	case errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusMethodNotAllowed:
+		return q.eject(ctx, pull, "GitHub refused the merge: "+ghErr.Message)
+	case err != nil:
+		return Step{}, err
+	}
+	pull.Merged = github.Bool(true)
+	pull.MergeCommitSHA = result.SHA
+	events.Publish(events.PullRequestMerged, q.Owner, q.Repo, pull)
+	return step(Merged, "")
+}
+
+// eject removes a pull request from the queue and comments with the reason.
+func (q *Queue) eject(ctx context.Context, pull *github.PullRequest, reason string) (Step, error) {
+	number := pull.GetNumber()
+	if _, err := q.Client.RemoveLabelForIssue(ctx, q.Owner, q.Repo, number, q.Label); err != nil {
+		return Step{}, err
+	}
+	body := fmt.Sprintf("Removed from the merge queue: %s. Add the %s label again once this is resolved.", reason, q.Label)
+	if _, _, err := q.Client.CreateIssueComment(ctx, q.Owner, q.Repo, number, &github.IssueComment{Body: github.String(body)}); err != nil {
+		return Step{}, err
+	}
+	return Step{Repository: q.Owner + "/" + q.Repo, Number: number, Outcome: Ejected, Reason: reason, SHA: pull.GetHead().GetSHA()}, nil
+}
+
+// requiredChecks returns the checks configured for the queue or required by the protection of
+// a branch, or nil if there are none, in which case every check must pass.
+func (q *Queue) requiredChecks(ctx context.Context, branch string) ([]string, error) {
+	if len(q.RequiredChecks) > 0 {
+		return q.RequiredChecks, nil
+	}
+	checks, _, err := q.Client.GetRequiredStatusChecks(ctx, q.Owner, q.Repo, branch)
+	var ghErr *github.ErrorResponse
+	if errors.Is(err, github.ErrBranchNotProtected) || (errors.As(err, &ghErr) && ghErr.Response.StatusCode == http.StatusNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Contexts is the legacy form of Checks and usually repeats the same names.
+	names := append([]string{}, checks.Contexts...)
+	for _, check := range checks.Checks {
+		names = append(names, check.Context)
+	}
+	var required []string
+	seen := map[string]bool{}
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			required = append(required, name)
+		}
+	}
+	return required, nil
+}
@@ -0,0 +1,133 @@
+package mergequeue
+
+import (
+	"context"
+	"github-api/pkg/events"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// outcomes returns the pull request numbers and outcomes of steps.
+func outcomes(steps []Step) [][2]interface{} {
+	var out [][2]interface{}
+	for _, s := range steps {
+		out = append(out, [2]interface{}{s.Number, s.Outcome})
+	}
+	return out
+}
+
+// TestQueueProcess tests that pull requests are merged in queue order, updated when behind,
+// and ejected when they cannot be merged.
+func TestQueueProcess(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	fake.add(2, start, "bob")
+	fake.add(1, start.Add(time.Hour), "bob")
+	rejected := fake.add(3, start.Add(2*time.Hour), "bob")
+	rejected.reviews = append(rejected.reviews, &github.PullRequestReview{
+		User:  &github.User{Login: github.String("bob")},
+		State: github.String("CHANGES_REQUESTED"),
+	})
+	q := &Queue{Client: client, Owner: "octocat", Repo: "hello", Label: DefaultLabel, MergeMethod: "squash", RequiredApprovals: 1}
+
+	steps, err := q.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][2]interface{}{{2, Merged}, {1, Updated}}, outcomes(steps))
+
+	steps, err = q.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][2]interface{}{{1, Waiting}}, outcomes(steps))
+
+	fake.set(func() { fake.pulls[1].checks["build"] = "success" })
+	steps, err = q.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][2]interface{}{{1, Merged}, {3, Ejected}}, outcomes(steps))
+	assert.Equal(t, []int{2, 1}, fake.merged)
+	assert.Equal(t, []int{1}, fake.updated)
+	assert.Empty(t, rejected.labels)
+	require.Len(t, rejected.comments, 1)
+	assert.Contains(t, rejected.comments[0], "changes were requested by bob")
+
+	steps, err = q.Process(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, steps)
+}
+
+// TestQueueRequiredChecks tests that only the checks required by branch protection gate a merge.
+func TestQueueRequiredChecks(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	pull := fake.add(1, start, "bob")
+	pull.checks["lint"] = "failure"
+	fake.required = []string{"build"}
+	failing := fake.add(2, start.Add(time.Hour), "bob")
+	failing.checks["build"] = "failure"
+	unapproved := fake.add(3, start.Add(2*time.Hour))
+	q := &Queue{Client: client, Owner: "octocat", Repo: "hello", Label: DefaultLabel, MergeMethod: "merge", RequiredApprovals: 1}
+
+	steps, err := q.Process(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, steps, 2)
+	assert.Equal(t, Merged, steps[0].Outcome)
+	assert.Equal(t, Updated, steps[1].Outcome)
+
+	fake.set(func() { failing.checks["build"] = "failure" })
+	steps, err = q.Process(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, [][2]interface{}{{2, Ejected}, {3, Ejected}}, outcomes(steps))
+	assert.Contains(t, steps[0].Reason, "build failed")
+	assert.Contains(t, steps[1].Reason, "1 approving review(s) required")
+	assert.Len(t, unapproved.comments, 1)
+}
+
+// TestRunnerProcessesOnEvents tests that the runner moves a queue forward when a webhook event arrives.
+func TestRunnerProcessesOnEvents(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	bus := events.NewBus(events.NewMemoryStore())
+	bus.Start(context.Background())
+	defer bus.Close()
+	runner, err := NewRunner(client, Config{Repos: []string{"octocat/hello"}, Label: DefaultLabel, MergeMethod: "merge", RequiredApprovals: 1})
+	require.NoError(t, err)
+	runner.Start(context.Background(), bus)
+	defer runner.Close()
+
+	fake.add(1, time.Now(), "bob")
+	e, err := events.New(events.PullRequestLabeled, "octocat", "hello", nil)
+	require.NoError(t, err)
+	_, err = bus.Publish(e)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		return len(fake.merged) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+}
+
+// TestRunnerFollowsMovedRepository tests that the queue of a renamed repository follows it.
+func TestRunnerFollowsMovedRepository(t *testing.T) {
+	_, client := newFakeGitHub(t)
+	runner, err := NewRunner(client, Config{Repos: []string{"octocat/hello"}, Label: DefaultLabel, MergeMethod: "merge", RequiredApprovals: 1})
+	require.NoError(t, err)
+
+	e, err := events.New(events.RepoRenamed, "octocat", "world", events.RepoMove{FromOwner: "octocat", FromRepo: "hello"})
+	require.NoError(t, err)
+	runner.follow(e)
+	assert.Equal(t, "world", runner.Queues[0].Repo)
+	assert.NotNil(t, runner.queue(events.Event{Type: events.PullRequestLabeled, Owner: "octocat", Repo: "world"}))
+}
+
+// TestRunnerQueueIgnoresCase tests that events reach the queue of a repository whatever the case of its name.
+func TestRunnerQueueIgnoresCase(t *testing.T) {
+	_, client := newFakeGitHub(t)
+	runner, err := NewRunner(client, Config{Repos: []string{"Octo-Org/Hello"}, Label: DefaultLabel, MergeMethod: "merge", RequiredApprovals: 1})
+	require.NoError(t, err)
+
+	assert.NotNil(t, runner.queue(events.Event{Type: events.PullRequestLabeled, Owner: "octo-org", Repo: "hello"}))
+	assert.Nil(t, runner.queue(events.Event{Type: events.PullRequestLabeled, Owner: "octo-org", Repo: "world"}))
+}
@@ -0,0 +1,143 @@
+package mergequeue
+
+import (
+	"context"
+	"encoding/json"
+	"github-api/pkg/events"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// triggers are the event types after which a queue may be able to move forward.
+var triggers = map[events.Type]bool{
+	events.PullRequestLabeled:      true,
+	events.PullRequestSynchronized: true,
+	events.PullRequestReviewed:     true,
+	events.PullRequestMerged:       true,
+	events.PullRequestClosed:       true,
+	events.ChecksCompleted:         true,
+}
+
+// Runner drives a set of queues. Each queue is processed when an event of its repository
+// arrives on the bus, typically from an inbound GitHub webhook, and every Interval as a fallback.
+// Queues are processed one at a time.
+type Runner struct {
+	Queues   []*Queue
+	Interval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// Start processes every queue once, then keeps processing them on events and on a timer
+// until Close is called or ctx is done.
+//
+// Parameters:
+//   - ctx: The context bounding the lifetime of the runner.
+//   - bus: The bus delivering repository events, or nil to rely on the timer only.
+func (r *Runner) Start(ctx context.Context, bus *events.Bus) {
+	ctx, r.cancel = context.WithCancel(ctx)
+	var received <-chan events.Event
+	if bus != nil {
+		ch, unsubscribe := bus.Subscribe(100)
+		received = ch
+		go func() {
+			<-ctx.Done()
+			unsubscribe()
+		}()
+	}
+	var tick <-chan time.Time
+	if r.Interval > 0 {
+		ticker := time.NewTicker(r.Interval)
+		tick = ticker.C
+		go func() {
+			<-ctx.Done()
+			ticker.Stop()
+		}()
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.processAll(ctx)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-tick:
+				r.processAll(ctx)
+			case e, ok := <-received:
+				if !ok {
+					received = nil
+					continue
+				}
+				r.follow(e)
+				if q := r.queue(e); q != nil {
+					r.process(ctx, q)
+				}
+			}
+		}
+	}()
+}
+
+// Close stops the runner and waits for the queue being processed, if any.
+func (r *Runner) Close() {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+}
+
+// queue returns the queue an event may move forward, or nil.
+func (r *Runner) queue(e events.Event) *Queue {
+	if !triggers[e.Type] {
+		return nil
+	}
+	for _, q := range r.Queues {
+		if strings.EqualFold(q.Owner, e.Owner) && strings.EqualFold(q.Repo, e.Repo) {
+			return q
+		}
+	}
+	return nil
+}
+
+// follow points the queue of a renamed or transferred repository to its new location.
+func (r *Runner) follow(e events.Event) {
+	if e.Type != events.RepoRenamed && e.Type != events.RepoTransferred {
+		return
+	}
+	var move events.RepoMove
+	if err := json.Unmarshal(e.Data, &move); err != nil {
+		log.Printf("mergequeue: decoding %s event: %v", e.Type, err)
+		return
+	}
+	for _, q := range r.Queues {
+		if strings.EqualFold(q.Owner, move.FromOwner) && strings.EqualFold(q.Repo, move.FromRepo) {
+			log.Printf("mergequeue: %s/%s moved to %s/%s", q.Owner, q.Repo, e.Owner, e.Repo)
+			q.Owner, q.Repo = e.Owner, e.Repo
+		}
+	}
+}
+
+// processAll processes every queue in turn.
+func (r *Runner) processAll(ctx context.Context) {
+	for _, q := range r.Queues {
+		if ctx.Err() != nil {
+			return
+		}
+		r.process(ctx, q)
+	}
+}
+
+// process processes a queue and logs what was done.
+func (r *Runner) process(ctx context.Context, q *Queue) {
+	steps, err := q.Process(ctx)
+	for _, s := range steps {
+		log.Printf("mergequeue: %s#%d %s %s", s.Repository, s.Number, s.Outcome, s.Reason)
+	}
+	if err != nil && ctx.Err() == nil {
+		log.Printf("mergequeue: processing %s/%s: %v", q.Owner, q.Repo, err)
+	}
+}
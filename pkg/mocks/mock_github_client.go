@@ -2,8 +2,12 @@ package mocks
 
 import (
 	"context"
+	"github-api/pkg/interfaces"
 	"github.com/google/go-github/v50/github"
 	"github.com/stretchr/testify/mock"
+	"io"
+	"net/url"
+	"os"
 )
 
 // MockGitHubClient is a mock implementation of the GitHubClient interface.
@@ -113,3 +117,2082 @@ func (m *MockGitHubClient) ListRepos(ctx context.Context, owner string, opt *git
 	args := m.Called(ctx, owner, opt)
 	return args.Get(0).([]*github.Repository), args.Get(1).(*github.Response), args.Error(2)
 }
+
+// ListIssuesByRepo mocks the ListIssuesByRepo method of the GitHub client.
+// It lists issues for a specified repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opt: Options for filtering and paginating the issues.
+//
+// Returns:
+//   - []*github.Issue: A slice of pointers to the listed issues.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListIssuesByRepo(ctx context.Context, owner string, repo string, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opt)
+	return args.Get(0).([]*github.Issue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetIssue mocks the GetIssue method of the GitHub client.
+// It retrieves a single issue by its number.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue.
+//
+// Returns:
+//   - *github.Issue: A pointer to the retrieved issue.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetIssue(ctx context.Context, owner string, repo string, number int) (*github.Issue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number)
+	return args.Get(0).(*github.Issue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateIssue mocks the CreateIssue method of the GitHub client.
+// It creates a new issue in the specified repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - issue: The fields of the issue to be created.
+//
+// Returns:
+//   - *github.Issue: A pointer to the created issue.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateIssue(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, issue)
+	return args.Get(0).(*github.Issue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditIssue mocks the EditIssue method of the GitHub client.
+// It edits an existing issue, including its state.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue.
+//   - issue: The fields of the issue to be updated.
+//
+// Returns:
+//   - *github.Issue: A pointer to the updated issue.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditIssue(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, issue)
+	return args.Get(0).(*github.Issue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListIssueComments mocks the ListIssueComments method of the GitHub client.
+// It lists the comments on an issue.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue.
+//   - opt: Options for filtering and paginating the comments.
+//
+// Returns:
+//   - []*github.IssueComment: A slice of pointers to the listed comments.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListIssueComments(ctx context.Context, owner string, repo string, number int, opt *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opt)
+	return args.Get(0).([]*github.IssueComment), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetIssueComment mocks the GetIssueComment method of the GitHub client.
+// It retrieves a single issue comment by its ID.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - commentID: The ID of the comment.
+//
+// Returns:
+//   - *github.IssueComment: A pointer to the retrieved comment.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetIssueComment(ctx context.Context, owner string, repo string, commentID int64) (*github.IssueComment, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, commentID)
+	return args.Get(0).(*github.IssueComment), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateIssueComment mocks the CreateIssueComment method of the GitHub client.
+// It adds a comment to an issue.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue.
+//   - comment: The comment to be created.
+//
+// Returns:
+//   - *github.IssueComment: A pointer to the created comment.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateIssueComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, comment)
+	return args.Get(0).(*github.IssueComment), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditIssueComment mocks the EditIssueComment method of the GitHub client.
+// It updates the body of an issue comment.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - commentID: The ID of the comment.
+//   - comment: The updated comment.
+//
+// Returns:
+//   - *github.IssueComment: A pointer to the updated comment.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditIssueComment(ctx context.Context, owner string, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, commentID, comment)
+	return args.Get(0).(*github.IssueComment), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteIssueComment mocks the DeleteIssueComment method of the GitHub client.
+// It deletes an issue comment.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - commentID: The ID of the comment.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteIssueComment(ctx context.Context, owner string, repo string, commentID int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, commentID)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListLabels mocks the ListLabels method of the GitHub client.
+// It lists the labels defined in a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opt: Options for paginating the labels.
+//
+// Returns:
+//   - []*github.Label: A slice of pointers to the listed labels.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListLabels(ctx context.Context, owner string, repo string, opt *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opt)
+	return args.Get(0).([]*github.Label), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateLabel mocks the CreateLabel method of the GitHub client.
+// It creates a label in a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - label: The name, color and description of the label.
+//
+// Returns:
+//   - *github.Label: A pointer to the created label.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateLabel(ctx context.Context, owner string, repo string, label *github.Label) (*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, label)
+	return args.Get(0).(*github.Label), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditLabel mocks the EditLabel method of the GitHub client.
+// It updates a label, renaming it when the name of label differs from name.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The current name of the label.
+//   - label: The new name, color and description of the label.
+//
+// Returns:
+//   - *github.Label: A pointer to the updated label.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditLabel(ctx context.Context, owner string, repo string, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, name, label)
+	return args.Get(0).(*github.Label), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteLabel mocks the DeleteLabel method of the GitHub client.
+// It deletes a label from a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The name of the label.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteLabel(ctx context.Context, owner string, repo string, name string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, name)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListMilestones mocks the ListMilestones method of the GitHub client.
+// It lists the milestones of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opt: Options for filtering, sorting and paginating the milestones.
+//
+// Returns:
+//   - []*github.Milestone: A slice of pointers to the listed milestones.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListMilestones(ctx context.Context, owner string, repo string, opt *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opt)
+	return args.Get(0).([]*github.Milestone), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetMilestone mocks the GetMilestone method of the GitHub client.
+// It retrieves a single milestone by its number.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the milestone.
+//
+// Returns:
+//   - *github.Milestone: A pointer to the retrieved milestone.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetMilestone(ctx context.Context, owner string, repo string, number int) (*github.Milestone, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number)
+	return args.Get(0).(*github.Milestone), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateMilestone mocks the CreateMilestone method of the GitHub client.
+// It creates a milestone in a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - milestone: The title, description, state and due date of the milestone.
+//
+// Returns:
+//   - *github.Milestone: A pointer to the created milestone.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateMilestone(ctx context.Context, owner string, repo string, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, milestone)
+	return args.Get(0).(*github.Milestone), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditMilestone mocks the EditMilestone method of the GitHub client.
+// It updates a milestone.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the milestone.
+//   - milestone: The fields of the milestone to be updated.
+//
+// Returns:
+//   - *github.Milestone: A pointer to the updated milestone.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditMilestone(ctx context.Context, owner string, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, milestone)
+	return args.Get(0).(*github.Milestone), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteMilestone mocks the DeleteMilestone method of the GitHub client.
+// It deletes a milestone from a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the milestone.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteMilestone(ctx context.Context, owner string, repo string, number int) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, number)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// GetPullRequest mocks the GetPullRequest method of the GitHub client.
+// It retrieves a single pull request by its number.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//
+// Returns:
+//   - *github.PullRequest: A pointer to the retrieved pull request.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetPullRequest(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number)
+	return args.Get(0).(*github.PullRequest), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreatePullRequest mocks the CreatePullRequest method of the GitHub client.
+// It opens a new pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - pull: The head, base, title, body and draft flag of the pull request.
+//
+// Returns:
+//   - *github.PullRequest: A pointer to the created pull request.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreatePullRequest(ctx context.Context, owner string, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, pull)
+	return args.Get(0).(*github.PullRequest), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditPullRequest mocks the EditPullRequest method of the GitHub client.
+// It updates the title, body, state, base or maintainer_can_modify flag of a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - pull: The fields of the pull request to be updated.
+//
+// Returns:
+//   - *github.PullRequest: A pointer to the updated pull request.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditPullRequest(ctx context.Context, owner string, repo string, number int, pull *github.PullRequest) (*github.PullRequest, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, pull)
+	return args.Get(0).(*github.PullRequest), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// RequestReviewers mocks the RequestReviewers method of the GitHub client.
+// It requests reviews on a pull request from users and teams.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - reviewers: The logins of the users and the slugs of the teams to request.
+//
+// Returns:
+//   - *github.PullRequest: A pointer to the updated pull request.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) RequestReviewers(ctx context.Context, owner string, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, reviewers)
+	return args.Get(0).(*github.PullRequest), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// MergePullRequest mocks the MergePullRequest method of the GitHub client.
+// It merges a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - commitMessage: The extra detail of the merge commit message, or empty for the default.
+//   - options: The merge method, commit title and the SHA the head must match.
+//
+// Returns:
+//   - *github.PullRequestMergeResult: A pointer to the result of the merge.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) MergePullRequest(ctx context.Context, owner string, repo string, number int, commitMessage string, options *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, commitMessage, options)
+	return args.Get(0).(*github.PullRequestMergeResult), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListPullRequestFiles mocks the ListPullRequestFiles method of the GitHub client.
+// It lists the files changed by a pull request, with their patch and line stats.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.CommitFile: A slice of the changed files.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListPullRequestFiles(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).([]*github.CommitFile), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListPullRequestCommits mocks the ListPullRequestCommits method of the GitHub client.
+// It lists the commits of a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.RepositoryCommit: A slice of the commits.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListPullRequestCommits(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).([]*github.RepositoryCommit), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListPullRequestReviews mocks the ListPullRequestReviews method of the GitHub client.
+// It lists the reviews of a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.PullRequestReview: A slice of the reviews.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListPullRequestReviews(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).([]*github.PullRequestReview), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListPullRequestComments mocks the ListPullRequestComments method of the GitHub client.
+// It lists the review comments of a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - opts: The sorting and pagination options.
+//
+// Returns:
+//   - []*github.PullRequestComment: A slice of the review comments.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListPullRequestComments(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).([]*github.PullRequestComment), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetCombinedStatus mocks the GetCombinedStatus method of the GitHub client.
+// It retrieves the combined commit status of a reference.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The SHA, branch or tag name of the commit.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.CombinedStatus: A pointer to the combined status and its individual statuses.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetCombinedStatus(ctx context.Context, owner string, repo string, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref, opts)
+	return args.Get(0).(*github.CombinedStatus), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListCheckRunsForRef mocks the ListCheckRunsForRef method of the GitHub client.
+// It lists the check runs of a reference.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The SHA, branch or tag name of the commit.
+//   - opts: The filters and pagination options.
+//
+// Returns:
+//   - *github.ListCheckRunsResults: A pointer to the total count and the check runs.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListCheckRunsForRef(ctx context.Context, owner string, repo string, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref, opts)
+	return args.Get(0).(*github.ListCheckRunsResults), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListTeamRepos mocks the ListTeamRepos method of the GitHub client.
+// It lists the repositories a team of an organization has access to.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - slug: The slug of the team.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.Repository: A slice of the repositories of the team.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListTeamRepos(ctx context.Context, org string, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, org, slug, opts)
+	return args.Get(0).([]*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// AddLabelsToIssue mocks the AddLabelsToIssue method of the GitHub client.
+// It adds labels to an issue or pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue or pull request.
+//   - labels: The names of the labels to add.
+//
+// Returns:
+//   - []*github.Label: A slice of the labels of the issue after the change.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) AddLabelsToIssue(ctx context.Context, owner string, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, labels)
+	return args.Get(0).([]*github.Label), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// RemoveLabelForIssue mocks the RemoveLabelForIssue method of the GitHub client.
+// It removes a label from an issue or pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue or pull request.
+//   - label: The name of the label to remove.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) RemoveLabelForIssue(ctx context.Context, owner string, repo string, number int, label string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, label)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListIssueEvents mocks the ListIssueEvents method of the GitHub client.
+// It lists the events of an issue or pull request, such as labels being added or removed.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue or pull request.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.IssueEvent: A slice of the events of the issue.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListIssueEvents(ctx context.Context, owner string, repo string, number int, opts *github.ListOptions) ([]*github.IssueEvent, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).([]*github.IssueEvent), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetRequiredStatusChecks mocks the GetRequiredStatusChecks method of the GitHub client.
+// It retrieves the status checks required by the protection of a branch.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - branch: The name of the protected branch.
+//
+// Returns:
+//   - *github.RequiredStatusChecks: A pointer to the required status checks.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetRequiredStatusChecks(ctx context.Context, owner string, repo string, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, branch)
+	return args.Get(0).(*github.RequiredStatusChecks), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UpdatePullRequestBranch mocks the UpdatePullRequestBranch method of the GitHub client.
+// It merges the base branch into the head branch of a pull request.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - opts: The head SHA the branch is expected to be at.
+//
+// Returns:
+//   - *github.PullRequestBranchUpdateResponse: A pointer to the message returned by GitHub.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdatePullRequestBranch(ctx context.Context, owner string, repo string, number int, opts *github.PullRequestBranchUpdateOptions) (*github.PullRequestBranchUpdateResponse, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, number, opts)
+	return args.Get(0).(*github.PullRequestBranchUpdateResponse), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListReleases mocks the ListReleases method of the GitHub client.
+// It lists the releases of a repository, including drafts visible to the token.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: Options for paginating the results.
+//
+// Returns:
+//   - []*github.RepositoryRelease: A slice of pointers to the listed releases.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListReleases(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).([]*github.RepositoryRelease), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetRelease mocks the GetRelease method of the GitHub client.
+// It retrieves a single release by its ID.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release.
+//
+// Returns:
+//   - *github.RepositoryRelease: A pointer to the retrieved release.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetRelease(ctx context.Context, owner string, repo string, id int64) (*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	return args.Get(0).(*github.RepositoryRelease), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetReleaseByTag mocks the GetReleaseByTag method of the GitHub client.
+// It retrieves the published release of a tag.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - tag: The name of the tag.
+//
+// Returns:
+//   - *github.RepositoryRelease: A pointer to the retrieved release.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetReleaseByTag(ctx context.Context, owner string, repo string, tag string) (*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, tag)
+	return args.Get(0).(*github.RepositoryRelease), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateRelease mocks the CreateRelease method of the GitHub client.
+// It creates a release in a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - release: The tag, target, name, body and flags of the release.
+//
+// Returns:
+//   - *github.RepositoryRelease: A pointer to the created release.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateRelease(ctx context.Context, owner string, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, release)
+	return args.Get(0).(*github.RepositoryRelease), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditRelease mocks the EditRelease method of the GitHub client.
+// It updates a release.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release.
+//   - release: The fields of the release to be updated.
+//
+// Returns:
+//   - *github.RepositoryRelease: A pointer to the updated release.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditRelease(ctx context.Context, owner string, repo string, id int64, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id, release)
+	return args.Get(0).(*github.RepositoryRelease), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteRelease mocks the DeleteRelease method of the GitHub client.
+// It deletes a release. The tag of the release is kept.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteRelease(ctx context.Context, owner string, repo string, id int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListReleaseAssets mocks the ListReleaseAssets method of the GitHub client.
+// It lists the assets of a release.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release.
+//   - opts: Options for paginating the results.
+//
+// Returns:
+//   - []*github.ReleaseAsset: A slice of pointers to the listed assets.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListReleaseAssets(ctx context.Context, owner string, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id, opts)
+	return args.Get(0).([]*github.ReleaseAsset), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetReleaseAsset mocks the GetReleaseAsset method of the GitHub client.
+// It retrieves the metadata of a release asset.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release asset.
+//
+// Returns:
+//   - *github.ReleaseAsset: A pointer to the retrieved asset.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetReleaseAsset(ctx context.Context, owner string, repo string, id int64) (*github.ReleaseAsset, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	return args.Get(0).(*github.ReleaseAsset), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UploadReleaseAsset mocks the UploadReleaseAsset method of the GitHub client.
+// It uploads a file as an asset of a release.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release.
+//   - opts: The name, label and media type of the asset.
+//   - file: The file to upload.
+//
+// Returns:
+//   - *github.ReleaseAsset: A pointer to the uploaded asset.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UploadReleaseAsset(ctx context.Context, owner string, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, id, opts, file)
+	return args.Get(0).(*github.ReleaseAsset), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DownloadReleaseAsset mocks the DownloadReleaseAsset method of the GitHub client.
+// It downloads the content of a release asset.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release asset.
+//
+// Returns:
+//   - io.ReadCloser: The content of the asset, which the caller must close.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DownloadReleaseAsset(ctx context.Context, owner string, repo string, id int64) (io.ReadCloser, error) {
+	args := m.Called(ctx, owner, repo, id)
+	rc, _ := args.Get(0).(io.ReadCloser)
+	return rc, args.Error(1)
+}
+
+// DeleteReleaseAsset mocks the DeleteReleaseAsset method of the GitHub client.
+// It deletes a release asset.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the release asset.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteReleaseAsset(ctx context.Context, owner string, repo string, id int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListTags mocks the ListTags method of the GitHub client.
+// It lists the tags of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: Options for paginating the results.
+//
+// Returns:
+//   - []*github.RepositoryTag: A slice of pointers to the listed tags.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListTags(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).([]*github.RepositoryTag), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateTag mocks the CreateTag method of the GitHub client.
+// It creates an annotated tag object. The tag is only visible once a reference points to it.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - tag: The name, message and object of the tag.
+//
+// Returns:
+//   - *github.Tag: A pointer to the created tag object.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateTag(ctx context.Context, owner string, repo string, tag *github.Tag) (*github.Tag, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, tag)
+	return args.Get(0).(*github.Tag), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateRef mocks the CreateRef method of the GitHub client.
+// It creates a Git reference, such as refs/tags/v1.0.0.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The full name of the reference and the object it points to.
+//
+// Returns:
+//   - *github.Reference: A pointer to the created reference.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateRef(ctx context.Context, owner string, repo string, ref *github.Reference) (*github.Reference, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref)
+	return args.Get(0).(*github.Reference), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CompareCommits mocks the CompareCommits method of the GitHub client.
+// It compares two commits, branches or tags.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - base: The commit, branch or tag to compare from.
+//   - head: The commit, branch or tag to compare to.
+//   - opts: Options for paginating the results.
+//
+// Returns:
+//   - *github.CommitsComparison: A pointer to the comparison, with one page of its commits.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CompareCommits(ctx context.Context, owner string, repo string, base string, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, base, head, opts)
+	return args.Get(0).(*github.CommitsComparison), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListCommits mocks the ListCommits method of the GitHub client.
+// It lists the commits of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: Options for filtering the commits by ref, path, author and date, and paginating them.
+//
+// Returns:
+//   - []*github.RepositoryCommit: A slice of pointers to the listed commits.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListCommits(ctx context.Context, owner string, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).([]*github.RepositoryCommit), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetCommit mocks the GetCommit method of the GitHub client.
+// It retrieves a single commit with its stats and changed files.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - sha: The SHA, branch or tag of the commit.
+//   - opts: Options for paginating the changed files.
+//
+// Returns:
+//   - *github.RepositoryCommit: A pointer to the retrieved commit.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetCommit(ctx context.Context, owner string, repo string, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, sha, opts)
+	return args.Get(0).(*github.RepositoryCommit), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListPullRequestsWithCommit mocks the ListPullRequestsWithCommit method of the GitHub client.
+// It lists the pull requests a commit belongs to.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - sha: The SHA of the commit.
+//   - opts: Options for paginating the pull requests.
+//
+// Returns:
+//   - []*github.PullRequest: A slice of pointers to the listed pull requests.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListPullRequestsWithCommit(ctx context.Context, owner string, repo string, sha string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, sha, opts)
+	return args.Get(0).([]*github.PullRequest), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetContents mocks the GetContents method of the GitHub client.
+// It retrieves a file or the entries of a directory at a ref.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file or directory in the repository.
+//   - opts: The ref to read, defaulting to the default branch.
+//
+// Returns:
+//   - *github.RepositoryContent: A pointer to the file, or nil if the path is a directory.
+//   - []*github.RepositoryContent: The entries of the directory, or nil if the path is a file.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetContents(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, path, opts)
+	return args.Get(0).(*github.RepositoryContent), args.Get(1).([]*github.RepositoryContent), args.Get(2).(*github.Response), args.Error(3)
+}
+
+// DownloadContents mocks the DownloadContents method of the GitHub client.
+// It downloads the raw content of a file at a ref, including files too large for GetContents.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file in the repository.
+//   - opts: The ref to read, defaulting to the default branch.
+//
+// Returns:
+//   - io.ReadCloser: The content of the file, which the caller must close.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DownloadContents(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentGetOptions) (io.ReadCloser, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, path, opts)
+	rc, _ := args.Get(0).(io.ReadCloser)
+	return rc, args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateFile mocks the CreateFile method of the GitHub client.
+// It creates a file in a repository with a new commit.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file in the repository.
+//   - opts: The commit message, content, expected file SHA and target branch.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: A pointer to the created file and commit.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateFile(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, path, opts)
+	return args.Get(0).(*github.RepositoryContentResponse), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UpdateFile mocks the UpdateFile method of the GitHub client.
+// It replaces the content of a file with a new commit.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file in the repository.
+//   - opts: The commit message, content, expected file SHA and target branch.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: A pointer to the updated file and commit.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateFile(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, path, opts)
+	return args.Get(0).(*github.RepositoryContentResponse), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteFile mocks the DeleteFile method of the GitHub client.
+// It deletes a file with a new commit.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file in the repository.
+//   - opts: The commit message, content, expected file SHA and target branch.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: A pointer to the commit deleting the file.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteFile(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, path, opts)
+	return args.Get(0).(*github.RepositoryContentResponse), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetArchiveLink mocks the GetArchiveLink method of the GitHub client.
+// It retrieves the download link of a tarball or zipball of a repository at a ref.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - format: The archive format: github.Tarball or github.Zipball.
+//   - opts: The ref to archive, defaulting to the default branch.
+//
+// Returns:
+//   - *url.URL: The temporary download link of the archive.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetArchiveLink(ctx context.Context, owner string, repo string, format github.ArchiveFormat, opts *github.RepositoryContentGetOptions) (*url.URL, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, format, opts)
+	return args.Get(0).(*url.URL), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetRef mocks the GetRef method of the GitHub client.
+// It retrieves a Git reference, such as heads/main.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The name of the reference, with or without the refs/ prefix.
+//
+// Returns:
+//   - *github.Reference: A pointer to the reference and the object it points to.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetRef(ctx context.Context, owner string, repo string, ref string) (*github.Reference, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref)
+	return args.Get(0).(*github.Reference), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UpdateRef mocks the UpdateRef method of the GitHub client.
+// It points a Git reference to another object.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The full name of the reference and the object it must point to.
+//   - force: Whether to allow an update that is not a fast-forward.
+//
+// Returns:
+//   - *github.Reference: A pointer to the updated reference.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateRef(ctx context.Context, owner string, repo string, ref *github.Reference, force bool) (*github.Reference, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref, force)
+	return args.Get(0).(*github.Reference), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetGitCommit mocks the GetGitCommit method of the GitHub client.
+// It retrieves a Git commit object, with its tree and parents.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - sha: The SHA of the commit.
+//
+// Returns:
+//   - *github.Commit: A pointer to the commit.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetGitCommit(ctx context.Context, owner string, repo string, sha string) (*github.Commit, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, sha)
+	return args.Get(0).(*github.Commit), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateGitCommit mocks the CreateGitCommit method of the GitHub client.
+// It creates a Git commit object from a tree and its parents.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - commit: The message, tree, parents, author and committer of the commit.
+//
+// Returns:
+//   - *github.Commit: A pointer to the created commit.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateGitCommit(ctx context.Context, owner string, repo string, commit *github.Commit) (*github.Commit, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, commit)
+	return args.Get(0).(*github.Commit), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateBlob mocks the CreateBlob method of the GitHub client.
+// It creates a Git blob object.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - blob: The content of the blob and its encoding, utf-8 or base64.
+//
+// Returns:
+//   - *github.Blob: A pointer to the created blob, with its SHA.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateBlob(ctx context.Context, owner string, repo string, blob *github.Blob) (*github.Blob, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, blob)
+	return args.Get(0).(*github.Blob), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateTree mocks the CreateTree method of the GitHub client.
+// It creates a Git tree object by applying entries to a base tree.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - baseTree: The SHA of the tree to start from, or an empty string for an empty tree.
+//   - entries: The entries to add or replace; an entry with neither SHA nor content deletes its path.
+//
+// Returns:
+//   - *github.Tree: A pointer to the created tree.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateTree(ctx context.Context, owner string, repo string, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, baseTree, entries)
+	return args.Get(0).(*github.Tree), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateFromTemplate mocks the CreateFromTemplate method of the GitHub client.
+// It generates a repository from a template repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - templateOwner: The owner of the template repository.
+//   - templateRepo: The name of the template repository.
+//   - req: The name, owner, description and visibility of the new repository.
+//
+// Returns:
+//   - *github.Repository: A pointer to the generated repository.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateFromTemplate(ctx context.Context, templateOwner string, templateRepo string, req *github.TemplateRepoRequest) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, templateOwner, templateRepo, req)
+	return args.Get(0).(*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// EditRepository mocks the EditRepository method of the GitHub client.
+// It updates the settings of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - repository: The settings to change; nil fields are left unchanged.
+//
+// Returns:
+//   - *github.Repository: A pointer to the updated repository.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) EditRepository(ctx context.Context, owner string, repo string, repository *github.Repository) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, repository)
+	return args.Get(0).(*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ReplaceAllTopics mocks the ReplaceAllTopics method of the GitHub client.
+// It replaces the topics of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - topics: The new topics, lowercase; an empty list removes all topics.
+//
+// Returns:
+//   - []string: The topics of the repository.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ReplaceAllTopics(ctx context.Context, owner string, repo string, topics []string) ([]string, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, topics)
+	return args.Get(0).([]string), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// RenameBranch mocks the RenameBranch method of the GitHub client.
+// It renames a branch, updating the default branch and open pull requests.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - branch: The current name of the branch.
+//   - newName: The new name of the branch.
+//
+// Returns:
+//   - *github.Branch: A pointer to the renamed branch.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) RenameBranch(ctx context.Context, owner string, repo string, branch string, newName string) (*github.Branch, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, branch, newName)
+	return args.Get(0).(*github.Branch), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// TransferRepository mocks the TransferRepository method of the GitHub client.
+// It starts the transfer of a repository to another user or organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - transfer: The new owner and the teams of the organization given access.
+//
+// Returns:
+//   - *github.Repository: A pointer to the repository, at its old location while the transfer is pending.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) TransferRepository(ctx context.Context, owner string, repo string, transfer github.TransferRequest) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, transfer)
+	return args.Get(0).(*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateFork mocks the CreateFork method of the GitHub client.
+// It starts forking a repository into the authenticated user's account or an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The organization, name and default-branch-only flag of the fork.
+//
+// Returns:
+//   - *github.Repository: A pointer to the fork, unset while GitHub is still creating it.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateFork(ctx context.Context, owner string, repo string, opts *github.RepositoryCreateForkOptions) (*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListForks mocks the ListForks method of the GitHub client.
+// It lists the forks of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The sort order and pagination of the forks.
+//
+// Returns:
+//   - []*github.Repository: A slice of forks.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListForks(ctx context.Context, owner string, repo string, opts *github.RepositoryListForksOptions) ([]*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).([]*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// MergeUpstream mocks the MergeUpstream method of the GitHub client.
+// It syncs a branch of a fork with the upstream repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the fork.
+//   - repo: The name of the fork.
+//   - request: The branch to sync.
+//
+// Returns:
+//   - *github.RepoMergeUpstreamResult: A pointer to the merge type and message of the sync.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) MergeUpstream(ctx context.Context, owner string, repo string, request *github.RepoMergeUpstreamRequest) (*github.RepoMergeUpstreamResult, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, request)
+	return args.Get(0).(*github.RepoMergeUpstreamResult), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListAllTopics mocks the ListAllTopics method of the GitHub client.
+// It lists the topics of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - []string: The topics of the repository.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListAllTopics(ctx context.Context, owner string, repo string) ([]string, *github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).([]string), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListOrgRepos mocks the ListOrgRepos method of the GitHub client.
+// It lists the repositories of an organization, including the private ones the user can see.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - opt: The type, sort order and pagination of the repositories.
+//
+// Returns:
+//   - []*github.Repository: A slice of repositories.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListOrgRepos(ctx context.Context, org string, opt *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	args := m.Called(ctx, org, opt)
+	return args.Get(0).([]*github.Repository), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetCustomPropertyValues mocks the GetCustomPropertyValues method of the GitHub client.
+// It retrieves the values of the organization custom properties of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - []*interfaces.CustomPropertyValue: A slice of property values, set or not.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetCustomPropertyValues(ctx context.Context, owner string, repo string) ([]*interfaces.CustomPropertyValue, *github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).([]*interfaces.CustomPropertyValue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UpdateCustomPropertyValues mocks the UpdateCustomPropertyValues method of the GitHub client.
+// It creates or updates the values of organization custom properties of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - values: The property values to set.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateCustomPropertyValues(ctx context.Context, owner string, repo string, values []*interfaces.CustomPropertyValue) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, values)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListCustomPropertyValues mocks the ListCustomPropertyValues method of the GitHub client.
+// It lists the custom property values of the repositories of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - opt: The pagination of the repositories.
+//
+// Returns:
+//   - []*interfaces.RepoCustomPropertyValue: A slice of repositories with their property values.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListCustomPropertyValues(ctx context.Context, org string, opt *github.ListOptions) ([]*interfaces.RepoCustomPropertyValue, *github.Response, error) {
+	args := m.Called(ctx, org, opt)
+	return args.Get(0).([]*interfaces.RepoCustomPropertyValue), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetRepoPublicKey mocks the GetRepoPublicKey method of the GitHub client.
+// It retrieves the public key secrets of a repository are encrypted with.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - *github.PublicKey: A pointer to the key ID and base64-encoded public key.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetRepoPublicKey(ctx context.Context, owner string, repo string) (*github.PublicKey, *github.Response, error) {
+	args := m.Called(ctx, owner, repo)
+	return args.Get(0).(*github.PublicKey), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetOrgPublicKey mocks the GetOrgPublicKey method of the GitHub client.
+// It retrieves the public key secrets of an organization are encrypted with.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//
+// Returns:
+//   - *github.PublicKey: A pointer to the key ID and base64-encoded public key.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetOrgPublicKey(ctx context.Context, org string) (*github.PublicKey, *github.Response, error) {
+	args := m.Called(ctx, org)
+	return args.Get(0).(*github.PublicKey), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListRepoSecrets mocks the ListRepoSecrets method of the GitHub client.
+// It lists the Actions secrets of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.Secrets: A pointer to the secret names, without their values, and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListRepoSecrets(ctx context.Context, owner string, repo string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.Secrets), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListOrgSecrets mocks the ListOrgSecrets method of the GitHub client.
+// It lists the Actions secrets of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.Secrets: A pointer to the secret names, without their values, and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListOrgSecrets(ctx context.Context, org string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	args := m.Called(ctx, org, opts)
+	return args.Get(0).(*github.Secrets), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateOrUpdateRepoSecret mocks the CreateOrUpdateRepoSecret method of the GitHub client.
+// It creates or updates an Actions secret of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - secret: The name, key ID and encrypted value of the secret.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateOrUpdateRepoSecret(ctx context.Context, owner string, repo string, secret *github.EncryptedSecret) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, secret)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// CreateOrUpdateOrgSecret mocks the CreateOrUpdateOrgSecret method of the GitHub client.
+// It creates or updates an Actions secret of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - secret: The name, key ID, encrypted value, visibility and selected repositories of the secret.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateOrUpdateOrgSecret(ctx context.Context, org string, secret *github.EncryptedSecret) (*github.Response, error) {
+	args := m.Called(ctx, org, secret)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// DeleteRepoSecret mocks the DeleteRepoSecret method of the GitHub client.
+// It deletes an Actions secret of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The name of the secret.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteRepoSecret(ctx context.Context, owner string, repo string, name string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, name)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// DeleteOrgSecret mocks the DeleteOrgSecret method of the GitHub client.
+// It deletes an Actions secret of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - name: The name of the secret.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteOrgSecret(ctx context.Context, org string, name string) (*github.Response, error) {
+	args := m.Called(ctx, org, name)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListRepoVariables mocks the ListRepoVariables method of the GitHub client.
+// It lists the Actions variables of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.ActionsVariables: A pointer to the variables and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListRepoVariables(ctx context.Context, owner string, repo string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.ActionsVariables), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListOrgVariables mocks the ListOrgVariables method of the GitHub client.
+// It lists the Actions variables of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.ActionsVariables: A pointer to the variables and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListOrgVariables(ctx context.Context, org string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	args := m.Called(ctx, org, opts)
+	return args.Get(0).(*github.ActionsVariables), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateRepoVariable mocks the CreateRepoVariable method of the GitHub client.
+// It creates an Actions variable of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - variable: The name and value of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateRepoVariable(ctx context.Context, owner string, repo string, variable *github.ActionsVariable) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, variable)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// CreateOrgVariable mocks the CreateOrgVariable method of the GitHub client.
+// It creates an Actions variable of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - variable: The name, value, visibility and selected repositories of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateOrgVariable(ctx context.Context, org string, variable *github.ActionsVariable) (*github.Response, error) {
+	args := m.Called(ctx, org, variable)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// UpdateRepoVariable mocks the UpdateRepoVariable method of the GitHub client.
+// It updates an Actions variable of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - variable: The name and value of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateRepoVariable(ctx context.Context, owner string, repo string, variable *github.ActionsVariable) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, variable)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// UpdateOrgVariable mocks the UpdateOrgVariable method of the GitHub client.
+// It updates an Actions variable of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - variable: The name, value, visibility and selected repositories of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateOrgVariable(ctx context.Context, org string, variable *github.ActionsVariable) (*github.Response, error) {
+	args := m.Called(ctx, org, variable)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// DeleteRepoVariable mocks the DeleteRepoVariable method of the GitHub client.
+// It deletes an Actions variable of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The name of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteRepoVariable(ctx context.Context, owner string, repo string, name string) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, name)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// DeleteOrgVariable mocks the DeleteOrgVariable method of the GitHub client.
+// It deletes an Actions variable of an organization.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - org: The login of the organization.
+//   - name: The name of the variable.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteOrgVariable(ctx context.Context, org string, name string) (*github.Response, error) {
+	args := m.Called(ctx, org, name)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListWorkflows mocks the ListWorkflows method of the GitHub client.
+// It lists the workflows of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.Workflows: A pointer to the workflows and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListWorkflows(ctx context.Context, owner string, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.Workflows), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListRepositoryWorkflowRuns mocks the ListRepositoryWorkflowRuns method of the GitHub client.
+// It lists the workflow runs of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The branch, event, status and actor filters and the pagination.
+//
+// Returns:
+//   - *github.WorkflowRuns: A pointer to the workflow runs and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListRepositoryWorkflowRuns(ctx context.Context, owner string, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.WorkflowRuns), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListWorkflowRunsByFileName mocks the ListWorkflowRunsByFileName method of the GitHub client.
+// It lists the runs of a workflow.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - workflow: The file name or ID of the workflow.
+//   - opts: The branch, event, status and actor filters and the pagination.
+//
+// Returns:
+//   - *github.WorkflowRuns: A pointer to the workflow runs and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListWorkflowRunsByFileName(ctx context.Context, owner string, repo string, workflow string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, workflow, opts)
+	return args.Get(0).(*github.WorkflowRuns), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetWorkflowRunByID mocks the GetWorkflowRunByID method of the GitHub client.
+// It retrieves a workflow run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//
+// Returns:
+//   - *github.WorkflowRun: A pointer to the workflow run.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetWorkflowRunByID(ctx context.Context, owner string, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).(*github.WorkflowRun), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListWorkflowJobs mocks the ListWorkflowJobs method of the GitHub client.
+// It lists the jobs of a workflow run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//   - opts: Whether to list the jobs of the latest or of all attempts, and the pagination.
+//
+// Returns:
+//   - *github.Jobs: A pointer to the jobs and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListWorkflowJobs(ctx context.Context, owner string, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID, opts)
+	return args.Get(0).(*github.Jobs), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetWorkflowJobLogs mocks the GetWorkflowJobLogs method of the GitHub client.
+// It retrieves the download link of the logs of a workflow job.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - jobID: The ID of the job.
+//
+// Returns:
+//   - *url.URL: The temporary download link of the logs.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetWorkflowJobLogs(ctx context.Context, owner string, repo string, jobID int64) (*url.URL, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, jobID)
+	return args.Get(0).(*url.URL), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// RerunWorkflowByID mocks the RerunWorkflowByID method of the GitHub client.
+// It re-runs all the jobs of a workflow run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) RerunWorkflowByID(ctx context.Context, owner string, repo string, runID int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// RerunFailedJobsByID mocks the RerunFailedJobsByID method of the GitHub client.
+// It re-runs the failed jobs of a workflow run and the jobs depending on them.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) RerunFailedJobsByID(ctx context.Context, owner string, repo string, runID int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// CancelWorkflowRunByID mocks the CancelWorkflowRunByID method of the GitHub client.
+// It cancels a workflow run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CancelWorkflowRunByID(ctx context.Context, owner string, repo string, runID int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// CreateWorkflowDispatchEventByFileName mocks the CreateWorkflowDispatchEventByFileName method of the GitHub client.
+// It triggers a workflow with a workflow_dispatch event.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - workflow: The file name or ID of the workflow.
+//   - event: The ref to run the workflow on and its inputs.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner string, repo string, workflow string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, workflow, event)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
+
+// ListArtifacts mocks the ListArtifacts method of the GitHub client.
+// It lists the artifacts of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.ArtifactList: A pointer to the artifacts and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListArtifacts(ctx context.Context, owner string, repo string, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.ArtifactList), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListWorkflowRunArtifacts mocks the ListWorkflowRunArtifacts method of the GitHub client.
+// It lists the artifacts of a workflow run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - *github.ArtifactList: A pointer to the artifacts and their total count.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListWorkflowRunArtifacts(ctx context.Context, owner string, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, runID, opts)
+	return args.Get(0).(*github.ArtifactList), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetArtifact mocks the GetArtifact method of the GitHub client.
+// It retrieves an artifact.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - artifactID: The ID of the artifact.
+//
+// Returns:
+//   - *github.Artifact: A pointer to the artifact.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetArtifact(ctx context.Context, owner string, repo string, artifactID int64) (*github.Artifact, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, artifactID)
+	return args.Get(0).(*github.Artifact), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DownloadArtifact mocks the DownloadArtifact method of the GitHub client.
+// It retrieves the download link of the zip archive of an artifact.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - artifactID: The ID of the artifact.
+//
+// Returns:
+//   - *url.URL: The temporary download link of the archive.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DownloadArtifact(ctx context.Context, owner string, repo string, artifactID int64) (*url.URL, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, artifactID)
+	return args.Get(0).(*url.URL), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateStatus mocks the CreateStatus method of the GitHub client.
+// It creates a commit status for a ref.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - ref: The SHA, branch or tag of the commit.
+//   - status: The state, target URL, description and context of the status.
+//
+// Returns:
+//   - *github.RepoStatus: A pointer to the created status.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateStatus(ctx context.Context, owner string, repo string, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, ref, status)
+	return args.Get(0).(*github.RepoStatus), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateCheckRun mocks the CreateCheckRun method of the GitHub client.
+// It creates a check run for a commit.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The name, head SHA, status, conclusion and output of the check run.
+//
+// Returns:
+//   - *github.CheckRun: A pointer to the check run.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateCheckRun(ctx context.Context, owner string, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).(*github.CheckRun), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// GetCheckRun mocks the GetCheckRun method of the GitHub client.
+// It retrieves a check run.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - checkRunID: The ID of the check run.
+//
+// Returns:
+//   - *github.CheckRun: A pointer to the check run.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) GetCheckRun(ctx context.Context, owner string, repo string, checkRunID int64) (*github.CheckRun, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, checkRunID)
+	return args.Get(0).(*github.CheckRun), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// UpdateCheckRun mocks the UpdateCheckRun method of the GitHub client.
+// It updates a check run; annotations in its output are added to the existing ones.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - checkRunID: The ID of the check run.
+//   - opts: The name, status, conclusion and output of the check run.
+//
+// Returns:
+//   - *github.CheckRun: A pointer to the check run.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) UpdateCheckRun(ctx context.Context, owner string, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, checkRunID, opts)
+	return args.Get(0).(*github.CheckRun), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// ListKeys mocks the ListKeys method of the GitHub client.
+// It lists the deploy keys of a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The pagination options.
+//
+// Returns:
+//   - []*github.Key: A slice of pointers to the deploy keys.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) ListKeys(ctx context.Context, owner string, repo string, opts *github.ListOptions) ([]*github.Key, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, opts)
+	return args.Get(0).([]*github.Key), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// CreateKey mocks the CreateKey method of the GitHub client.
+// It adds a deploy key to a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - key: The title, public key and access of the deploy key.
+//
+// Returns:
+//   - *github.Key: A pointer to the deploy key.
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) CreateKey(ctx context.Context, owner string, repo string, key *github.Key) (*github.Key, *github.Response, error) {
+	args := m.Called(ctx, owner, repo, key)
+	return args.Get(0).(*github.Key), args.Get(1).(*github.Response), args.Error(2)
+}
+
+// DeleteKey mocks the DeleteKey method of the GitHub client.
+// It removes a deploy key from a repository.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the deploy key.
+//
+// Returns:
+//   - *github.Response: The HTTP response from the GitHub API.
+//   - error: An error if the operation fails.
+func (m *MockGitHubClient) DeleteKey(ctx context.Context, owner string, repo string, id int64) (*github.Response, error) {
+	args := m.Called(ctx, owner, repo, id)
+	return args.Get(0).(*github.Response), args.Error(1)
+}
@@ -0,0 +1,191 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+)
+
+// ErrArtifactExpired is returned when downloading an artifact past its retention period.
+var ErrArtifactExpired = errors.New("artifact has expired")
+
+// WorkflowRunFilter holds the query parameters accepted when listing workflow runs. Workflow is
+// the file name or ID of a workflow, and Created a date or range such as ">=2024-01-01".
+type WorkflowRunFilter struct {
+	Workflow string `form:"workflow"`
+	Branch   string `form:"branch"`
+	Event    string `form:"event"`
+	Status   string `form:"status" binding:"omitempty,oneof=completed action_required cancelled failure neutral skipped stale success timed_out in_progress queued requested waiting pending"`
+	Actor    string `form:"actor"`
+	HeadSHA  string `form:"head_sha"`
+	Created  string `form:"created"`
+}
+
+// ToOptions converts the filter to the options of the GitHub workflow runs API.
+//
+// Parameters:
+//   - page: The pagination options of the request.
+//
+// Returns:
+//   - *github.ListWorkflowRunsOptions: The options to list workflow runs with.
+func (f WorkflowRunFilter) ToOptions(page github.ListOptions) *github.ListWorkflowRunsOptions {
+	return &github.ListWorkflowRunsOptions{
+		Actor:       f.Actor,
+		Branch:      f.Branch,
+		Event:       f.Event,
+		Status:      f.Status,
+		Created:     f.Created,
+		HeadSHA:     f.HeadSHA,
+		ListOptions: page,
+	}
+}
+
+// DispatchRequest is the payload accepted when triggering a workflow. The workflow runs on the
+// default branch of the repository unless Ref names a branch or tag. Inputs are the values of
+// the inputs the workflow declares, at most 10.
+type DispatchRequest struct {
+	Ref    string                 `json:"ref"`
+	Inputs map[string]interface{} `json:"inputs" binding:"max=10"`
+}
+
+// RerunRequest is the payload accepted when re-running a workflow run. With FailedOnly, only the
+// failed jobs and the jobs depending on them are re-run.
+type RerunRequest struct {
+	FailedOnly bool `json:"failed_only"`
+}
+
+// ListWorkflowRuns lists a page of the workflow runs of a repository, or of one of its workflows.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - filter: The workflow, branch, event, status, actor, head SHA and creation date of the runs.
+//   - page: The pagination options.
+//
+// Returns:
+//   - []*github.WorkflowRun: The workflow runs, newest first.
+//   - *github.Response: The response of the page, holding the pagination links.
+//   - error: An error if the repository or the workflow does not exist.
+func ListWorkflowRuns(ctx context.Context, client interfaces.GitHubClient, owner, repo string, filter WorkflowRunFilter, page github.ListOptions) ([]*github.WorkflowRun, *github.Response, error) {
+	opts := filter.ToOptions(page)
+	var runs *github.WorkflowRuns
+	var resp *github.Response
+	var err error
+	if filter.Workflow != "" {
+		runs, resp, err = client.ListWorkflowRunsByFileName(ctx, owner, repo, filter.Workflow, opts)
+	} else {
+		runs, resp, err = client.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return runs.WorkflowRuns, resp, nil
+}
+
+// DispatchWorkflow triggers a workflow with a workflow_dispatch event.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - workflow: The file name or ID of the workflow.
+//   - req: The ref to run the workflow on and its inputs.
+//
+// Returns:
+//   - string: The ref the workflow runs on.
+//   - error: An error if the repository or the workflow does not exist, or the workflow cannot be triggered.
+func DispatchWorkflow(ctx context.Context, client interfaces.GitHubClient, owner, repo, workflow string, req DispatchRequest) (string, error) {
+	ref := req.Ref
+	if ref == "" {
+		repository, _, err := client.GetRepositories(ctx, owner, repo)
+		if err != nil {
+			return "", err
+		}
+		ref = repository.GetDefaultBranch()
+	}
+	_, err := client.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflow, github.CreateWorkflowDispatchEventRequest{
+		Ref:    ref,
+		Inputs: req.Inputs,
+	})
+	return ref, err
+}
+
+// RerunWorkflowRun re-runs all the jobs of a workflow run, or only the failed ones.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - runID: The ID of the workflow run.
+//   - req: Whether to re-run the failed jobs only.
+//
+// Returns:
+//   - error: An error if the run does not exist or cannot be re-run.
+func RerunWorkflowRun(ctx context.Context, client interfaces.GitHubClient, owner, repo string, runID int64, req RerunRequest) error {
+	var err error
+	if req.FailedOnly {
+		_, err = client.RerunFailedJobsByID(ctx, owner, repo, runID)
+	} else {
+		_, err = client.RerunWorkflowByID(ctx, owner, repo, runID)
+	}
+	return err
+}
+
+// DownloadJobLogs downloads the plain-text logs of a workflow job.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - jobID: The ID of the job.
+//
+// Returns:
+//   - *http.Response: The response streaming the logs; the caller must close its body.
+//   - error: An error if the link cannot be retrieved or the download fails.
+func DownloadJobLogs(ctx context.Context, client interfaces.GitHubClient, owner, repo string, jobID int64) (*http.Response, error) {
+	link, _, err := client.GetWorkflowJobLogs(ctx, owner, repo, jobID)
+	if err != nil {
+		return nil, err
+	}
+	return fetchLink(ctx, link, "job logs")
+}
+
+// DownloadArtifact downloads the zip archive of an artifact.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - artifactID: The ID of the artifact.
+//
+// Returns:
+//   - *github.Artifact: The artifact, with its name and size.
+//   - *http.Response: The response streaming the archive; the caller must close its body.
+//   - error: ErrArtifactExpired if the artifact has expired, or an error if the download fails.
+func DownloadArtifact(ctx context.Context, client interfaces.GitHubClient, owner, repo string, artifactID int64) (*github.Artifact, *http.Response, error) {
+	artifact, _, err := client.GetArtifact(ctx, owner, repo, artifactID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if artifact.GetExpired() {
+		return nil, nil, fmt.Errorf("%w: %s expired at %s", ErrArtifactExpired, artifact.GetName(), artifact.GetExpiresAt())
+	}
+	link, _, err := client.DownloadArtifact(ctx, owner, repo, artifactID)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := fetchLink(ctx, link, "artifact")
+	if err != nil {
+		return nil, nil, err
+	}
+	return artifact, resp, nil
+}
@@ -0,0 +1,186 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"golang.org/x/crypto/nacl/box"
+	"net/http"
+)
+
+// SecretRequest is the payload accepted when creating or updating an Actions secret of a
+// repository. The value is encrypted before it leaves the API and is never returned.
+type SecretRequest struct {
+	Value string `json:"value" binding:"required"`
+}
+
+// OrgSecretRequest is the payload accepted when creating or updating an Actions secret of an
+// organization. Visibility is all, private or selected; with selected, only the repositories
+// listed in SelectedRepositoryIDs can use the secret.
+type OrgSecretRequest struct {
+	Value                 string  `json:"value" binding:"required"`
+	Visibility            string  `json:"visibility" binding:"required,oneof=all private selected"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids" binding:"omitempty,dive,min=1"`
+}
+
+// VariableRequest is the payload accepted when creating or updating an Actions variable of a repository.
+type VariableRequest struct {
+	Value string `json:"value" binding:"required"`
+}
+
+// OrgVariableRequest is the payload accepted when creating or updating an Actions variable of an
+// organization, with the same visibility rules as OrgSecretRequest.
+type OrgVariableRequest struct {
+	Value                 string  `json:"value" binding:"required"`
+	Visibility            string  `json:"visibility" binding:"required,oneof=all private selected"`
+	SelectedRepositoryIDs []int64 `json:"selected_repository_ids" binding:"omitempty,dive,min=1"`
+}
+
+// EncryptSecret encrypts the value of a secret into a libsodium sealed box for the public key of
+// a repository or organization, as GitHub requires.
+//
+// Parameters:
+//   - key: The public key of the repository or organization.
+//   - name: The name of the secret.
+//   - value: The plaintext value of the secret.
+//
+// Returns:
+//   - *github.EncryptedSecret: The secret with its base64-encoded encrypted value and the key ID.
+//   - error: An error if the public key is malformed.
+func EncryptSecret(key *github.PublicKey, name, value string) (*github.EncryptedSecret, error) {
+	raw, err := base64.StdEncoding.DecodeString(key.GetKey())
+	if err != nil {
+		return nil, fmt.Errorf("decoding public key %s: %w", key.GetKeyID(), err)
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("public key %s is %d bytes long, not 32", key.GetKeyID(), len(raw))
+	}
+	var recipient [32]byte
+	copy(recipient[:], raw)
+	sealed, err := box.SealAnonymous(nil, []byte(value), &recipient, rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &github.EncryptedSecret{
+		Name:           name,
+		KeyID:          key.GetKeyID(),
+		EncryptedValue: base64.StdEncoding.EncodeToString(sealed),
+	}, nil
+}
+
+// SetRepoSecret encrypts a value with the public key of a repository and stores it as an Actions secret.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The name of the secret.
+//   - req: The value of the secret.
+//
+// Returns:
+//   - bool: True if the secret was created, false if it was updated.
+//   - error: An error if the public key cannot be retrieved or the secret cannot be stored.
+func SetRepoSecret(ctx context.Context, client interfaces.GitHubClient, owner, repo, name string, req SecretRequest) (bool, error) {
+	key, _, err := client.GetRepoPublicKey(ctx, owner, repo)
+	if err != nil {
+		return false, err
+	}
+	secret, err := EncryptSecret(key, name, req.Value)
+	if err != nil {
+		return false, err
+	}
+	resp, err := client.CreateOrUpdateRepoSecret(ctx, owner, repo, secret)
+	if err != nil {
+		return false, err
+	}
+	return isCreated(resp), nil
+}
+
+// SetOrgSecret encrypts a value with the public key of an organization and stores it as an
+// Actions secret with the requested visibility.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - org: The login of the organization.
+//   - name: The name of the secret.
+//   - req: The value, visibility and selected repositories of the secret.
+//
+// Returns:
+//   - bool: True if the secret was created, false if it was updated.
+//   - error: An error if the public key cannot be retrieved or the secret cannot be stored.
+func SetOrgSecret(ctx context.Context, client interfaces.GitHubClient, org, name string, req OrgSecretRequest) (bool, error) {
+	key, _, err := client.GetOrgPublicKey(ctx, org)
+	if err != nil {
+		return false, err
+	}
+	secret, err := EncryptSecret(key, name, req.Value)
+	if err != nil {
+		return false, err
+	}
+	secret.Visibility = req.Visibility
+	secret.SelectedRepositoryIDs = req.SelectedRepositoryIDs
+	resp, err := client.CreateOrUpdateOrgSecret(ctx, org, secret)
+	if err != nil {
+		return false, err
+	}
+	return isCreated(resp), nil
+}
+
+// SetRepoVariable updates an Actions variable of a repository, or creates it if it does not exist.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - name: The name of the variable.
+//   - req: The value of the variable.
+//
+// Returns:
+//   - bool: True if the variable was created, false if it was updated.
+//   - error: An error if the variable cannot be stored.
+func SetRepoVariable(ctx context.Context, client interfaces.GitHubClient, owner, repo, name string, req VariableRequest) (bool, error) {
+	variable := &github.ActionsVariable{Name: name, Value: req.Value}
+	_, err := client.UpdateRepoVariable(ctx, owner, repo, variable)
+	if !isNotFound(err) {
+		return false, err
+	}
+	_, err = client.CreateRepoVariable(ctx, owner, repo, variable)
+	return err == nil, err
+}
+
+// SetOrgVariable updates an Actions variable of an organization, or creates it if it does not exist.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - org: The login of the organization.
+//   - name: The name of the variable.
+//   - req: The value, visibility and selected repositories of the variable.
+//
+// Returns:
+//   - bool: True if the variable was created, false if it was updated.
+//   - error: An error if the variable cannot be stored.
+func SetOrgVariable(ctx context.Context, client interfaces.GitHubClient, org, name string, req OrgVariableRequest) (bool, error) {
+	variable := &github.ActionsVariable{Name: name, Value: req.Value, Visibility: github.String(req.Visibility)}
+	if req.SelectedRepositoryIDs != nil {
+		ids := github.SelectedRepoIDs(req.SelectedRepositoryIDs)
+		variable.SelectedRepositoryIDs = &ids
+	}
+	_, err := client.UpdateOrgVariable(ctx, org, variable)
+	if !isNotFound(err) {
+		return false, err
+	}
+	_, err = client.CreateOrgVariable(ctx, org, variable)
+	return err == nil, err
+}
+
+// isCreated reports whether GitHub answered 201 Created rather than 204 No Content.
+func isCreated(resp *github.Response) bool {
+	return resp != nil && resp.Response != nil && resp.StatusCode == http.StatusCreated
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github-api/pkg/mocks"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// publicKey generates a key pair and returns the public key as GitHub serves it, and the private key.
+func publicKey(t *testing.T) (*github.PublicKey, *[32]byte) {
+	public, private, err := box.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &github.PublicKey{KeyID: github.String("568250167242549743"), Key: github.String(base64.StdEncoding.EncodeToString(public[:]))}, private
+}
+
+// TestEncryptSecret tests that the secret can only be opened with the private key.
+func TestEncryptSecret(t *testing.T) {
+	key, private := publicKey(t)
+	secret, err := EncryptSecret(key, "DEPLOY_TOKEN", "s3cr3t")
+	require.NoError(t, err)
+	assert.Equal(t, "DEPLOY_TOKEN", secret.Name)
+	assert.Equal(t, "568250167242549743", secret.KeyID)
+
+	sealed, err := base64.StdEncoding.DecodeString(secret.EncryptedValue)
+	require.NoError(t, err)
+	public, _ := base64.StdEncoding.DecodeString(key.GetKey())
+	var recipient [32]byte
+	copy(recipient[:], public)
+	opened, ok := box.OpenAnonymous(nil, sealed, &recipient, private)
+	require.True(t, ok)
+	assert.Equal(t, "s3cr3t", string(opened))
+
+	_, err = EncryptSecret(&github.PublicKey{Key: github.String("not base64!")}, "DEPLOY_TOKEN", "s3cr3t")
+	assert.Error(t, err)
+	_, err = EncryptSecret(&github.PublicKey{Key: github.String(base64.StdEncoding.EncodeToString([]byte("short")))}, "DEPLOY_TOKEN", "s3cr3t")
+	assert.Error(t, err)
+}
+
+// TestSetRepoSecret tests that only the encrypted value is sent and that creation is reported.
+func TestSetRepoSecret(t *testing.T) {
+	key, _ := publicKey(t)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRepoPublicKey", mock.Anything, "octocat", "hello").Return(key, &github.Response{}, nil)
+	mockClient.On("CreateOrUpdateRepoSecret", mock.Anything, "octocat", "hello", mock.MatchedBy(func(s *github.EncryptedSecret) bool {
+		return s.Name == "DEPLOY_TOKEN" && s.KeyID == key.GetKeyID() && !strings.Contains(s.EncryptedValue, "s3cr3t")
+	})).Return(&github.Response{Response: &http.Response{StatusCode: http.StatusCreated}}, nil).Once()
+	mockClient.On("CreateOrUpdateRepoSecret", mock.Anything, "octocat", "hello", mock.Anything).
+		Return(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil).Once()
+
+	created, err := SetRepoSecret(context.Background(), mockClient, "octocat", "hello", "DEPLOY_TOKEN", SecretRequest{Value: "s3cr3t"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = SetRepoSecret(context.Background(), mockClient, "octocat", "hello", "DEPLOY_TOKEN", SecretRequest{Value: "s3cr3t"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	mockClient.AssertExpectations(t)
+}
+
+// TestSetOrgSecret tests that the visibility and selected repositories are sent with the secret.
+func TestSetOrgSecret(t *testing.T) {
+	key, _ := publicKey(t)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetOrgPublicKey", mock.Anything, "octo-org").Return(key, &github.Response{}, nil)
+	mockClient.On("CreateOrUpdateOrgSecret", mock.Anything, "octo-org", mock.MatchedBy(func(s *github.EncryptedSecret) bool {
+		return s.Visibility == "selected" && len(s.SelectedRepositoryIDs) == 2
+	})).Return(&github.Response{Response: &http.Response{StatusCode: http.StatusNoContent}}, nil)
+
+	req := OrgSecretRequest{Value: "s3cr3t", Visibility: "selected", SelectedRepositoryIDs: []int64{1296269, 1296270}}
+	created, err := SetOrgSecret(context.Background(), mockClient, "octo-org", "DEPLOY_TOKEN", req)
+	require.NoError(t, err)
+	assert.False(t, created)
+	mockClient.AssertExpectations(t)
+}
+
+// TestSetRepoVariable tests that a variable is updated, or created if it does not exist.
+func TestSetRepoVariable(t *testing.T) {
+	variable := &github.ActionsVariable{Name: "REGION", Value: "eu-west-1"}
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("UpdateRepoVariable", mock.Anything, "octocat", "hello", variable).Return(&github.Response{}, nil)
+	mockClient.On("UpdateRepoVariable", mock.Anything, "octocat", "new", variable).Return(&github.Response{}, notFound)
+	mockClient.On("CreateRepoVariable", mock.Anything, "octocat", "new", variable).Return(&github.Response{}, nil)
+
+	created, err := SetRepoVariable(context.Background(), mockClient, "octocat", "hello", "REGION", VariableRequest{Value: "eu-west-1"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	created, err = SetRepoVariable(context.Background(), mockClient, "octocat", "new", "REGION", VariableRequest{Value: "eu-west-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	mockClient.AssertNotCalled(t, "CreateRepoVariable", mock.Anything, "octocat", "hello", mock.Anything)
+}
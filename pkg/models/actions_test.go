@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestListWorkflowRuns tests that the runs of a workflow or of the repository are listed with the filter.
+func TestListWorkflowRuns(t *testing.T) {
+	page := github.ListOptions{Page: 2}
+	filter := WorkflowRunFilter{Branch: "main", Status: "failure"}
+	opts := &github.ListWorkflowRunsOptions{Branch: "main", Status: "failure", ListOptions: page}
+	runs := &github.WorkflowRuns{WorkflowRuns: []*github.WorkflowRun{{ID: github.Int64(30433642)}}}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListRepositoryWorkflowRuns", mock.Anything, "octocat", "hello", opts).Return(runs, &github.Response{}, nil)
+	mockClient.On("ListWorkflowRunsByFileName", mock.Anything, "octocat", "hello", "ci.yml", opts).Return(runs, &github.Response{}, nil)
+
+	listed, _, err := ListWorkflowRuns(context.Background(), mockClient, "octocat", "hello", filter, page)
+	require.NoError(t, err)
+	assert.Equal(t, runs.WorkflowRuns, listed)
+
+	filter.Workflow = "ci.yml"
+	_, _, err = ListWorkflowRuns(context.Background(), mockClient, "octocat", "hello", filter, page)
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+// TestDispatchWorkflow tests that a workflow runs on the default branch unless a ref is given.
+func TestDispatchWorkflow(t *testing.T) {
+	repo := repoAt("octocat", "hello")
+	repo.DefaultBranch = github.String("trunk")
+	inputs := map[string]interface{}{"environment": "staging"}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "hello").Return(repo, &github.Response{}, nil)
+	mockClient.On("CreateWorkflowDispatchEventByFileName", mock.Anything, "octocat", "hello", "deploy.yml",
+		github.CreateWorkflowDispatchEventRequest{Ref: "trunk", Inputs: inputs}).Return(&github.Response{}, nil).Once()
+	mockClient.On("CreateWorkflowDispatchEventByFileName", mock.Anything, "octocat", "hello", "deploy.yml",
+		github.CreateWorkflowDispatchEventRequest{Ref: "v1.2.0"}).Return(&github.Response{}, nil).Once()
+
+	ref, err := DispatchWorkflow(context.Background(), mockClient, "octocat", "hello", "deploy.yml", DispatchRequest{Inputs: inputs})
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", ref)
+	ref, err = DispatchWorkflow(context.Background(), mockClient, "octocat", "hello", "deploy.yml", DispatchRequest{Ref: "v1.2.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", ref)
+	mockClient.AssertNumberOfCalls(t, "GetRepositories", 1)
+	mockClient.AssertExpectations(t)
+}
+
+// TestRerunWorkflowRun tests that all jobs, or only the failed ones, are re-run.
+func TestRerunWorkflowRun(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("RerunWorkflowByID", mock.Anything, "octocat", "hello", int64(1)).Return(&github.Response{}, nil)
+	mockClient.On("RerunFailedJobsByID", mock.Anything, "octocat", "hello", int64(2)).Return(&github.Response{}, errors.New("forbidden"))
+
+	assert.NoError(t, RerunWorkflowRun(context.Background(), mockClient, "octocat", "hello", 1, RerunRequest{}))
+	assert.Error(t, RerunWorkflowRun(context.Background(), mockClient, "octocat", "hello", 2, RerunRequest{FailedOnly: true}))
+	mockClient.AssertExpectations(t)
+}
+
+// TestDownloadJobLogs tests that the logs are fetched from the link GitHub redirects to.
+func TestDownloadJobLogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs/399444496" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = io.WriteString(w, "2024-01-01T00:00:00Z ok\n")
+	}))
+	defer server.Close()
+	link, _ := url.Parse(server.URL + "/logs/399444496")
+	expired, _ := url.Parse(server.URL + "/logs/expired")
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetWorkflowJobLogs", mock.Anything, "octocat", "hello", int64(399444496)).Return(link, &github.Response{}, nil)
+	mockClient.On("GetWorkflowJobLogs", mock.Anything, "octocat", "hello", int64(1)).Return(expired, &github.Response{}, nil)
+
+	logs, err := DownloadJobLogs(context.Background(), mockClient, "octocat", "hello", 399444496)
+	require.NoError(t, err)
+	defer logs.Body.Close()
+	body, _ := io.ReadAll(logs.Body)
+	assert.Equal(t, "2024-01-01T00:00:00Z ok\n", string(body))
+
+	_, err = DownloadJobLogs(context.Background(), mockClient, "octocat", "hello", 1)
+	assert.ErrorContains(t, err, "downloading job logs: 404")
+}
+
+// TestDownloadArtifactExpired tests that an expired artifact is not downloaded.
+func TestDownloadArtifactExpired(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetArtifact", mock.Anything, "octocat", "hello", int64(11)).Return(&github.Artifact{
+		Name:      github.String("coverage"),
+		Expired:   github.Bool(true),
+		ExpiresAt: &github.Timestamp{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, &github.Response{}, nil)
+
+	_, _, err := DownloadArtifact(context.Background(), mockClient, "octocat", "hello", 11)
+	assert.ErrorIs(t, err, ErrArtifactExpired)
+	mockClient.AssertNotCalled(t, "DownloadArtifact", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
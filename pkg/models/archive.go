@@ -0,0 +1,356 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"path"
+	"strings"
+	"time"
+)
+
+// archiveConcurrency is the number of inactive repositories evaluated concurrently.
+const archiveConcurrency = 8
+
+// DefaultInactiveMonths is the number of months without push after which a repository is
+// an archive candidate, unless the policy sets another one.
+const DefaultInactiveMonths = 12
+
+// Reasons an inactive repository is exempt from archiving.
+const (
+	ArchiveAllowlisted      = "allowlisted"
+	ArchiveExemptTopic      = "exempt_topic"
+	ArchiveOpenPullRequests = "open_pull_requests"
+	ArchiveOpenIssues       = "open_issues"
+)
+
+// Actions of an archive request.
+const (
+	ArchiveAction   = "archive"
+	UnarchiveAction = "unarchive"
+)
+
+// Outcomes of the change of a repository by an archive request.
+const (
+	ArchivePlanned    = "planned"
+	ArchiveApplied    = "applied"
+	ArchiveSkipped    = "skipped"
+	ArchiveFailed     = "failed"
+	ArchiveRolledBack = "rolled_back"
+)
+
+// ArchivePolicy configures which repositories are archived. A repository is inactive when it has
+// not been pushed to for InactiveMonths months; it is then a candidate unless it matches the
+// Allowlist, carries one of the ExemptTopics, or has more open pull requests or issues than
+// allowed, none by default. Allowlist entries are "owner/name" patterns, such as "octo-org/infra-*".
+// Owners restricts the report to the repositories of some users or organizations, and forks are
+// only considered with IncludeForks.
+type ArchivePolicy struct {
+	InactiveMonths      int      `json:"inactive_months" binding:"min=0"`
+	Allowlist           []string `json:"allowlist"`
+	ExemptTopics        []string `json:"exempt_topics" binding:"dive,topic"`
+	MaxOpenPullRequests int      `json:"max_open_pull_requests" binding:"min=0"`
+	MaxOpenIssues       int      `json:"max_open_issues" binding:"min=0"`
+	Owners              []string `json:"owners" binding:"dive,login"`
+	IncludeForks        bool     `json:"include_forks"`
+}
+
+// Validate checks the allowlist patterns and fills in the defaults of the policy.
+//
+// Returns:
+//   - error: An error describing the first invalid pattern.
+func (p *ArchivePolicy) Validate() error {
+	if p.InactiveMonths == 0 {
+		p.InactiveMonths = DefaultInactiveMonths
+	}
+	for _, pattern := range p.Allowlist {
+		if _, err := path.Match(pattern, ""); err != nil || !strings.Contains(pattern, "/") {
+			return fmt.Errorf("invalid allowlist pattern %q: must be of the form owner/name", pattern)
+		}
+	}
+	return nil
+}
+
+// allowlisted reports whether a repository matches the allowlist, ignoring case.
+func (p ArchivePolicy) allowlisted(fullName string) bool {
+	for _, pattern := range p.Allowlist {
+		if ok, _ := path.Match(strings.ToLower(pattern), strings.ToLower(fullName)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// owned reports whether a repository belongs to one of the owners of the policy.
+func (p ArchivePolicy) owned(owner string) bool {
+	if len(p.Owners) == 0 {
+		return true
+	}
+	for _, o := range p.Owners {
+		if strings.EqualFold(o, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchiveCandidate is an inactive repository of the archive report.
+// Exemptions lists why an inactive repository is kept, and is empty for candidates.
+type ArchiveCandidate struct {
+	Repository       string    `json:"repository"`
+	URL              string    `json:"url"`
+	PushedAt         time.Time `json:"pushed_at"`
+	InactiveDays     int       `json:"inactive_days"`
+	OpenPullRequests int       `json:"open_pull_requests"`
+	OpenIssues       int       `json:"open_issues"`
+	Topics           []string  `json:"topics,omitempty"`
+	Exemptions       []string  `json:"exemptions,omitempty"`
+}
+
+// ArchiveReport lists the inactive repositories an archive policy selects, those it exempts,
+// and the repositories that are already archived.
+type ArchiveReport struct {
+	Policy     ArchivePolicy      `json:"policy"`
+	Candidates []ArchiveCandidate `json:"candidates"`
+	Exempt     []ArchiveCandidate `json:"exempt"`
+	Archived   []string           `json:"archived"`
+	Errors     []RepositoryError  `json:"errors,omitempty"`
+}
+
+// FindArchiveCandidates evaluates the repositories the authenticated user administers against an
+// archive policy. Only the open pull requests of inactive repositories are counted.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - policy: The validated archive policy.
+//   - now: The time against which inactivity is measured.
+//
+// Returns:
+//   - ArchiveReport: The candidates and exempt repositories, with those that could not be evaluated listed in Errors.
+//   - error: An error if the repositories cannot be listed.
+func FindArchiveCandidates(ctx context.Context, client interfaces.GitHubClient, policy ArchivePolicy, now time.Time) (ArchiveReport, error) {
+	report := ArchiveReport{Policy: policy, Candidates: []ArchiveCandidate{}, Exempt: []ArchiveCandidate{}, Archived: []string{}}
+	repos, err := allPages(func(opt *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return client.ListRepos(ctx, "", &github.RepositoryListOptions{Affiliation: "owner,organization_member", ListOptions: *opt})
+	})
+	if err != nil {
+		return ArchiveReport{}, err
+	}
+
+	cutoff := now.AddDate(0, -policy.InactiveMonths, 0)
+	var inactive []*github.Repository
+	for _, repo := range repos {
+		if !repo.GetPermissions()["admin"] || !policy.owned(repo.GetOwner().GetLogin()) || (repo.GetFork() && !policy.IncludeForks) {
+			continue
+		}
+		if repo.GetArchived() {
+			report.Archived = append(report.Archived, repo.GetFullName())
+			continue
+		}
+		if lastPush(repo).Before(cutoff) {
+			inactive = append(inactive, repo)
+		}
+	}
+
+	evaluated := make([]*ArchiveCandidate, len(inactive))
+	errs := make([]error, len(inactive))
+	_ = forEachLimit(len(inactive), archiveConcurrency, func(i int) error {
+		evaluated[i], errs[i] = evaluateArchiveCandidate(ctx, client, inactive[i], policy, now)
+		return nil
+	})
+	for i, candidate := range evaluated {
+		switch {
+		case errs[i] != nil:
+			report.Errors = append(report.Errors, RepositoryError{Repository: inactive[i].GetFullName(), Error: errs[i].Error()})
+		case len(candidate.Exemptions) > 0:
+			report.Exempt = append(report.Exempt, *candidate)
+		default:
+			report.Candidates = append(report.Candidates, *candidate)
+		}
+	}
+	return report, nil
+}
+
+// evaluateArchiveCandidate counts the open pull requests and issues of an inactive repository
+// and lists the exemptions the policy grants it.
+func evaluateArchiveCandidate(ctx context.Context, client interfaces.GitHubClient, repo *github.Repository, policy ArchivePolicy, now time.Time) (*ArchiveCandidate, error) {
+	pushed := lastPush(repo)
+	candidate := &ArchiveCandidate{
+		Repository:   repo.GetFullName(),
+		URL:          repo.GetHTMLURL(),
+		PushedAt:     pushed,
+		InactiveDays: int(now.Sub(pushed).Hours() / 24),
+		Topics:       repo.Topics,
+	}
+	if policy.allowlisted(repo.GetFullName()) {
+		candidate.Exemptions = append(candidate.Exemptions, ArchiveAllowlisted)
+	}
+	for _, topic := range repo.Topics {
+		if containsFold(policy.ExemptTopics, topic) {
+			candidate.Exemptions = append(candidate.Exemptions, ArchiveExemptTopic)
+			break
+		}
+	}
+
+	pulls, err := countOpenPullRequests(ctx, client, repo.GetOwner().GetLogin(), repo.GetName())
+	if err != nil {
+		return nil, err
+	}
+	// The open issues count of a repository includes its open pull requests.
+	candidate.OpenPullRequests = pulls
+	candidate.OpenIssues = max(repo.GetOpenIssuesCount()-pulls, 0)
+	if candidate.OpenPullRequests > policy.MaxOpenPullRequests {
+		candidate.Exemptions = append(candidate.Exemptions, ArchiveOpenPullRequests)
+	}
+	if candidate.OpenIssues > policy.MaxOpenIssues {
+		candidate.Exemptions = append(candidate.Exemptions, ArchiveOpenIssues)
+	}
+	return candidate, nil
+}
+
+// countOpenPullRequests counts the open pull requests of a repository from the number of pages of one pull request.
+func countOpenPullRequests(ctx context.Context, client interfaces.GitHubClient, owner, repo string) (int, error) {
+	pulls, resp, err := client.ListPullRequests(ctx, owner, repo, &github.PullRequestListOptions{State: "open", ListOptions: github.ListOptions{PerPage: 1}})
+	if err != nil {
+		return 0, err
+	}
+	if resp != nil && resp.LastPage > 0 {
+		return resp.LastPage, nil
+	}
+	return len(pulls), nil
+}
+
+// lastPush returns the time of the last push to a repository, or its creation time if it was never pushed to.
+func lastPush(repo *github.Repository) time.Time {
+	if repo.PushedAt != nil {
+		return repo.GetPushedAt().Time
+	}
+	return repo.GetCreatedAt().Time
+}
+
+// containsFold reports whether one of the values equals s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ArchiveRequest is the payload accepted when archiving or unarchiving selected repositories.
+// When Policy is set, repositories to archive that are no longer candidates are skipped. With
+// DryRun, the changes are only planned. With Rollback, the first failure stops the request and
+// reverts the repositories already changed, so that the request is applied entirely or not at all.
+type ArchiveRequest struct {
+	Repos    []string       `json:"repos" binding:"required,min=1,unique,dive,fullname"`
+	Action   string         `json:"action" binding:"required,oneof=archive unarchive"`
+	Policy   *ArchivePolicy `json:"policy"`
+	DryRun   bool           `json:"dry_run"`
+	Rollback bool           `json:"rollback"`
+}
+
+// ArchiveChange records the change of a repository by an archive request.
+type ArchiveChange struct {
+	Repository string   `json:"repository"`
+	Action     string   `json:"action"`
+	Status     string   `json:"status"`
+	Reasons    []string `json:"reasons,omitempty"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// ArchiveResult lists the changes of an archive request. RolledBack is set when a failure
+// reverted the changes already applied.
+type ArchiveResult struct {
+	DryRun     bool            `json:"dry_run"`
+	RolledBack bool            `json:"rolled_back"`
+	Changes    []ArchiveChange `json:"changes"`
+}
+
+// Apply archives or unarchives the requested repositories in order. Repositories already in the
+// requested state are skipped, as are, with a policy, repositories to archive that it exempts or
+// that are no longer inactive. A failed change is recorded and the next repository is changed,
+// unless Rollback is set, in which case the applied changes are reverted in reverse order.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - now: The time against which inactivity is measured.
+//
+// Returns:
+//   - ArchiveResult: The change of each repository processed.
+func (r ArchiveRequest) Apply(ctx context.Context, client interfaces.GitHubClient, now time.Time) ArchiveResult {
+	result := ArchiveResult{DryRun: r.DryRun, Changes: []ArchiveChange{}}
+	archive := r.Action == ArchiveAction
+	var applied []int
+	for _, fullName := range r.Repos {
+		change := ArchiveChange{Repository: fullName, Action: r.Action, Status: ArchivePlanned}
+		reasons, err := r.check(ctx, client, fullName, now)
+		switch {
+		case err != nil:
+			change.Status, change.Error = ArchiveFailed, err.Error()
+		case len(reasons) > 0:
+			change.Status, change.Reasons = ArchiveSkipped, reasons
+		case !r.DryRun:
+			if err := setArchived(ctx, client, fullName, archive); err != nil {
+				change.Status, change.Error = ArchiveFailed, err.Error()
+			} else {
+				change.Status = ArchiveApplied
+				applied = append(applied, len(result.Changes))
+			}
+		}
+		result.Changes = append(result.Changes, change)
+		if change.Status == ArchiveFailed && r.Rollback {
+			result.RolledBack = true
+			for i := len(applied) - 1; i >= 0; i-- {
+				reverted := &result.Changes[applied[i]]
+				if err := setArchived(ctx, client, reverted.Repository, !archive); err != nil {
+					reverted.Error = "rollback failed: " + err.Error()
+					continue
+				}
+				reverted.Status = ArchiveRolledBack
+			}
+			break
+		}
+	}
+	return result
+}
+
+// check returns why a repository is skipped, or nil if it is to be changed.
+func (r ArchiveRequest) check(ctx context.Context, client interfaces.GitHubClient, fullName string, now time.Time) ([]string, error) {
+	owner, name, err := SplitFullName(fullName)
+	if err != nil {
+		return nil, err
+	}
+	repo, _, err := client.GetRepositories(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	archive := r.Action == ArchiveAction
+	if repo.GetArchived() == archive {
+		return []string{"already " + r.Action + "d"}, nil
+	}
+	if !archive || r.Policy == nil {
+		return nil, nil
+	}
+	if !lastPush(repo).Before(now.AddDate(0, -r.Policy.InactiveMonths, 0)) {
+		return []string{"active"}, nil
+	}
+	candidate, err := evaluateArchiveCandidate(ctx, client, repo, *r.Policy, now)
+	if err != nil {
+		return nil, err
+	}
+	return candidate.Exemptions, nil
+}
+
+// setArchived archives or unarchives a repository.
+func setArchived(ctx context.Context, client interfaces.GitHubClient, fullName string, archived bool) error {
+	owner, name, err := SplitFullName(fullName)
+	if err != nil {
+		return err
+	}
+	_, _, err = client.EditRepository(ctx, owner, name, &github.Repository{Archived: github.Bool(archived)})
+	return err
+}
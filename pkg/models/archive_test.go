@@ -0,0 +1,131 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// pushedRepo returns a repository administered by the user, last pushed to at the given time.
+func pushedRepo(fullName string, pushed time.Time, openIssues int, topics ...string) *github.Repository {
+	owner, name, _ := SplitFullName(fullName)
+	repo := repoAt(owner, name)
+	repo.FullName = github.String(fullName)
+	repo.PushedAt = &github.Timestamp{Time: pushed}
+	repo.OpenIssuesCount = github.Int(openIssues)
+	repo.Topics = topics
+	repo.Permissions = map[string]bool{"admin": true}
+	return repo
+}
+
+// TestArchivePolicyValidate tests the defaults and the allowlist patterns of the policy.
+func TestArchivePolicyValidate(t *testing.T) {
+	policy := ArchivePolicy{Allowlist: []string{"octo-org/infra-*"}}
+	require.NoError(t, policy.Validate())
+	assert.Equal(t, DefaultInactiveMonths, policy.InactiveMonths)
+	assert.True(t, policy.allowlisted("Octo-Org/infra-terraform"))
+	assert.False(t, policy.allowlisted("octo-org/web"))
+
+	assert.Error(t, (&ArchivePolicy{Allowlist: []string{"infra"}}).Validate())
+	assert.Error(t, (&ArchivePolicy{Allowlist: []string{"octo-org/[a"}}).Validate())
+}
+
+// TestFindArchiveCandidates tests that inactive repositories are reported as candidates or exempt.
+func TestFindArchiveCandidates(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	old := now.AddDate(-2, 0, 0)
+	archived := pushedRepo("octo-org/legacy", old, 0)
+	archived.Archived = github.Bool(true)
+	readOnly := pushedRepo("other/tool", old, 0)
+	readOnly.Permissions = map[string]bool{"pull": true}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListRepos", mock.Anything, "", mock.Anything).Return([]*github.Repository{
+		pushedRepo("octo-org/active", now.AddDate(0, -1, 0), 0),
+		pushedRepo("octo-org/dormant", old, 0),
+		pushedRepo("octo-org/infra-dns", old, 0),
+		pushedRepo("octo-org/docs", old, 0, "keep"),
+		pushedRepo("octo-org/busy", old, 5),
+		archived,
+		readOnly,
+	}, &github.Response{}, nil)
+	for _, name := range []string{"dormant", "infra-dns", "docs"} {
+		mockClient.On("ListPullRequests", mock.Anything, "octo-org", name, mock.Anything).Return([]*github.PullRequest{}, &github.Response{}, nil)
+	}
+	mockClient.On("ListPullRequests", mock.Anything, "octo-org", "busy", mock.Anything).
+		Return([]*github.PullRequest{{}}, &github.Response{LastPage: 3}, nil)
+
+	policy := ArchivePolicy{Allowlist: []string{"octo-org/infra-*"}, ExemptTopics: []string{"keep"}, MaxOpenIssues: 1}
+	require.NoError(t, policy.Validate())
+	report, err := FindArchiveCandidates(context.Background(), mockClient, policy, now)
+	require.NoError(t, err)
+
+	require.Len(t, report.Candidates, 1)
+	assert.Equal(t, "octo-org/dormant", report.Candidates[0].Repository)
+	assert.Equal(t, 731, report.Candidates[0].InactiveDays)
+	exemptions := map[string][]string{}
+	for _, exempt := range report.Exempt {
+		exemptions[exempt.Repository] = exempt.Exemptions
+	}
+	assert.Equal(t, map[string][]string{
+		"octo-org/infra-dns": {ArchiveAllowlisted},
+		"octo-org/docs":      {ArchiveExemptTopic},
+		"octo-org/busy":      {ArchiveOpenPullRequests, ArchiveOpenIssues},
+	}, exemptions)
+	assert.Equal(t, []string{"octo-org/legacy"}, report.Archived)
+}
+
+// TestArchiveRequestRollback tests that a failure reverts the repositories already archived.
+func TestArchiveRequestRollback(t *testing.T) {
+	now := time.Now()
+	mockClient := new(mocks.MockGitHubClient)
+	archived := pushedRepo("octocat/archived", now, 0)
+	archived.Archived = github.Bool(true)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "first").Return(pushedRepo("octocat/first", now, 0), &github.Response{}, nil)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "archived").Return(archived, &github.Response{}, nil)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "second").Return(pushedRepo("octocat/second", now, 0), &github.Response{}, nil)
+	mockClient.On("EditRepository", mock.Anything, "octocat", "first", &github.Repository{Archived: github.Bool(true)}).
+		Return(&github.Repository{}, &github.Response{}, nil).Once()
+	mockClient.On("EditRepository", mock.Anything, "octocat", "second", &github.Repository{Archived: github.Bool(true)}).
+		Return((*github.Repository)(nil), &github.Response{}, errors.New("forbidden")).Once()
+	mockClient.On("EditRepository", mock.Anything, "octocat", "first", &github.Repository{Archived: github.Bool(false)}).
+		Return(&github.Repository{}, &github.Response{}, nil).Once()
+
+	req := ArchiveRequest{Repos: []string{"octocat/first", "octocat/archived", "octocat/second", "octocat/third"}, Action: ArchiveAction, Rollback: true}
+	result := req.Apply(context.Background(), mockClient, now)
+
+	assert.True(t, result.RolledBack)
+	require.Len(t, result.Changes, 3)
+	assert.Equal(t, ArchiveRolledBack, result.Changes[0].Status)
+	assert.Equal(t, ArchiveSkipped, result.Changes[1].Status)
+	assert.Equal(t, []string{"already archived"}, result.Changes[1].Reasons)
+	assert.Equal(t, ArchiveFailed, result.Changes[2].Status)
+	mockClient.AssertExpectations(t)
+}
+
+// TestArchiveRequestDryRunWithPolicy tests that a dry run plans the changes and skips active repositories.
+func TestArchiveRequestDryRunWithPolicy(t *testing.T) {
+	now := time.Now()
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "dormant").Return(pushedRepo("octocat/dormant", now.AddDate(-1, -1, 0), 0), &github.Response{}, nil)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "active").Return(pushedRepo("octocat/active", now, 0), &github.Response{}, nil)
+	mockClient.On("ListPullRequests", mock.Anything, "octocat", "dormant", mock.Anything).Return([]*github.PullRequest{}, &github.Response{}, nil)
+
+	policy := ArchivePolicy{}
+	require.NoError(t, policy.Validate())
+	req := ArchiveRequest{Repos: []string{"octocat/dormant", "octocat/active"}, Action: ArchiveAction, Policy: &policy, DryRun: true}
+	result := req.Apply(context.Background(), mockClient, now)
+
+	require.Len(t, result.Changes, 2)
+	assert.Equal(t, ArchivePlanned, result.Changes[0].Status)
+	assert.Equal(t, ArchiveSkipped, result.Changes[1].Status)
+	assert.Equal(t, []string{"active"}, result.Changes[1].Reasons)
+	mockClient.AssertNotCalled(t, "EditRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
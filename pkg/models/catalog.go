@@ -0,0 +1,215 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// propertyPrefix marks a catalog filter on a custom property whose name is also a catalog field.
+const propertyPrefix = "property."
+
+// TopicsRequest is the payload accepted when replacing the topics of a repository; an empty
+// list removes all topics.
+type TopicsRequest struct {
+	Topics []string `json:"topics" binding:"required,max=20,unique,dive,topic"`
+}
+
+// PropertiesRequest is the payload accepted when writing the custom property values of a
+// repository. Each value is a string, a list of strings for a multi-select property, or null to
+// unset the property; properties not listed keep their value.
+type PropertiesRequest struct {
+	Properties map[string]interface{} `json:"properties" binding:"required,min=1"`
+}
+
+// Values returns the property values to write, sorted by property name.
+//
+// Returns:
+//   - []*interfaces.CustomPropertyValue: The property values.
+//   - error: An error if a value is neither a string, a list of strings nor null.
+func (r PropertiesRequest) Values() ([]*interfaces.CustomPropertyValue, error) {
+	values := make([]*interfaces.CustomPropertyValue, 0, len(r.Properties))
+	for name, value := range r.Properties {
+		switch v := value.(type) {
+		case nil, string:
+		case []interface{}:
+			for _, item := range v {
+				if _, ok := item.(string); !ok {
+					return nil, fmt.Errorf("invalid value of property %q: must be a list of strings", name)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid value of property %q: must be a string, a list of strings or null", name)
+		}
+		values = append(values, &interfaces.CustomPropertyValue{PropertyName: name, Value: value})
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i].PropertyName < values[j].PropertyName })
+	return values, nil
+}
+
+// PropertyMap returns custom property values by property name, leaving out the unset ones.
+//
+// Parameters:
+//   - values: The custom property values of a repository.
+//
+// Returns:
+//   - map[string]interface{}: The set values by property name.
+func PropertyMap(values []*interfaces.CustomPropertyValue) map[string]interface{} {
+	properties := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		if value.Value != nil {
+			properties[value.PropertyName] = value.Value
+		}
+	}
+	return properties
+}
+
+// CatalogEntry is a repository of the metadata catalog, with its topics and custom properties.
+type CatalogEntry struct {
+	Repository    string                 `json:"repository"`
+	Description   string                 `json:"description"`
+	URL           string                 `json:"url"`
+	Visibility    string                 `json:"visibility"`
+	Language      string                 `json:"language"`
+	DefaultBranch string                 `json:"default_branch"`
+	Archived      bool                   `json:"archived"`
+	Fork          bool                   `json:"fork"`
+	PushedAt      time.Time              `json:"pushed_at"`
+	Topics        []string               `json:"topics"`
+	Properties    map[string]interface{} `json:"properties"`
+}
+
+// values returns the values of a field or custom property of the entry, as strings.
+// The topic, language, visibility, archived and fork keys select fields; any other key
+// selects a custom property, as does a key prefixed with "property.".
+func (e CatalogEntry) values(key string) []string {
+	switch key {
+	case "topic":
+		return e.Topics
+	case "language":
+		return []string{e.Language}
+	case "visibility":
+		return []string{e.Visibility}
+	case "archived":
+		return []string{strconv.FormatBool(e.Archived)}
+	case "fork":
+		return []string{strconv.FormatBool(e.Fork)}
+	}
+	switch v := e.Properties[strings.TrimPrefix(key, propertyPrefix)].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
+
+// CatalogFilter selects catalog entries by field or custom property. An entry matches when, for
+// every key, one of its values equals one of the accepted values, ignoring case.
+type CatalogFilter map[string][]string
+
+// Match reports whether an entry satisfies the filter.
+//
+// Parameters:
+//   - entry: The catalog entry.
+//
+// Returns:
+//   - bool: True if the entry matches every key of the filter.
+func (f CatalogFilter) Match(entry CatalogEntry) bool {
+	for key, accepted := range f {
+		matched := false
+		for _, value := range entry.values(key) {
+			if containsFold(accepted, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// BuildCatalog joins the metadata of the repositories of a user or organization: their
+// settings, topics and, for an organization, custom property values.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The login of the user or organization.
+//   - filter: The filter the returned entries match.
+//
+// Returns:
+//   - []CatalogEntry: The matching repositories, sorted by full name.
+//   - error: An error if the owner, its repositories or their properties cannot be retrieved.
+func BuildCatalog(ctx context.Context, client interfaces.GitHubClient, owner string, filter CatalogFilter) ([]CatalogEntry, error) {
+	account, _, err := client.GetUser(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	var repos []*github.Repository
+	properties := map[string]map[string]interface{}{}
+	if account.GetType() == "Organization" {
+		repos, err = allPages(func(opt *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return client.ListOrgRepos(ctx, owner, &github.RepositoryListByOrgOptions{Type: "all", ListOptions: *opt})
+		})
+		if err != nil {
+			return nil, err
+		}
+		values, err := allPages(func(opt *github.ListOptions) ([]*interfaces.RepoCustomPropertyValue, *github.Response, error) {
+			return client.ListCustomPropertyValues(ctx, owner, opt)
+		})
+		// Organizations without custom properties, e.g. on older GitHub Enterprise versions, have none.
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		for _, repo := range values {
+			properties[strings.ToLower(repo.RepositoryFullName)] = PropertyMap(repo.Properties)
+		}
+	} else {
+		repos, err = allPages(func(opt *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return client.ListRepos(ctx, owner, &github.RepositoryListOptions{Type: "owner", ListOptions: *opt})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	catalog := []CatalogEntry{}
+	for _, repo := range repos {
+		entry := CatalogEntry{
+			Repository:    repo.GetFullName(),
+			Description:   repo.GetDescription(),
+			URL:           repo.GetHTMLURL(),
+			Visibility:    repo.GetVisibility(),
+			Language:      repo.GetLanguage(),
+			DefaultBranch: repo.GetDefaultBranch(),
+			Archived:      repo.GetArchived(),
+			Fork:          repo.GetFork(),
+			PushedAt:      repo.GetPushedAt().Time,
+			Topics:        repo.Topics,
+			Properties:    properties[strings.ToLower(repo.GetFullName())],
+		}
+		if entry.Topics == nil {
+			entry.Topics = []string{}
+		}
+		if entry.Properties == nil {
+			entry.Properties = map[string]interface{}{}
+		}
+		if filter.Match(entry) {
+			catalog = append(catalog, entry)
+		}
+	}
+	sort.Slice(catalog, func(i, j int) bool { return catalog[i].Repository < catalog[j].Repository })
+	return catalog, nil
+}
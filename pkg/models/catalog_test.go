@@ -0,0 +1,125 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/mocks"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPropertiesRequestValues tests that property values are sorted and checked for their type.
+func TestPropertiesRequestValues(t *testing.T) {
+	req := PropertiesRequest{Properties: map[string]interface{}{
+		"team":      "platform",
+		"languages": []interface{}{"go", "rust"},
+		"tier":      nil,
+	}}
+	values, err := req.Values()
+	require.NoError(t, err)
+	assert.Equal(t, []*interfaces.CustomPropertyValue{
+		{PropertyName: "languages", Value: []interface{}{"go", "rust"}},
+		{PropertyName: "team", Value: "platform"},
+		{PropertyName: "tier", Value: nil},
+	}, values)
+
+	_, err = PropertiesRequest{Properties: map[string]interface{}{"tier": 1.0}}.Values()
+	assert.Error(t, err)
+	_, err = PropertiesRequest{Properties: map[string]interface{}{"languages": []interface{}{"go", 1.0}}}.Values()
+	assert.Error(t, err)
+}
+
+// TestCatalogFilterMatch tests filtering on fields and custom properties, ignoring case.
+func TestCatalogFilterMatch(t *testing.T) {
+	entry := CatalogEntry{
+		Language: "Go",
+		Topics:   []string{"api", "tooling"},
+		Properties: map[string]interface{}{
+			"team":     "Platform",
+			"language": []interface{}{"go", "sql"},
+		},
+	}
+
+	assert.True(t, CatalogFilter{}.Match(entry))
+	assert.True(t, CatalogFilter{"language": {"go"}, "team": {"backend", "platform"}}.Match(entry))
+	assert.True(t, CatalogFilter{"topic": {"tooling"}, "archived": {"false"}}.Match(entry))
+	assert.True(t, CatalogFilter{"property.language": {"SQL"}}.Match(entry))
+	assert.False(t, CatalogFilter{"language": {"sql"}}.Match(entry))
+	assert.False(t, CatalogFilter{"team": {"platform"}, "topic": {"web"}}.Match(entry))
+	assert.False(t, CatalogFilter{"owner": {"platform"}}.Match(entry))
+}
+
+// TestBuildCatalogOrganization tests that the repositories of an organization are joined with their properties.
+func TestBuildCatalogOrganization(t *testing.T) {
+	api := repoAt("octo-org", "api")
+	api.FullName = github.String("octo-org/api")
+	api.Topics = []string{"api"}
+	web := repoAt("octo-org", "web")
+	web.FullName = github.String("octo-org/web")
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetUser", mock.Anything, "octo-org").Return(&github.User{Type: github.String("Organization")}, &github.Response{}, nil)
+	mockClient.On("ListOrgRepos", mock.Anything, "octo-org", mock.Anything).Return([]*github.Repository{web, api}, &github.Response{}, nil)
+	mockClient.On("ListCustomPropertyValues", mock.Anything, "octo-org", mock.Anything).Return([]*interfaces.RepoCustomPropertyValue{
+		{RepositoryFullName: "octo-org/api", Properties: []*interfaces.CustomPropertyValue{
+			{PropertyName: "team", Value: "platform"},
+			{PropertyName: "tier", Value: nil},
+		}},
+	}, &github.Response{}, nil)
+
+	catalog, err := BuildCatalog(context.Background(), mockClient, "octo-org", CatalogFilter{})
+	require.NoError(t, err)
+	require.Len(t, catalog, 2)
+	assert.Equal(t, "octo-org/api", catalog[0].Repository)
+	assert.Equal(t, []string{"api"}, catalog[0].Topics)
+	assert.Equal(t, map[string]interface{}{"team": "platform"}, catalog[0].Properties)
+	assert.Equal(t, []string{}, catalog[1].Topics)
+	assert.Empty(t, catalog[1].Properties)
+
+	catalog, err = BuildCatalog(context.Background(), mockClient, "octo-org", CatalogFilter{"team": {"platform"}})
+	require.NoError(t, err)
+	require.Len(t, catalog, 1)
+	assert.Equal(t, "octo-org/api", catalog[0].Repository)
+}
+
+// TestBuildCatalogWithoutProperties tests that a user, or an organization without custom properties, has none.
+func TestBuildCatalogWithoutProperties(t *testing.T) {
+	tool := repoAt("octocat", "tool")
+	tool.FullName = github.String("octocat/tool")
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetUser", mock.Anything, "octocat").Return(&github.User{Type: github.String("User")}, &github.Response{}, nil)
+	mockClient.On("ListRepos", mock.Anything, "octocat", mock.Anything).Return([]*github.Repository{tool}, &github.Response{}, nil)
+	mockClient.On("GetUser", mock.Anything, "octo-org").Return(&github.User{Type: github.String("Organization")}, &github.Response{}, nil)
+	mockClient.On("ListOrgRepos", mock.Anything, "octo-org", mock.Anything).Return([]*github.Repository{}, &github.Response{}, nil)
+	mockClient.On("ListCustomPropertyValues", mock.Anything, "octo-org", mock.Anything).
+		Return([]*interfaces.RepoCustomPropertyValue(nil), &github.Response{}, notFound)
+
+	catalog, err := BuildCatalog(context.Background(), mockClient, "octocat", CatalogFilter{})
+	require.NoError(t, err)
+	require.Len(t, catalog, 1)
+	assert.Empty(t, catalog[0].Properties)
+	mockClient.AssertNotCalled(t, "ListCustomPropertyValues", mock.Anything, "octocat", mock.Anything)
+
+	catalog, err = BuildCatalog(context.Background(), mockClient, "octo-org", CatalogFilter{})
+	require.NoError(t, err)
+	assert.Empty(t, catalog)
+}
+
+// TestBuildCatalogPropertiesError tests that an error listing the properties other than 404 fails the catalog.
+func TestBuildCatalogPropertiesError(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetUser", mock.Anything, "octo-org").Return(&github.User{Type: github.String("Organization")}, &github.Response{}, nil)
+	mockClient.On("ListOrgRepos", mock.Anything, "octo-org", mock.Anything).Return([]*github.Repository{}, &github.Response{}, nil)
+	mockClient.On("ListCustomPropertyValues", mock.Anything, "octo-org", mock.Anything).
+		Return([]*interfaces.RepoCustomPropertyValue(nil), &github.Response{Response: &http.Response{StatusCode: http.StatusForbidden}}, errors.New("forbidden"))
+
+	_, err := BuildCatalog(context.Background(), mockClient, "octo-org", CatalogFilter{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,382 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/go-github/v50/github"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sources a changelog can be built from.
+const (
+	// ChangelogSourceAPI builds the changelog from the REST API, with one request per commit
+	// to find its pull request.
+	ChangelogSourceAPI = "api"
+	// ChangelogSourceGit builds the changelog from a local clone, reading pull request numbers
+	// from merge and squash commit messages.
+	ChangelogSourceGit = "git"
+)
+
+// changelogConcurrency bounds the concurrent requests linking commits to their pull requests.
+const changelogConcurrency = 8
+
+// ErrUnknownRevision is returned when a ref of a changelog range does not exist in a local clone.
+var ErrUnknownRevision = errors.New("unknown revision")
+
+var (
+	// mergePullPattern matches the subject of the merge commit of a pull request.
+	mergePullPattern = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+	// squashPullPattern matches the subject of a squash merge, which ends with the pull request number.
+	squashPullPattern = regexp.MustCompile(`\s*\(#(\d+)\)$`)
+)
+
+// ChangelogCommit is a commit listed in a changelog.
+// PullRequest is the number of the pull request that brought the commit in, or 0.
+type ChangelogCommit struct {
+	SHA         string    `json:"sha"`
+	Subject     string    `json:"subject"`
+	Author      string    `json:"author"`
+	Date        time.Time `json:"date"`
+	PullRequest int       `json:"pull_request,omitempty"`
+}
+
+// ChangelogPullRequest is a pull request listed in a changelog, with the number of its commits in the range.
+type ChangelogPullRequest struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Author  string `json:"author"`
+	URL     string `json:"url,omitempty"`
+	Commits int    `json:"commits"`
+}
+
+// Changelog lists the commits between two refs, newest first, and the pull requests they belong to.
+type Changelog struct {
+	Repository   string                 `json:"repository"`
+	Base         string                 `json:"base"`
+	Head         string                 `json:"head"`
+	Source       string                 `json:"source"`
+	Commits      []ChangelogCommit      `json:"commits"`
+	PullRequests []ChangelogPullRequest `json:"pull_requests"`
+}
+
+// BuildChangelog builds the changelog of a range from the REST API.
+// The pull request of each commit is looked up concurrently, so a range costs one request per
+// commit on top of the comparison; BuildChangelogFromClone avoids this for large ranges.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - base: The commit, branch or tag to compare from.
+//   - head: The commit, branch or tag to compare to.
+//
+// Returns:
+//   - Changelog: The changelog.
+//   - error: An error if the range cannot be compared or a pull request lookup fails.
+func BuildChangelog(ctx context.Context, client interfaces.GitHubClient, owner, repo, base, head string) (Changelog, error) {
+	var commits []*github.RepositoryCommit
+	page := &github.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := client.CompareCommits(ctx, owner, repo, base, head, page)
+		if err != nil {
+			return Changelog{}, err
+		}
+		commits = append(commits, comparison.Commits...)
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		page.Page = resp.NextPage
+	}
+
+	linked := make([]*github.PullRequest, len(commits))
+	err := forEachLimit(len(commits), changelogConcurrency, func(i int) error {
+		pulls, _, err := client.ListPullRequestsWithCommit(ctx, owner, repo, commits[i].GetSHA(), &github.PullRequestListOptions{State: "all"})
+		if err != nil {
+			return err
+		}
+		for _, pull := range pulls {
+			if pull.MergedAt != nil {
+				linked[i] = pull
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	log := newChangelog(owner, repo, base, head, ChangelogSourceAPI)
+	pulls := map[int]int{}
+	// Comparisons list commits oldest first.
+	for i := len(commits) - 1; i >= 0; i-- {
+		commit := commits[i]
+		author := commit.GetAuthor().GetLogin()
+		if author == "" {
+			author = commit.GetCommit().GetAuthor().GetName()
+		}
+		entry := ChangelogCommit{
+			SHA:     commit.GetSHA(),
+			Subject: subject(commit.GetCommit().GetMessage()),
+			Author:  author,
+			Date:    commit.GetCommit().GetAuthor().GetDate().Time,
+		}
+		if pull := linked[i]; pull != nil {
+			entry.PullRequest = pull.GetNumber()
+			log.addPullRequest(pulls, ChangelogPullRequest{
+				Number: pull.GetNumber(),
+				Title:  pull.GetTitle(),
+				Author: pull.GetUser().GetLogin(),
+				URL:    pull.GetHTMLURL(),
+			})
+		}
+		log.Commits = append(log.Commits, entry)
+	}
+	return log, nil
+}
+
+// BuildChangelogFromClone builds the changelog of a range from a local clone, without any API request.
+// The range holds the commits reachable from head but not from base. Pull requests are recognized
+// from the subjects GitHub gives merge commits ("Merge pull request #12 from ...") and squash merges
+// ("Title (#12)"); the commits a merge commit brings in are attributed to its pull request.
+//
+// Parameters:
+//   - r: The local clone of the repository.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - base: The commit, branch or tag to compare from.
+//   - head: The commit, branch or tag to compare to.
+//
+// Returns:
+//   - Changelog: The changelog.
+//   - error: An error wrapping ErrUnknownRevision if a ref does not exist, or an error reading the clone.
+func BuildChangelogFromClone(r *git.Repository, owner, repo, base, head string) (Changelog, error) {
+	baseHash, err := resolveRevision(r, base)
+	if err != nil {
+		return Changelog{}, err
+	}
+	headHash, err := resolveRevision(r, head)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	excluded := map[plumbing.Hash]bool{}
+	if err := walkCommits(r, baseHash, func(c *object.Commit) bool {
+		excluded[c.Hash] = true
+		return true
+	}); err != nil {
+		return Changelog{}, err
+	}
+	included := map[plumbing.Hash]*object.Commit{}
+	if err := walkCommits(r, headHash, func(c *object.Commit) bool {
+		if excluded[c.Hash] {
+			return false
+		}
+		included[c.Hash] = c
+		return true
+	}); err != nil {
+		return Changelog{}, err
+	}
+
+	commits := make([]*object.Commit, 0, len(included))
+	for _, c := range included {
+		commits = append(commits, c)
+	}
+	sort.Slice(commits, func(i, j int) bool {
+		if !commits[i].Committer.When.Equal(commits[j].Committer.When) {
+			return commits[i].Committer.When.After(commits[j].Committer.When)
+		}
+		return commits[i].Hash.String() < commits[j].Hash.String()
+	})
+
+	// The first-parent chain of head is the history of the branch itself; the other commits
+	// of the range were brought in by merges.
+	mainline := map[plumbing.Hash]bool{}
+	for c := included[headHash]; c != nil; {
+		mainline[c.Hash] = true
+		if c.NumParents() == 0 {
+			break
+		}
+		c = included[c.ParentHashes[0]]
+	}
+
+	pullOf := map[plumbing.Hash]int{}
+	found := map[int]ChangelogPullRequest{}
+	for _, c := range commits {
+		m := mergePullPattern.FindStringSubmatch(subject(c.Message))
+		if m == nil || c.NumParents() < 2 {
+			continue
+		}
+		number, _ := strconv.Atoi(m[1])
+		pullOf[c.Hash] = number
+		pull := ChangelogPullRequest{Number: number, Title: mergeTitle(c.Message), Author: c.Author.Name}
+		if tip, ok := included[c.ParentHashes[1]]; ok {
+			pull.Author = tip.Author.Name
+		}
+		found[number] = pull
+		for _, parent := range c.ParentHashes[1:] {
+			attribute(included, mainline, pullOf, parent, number)
+		}
+	}
+	for _, c := range commits {
+		s := subject(c.Message)
+		m := squashPullPattern.FindStringSubmatch(s)
+		if m == nil || pullOf[c.Hash] != 0 {
+			continue
+		}
+		number, _ := strconv.Atoi(m[1])
+		pullOf[c.Hash] = number
+		if _, ok := found[number]; !ok {
+			found[number] = ChangelogPullRequest{Number: number, Title: strings.TrimSuffix(s, m[0]), Author: c.Author.Name}
+		}
+	}
+
+	log := newChangelog(owner, repo, base, head, ChangelogSourceGit)
+	pulls := map[int]int{}
+	for _, c := range commits {
+		entry := ChangelogCommit{
+			SHA:         c.Hash.String(),
+			Subject:     subject(c.Message),
+			Author:      c.Author.Name,
+			Date:        c.Author.When,
+			PullRequest: pullOf[c.Hash],
+		}
+		if entry.PullRequest != 0 {
+			log.addPullRequest(pulls, found[entry.PullRequest])
+		}
+		log.Commits = append(log.Commits, entry)
+	}
+	return log, nil
+}
+
+// newChangelog creates an empty changelog.
+func newChangelog(owner, repo, base, head, source string) Changelog {
+	return Changelog{
+		Repository:   owner + "/" + repo,
+		Base:         base,
+		Head:         head,
+		Source:       source,
+		Commits:      []ChangelogCommit{},
+		PullRequests: []ChangelogPullRequest{},
+	}
+}
+
+// addPullRequest counts a commit of a pull request, listing the pull request on its first commit.
+// index maps the numbers of the listed pull requests to their position.
+func (c *Changelog) addPullRequest(index map[int]int, pull ChangelogPullRequest) {
+	if i, ok := index[pull.Number]; ok {
+		c.PullRequests[i].Commits++
+		return
+	}
+	pull.Commits = 1
+	index[pull.Number] = len(c.PullRequests)
+	c.PullRequests = append(c.PullRequests, pull)
+}
+
+// Markdown renders the changelog as Markdown: the pull requests first, then the commits that
+// do not belong to any pull request.
+//
+// Returns:
+//   - string: The rendered changelog.
+func (c Changelog) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "## %s %s...%s\n\n", c.Repository, c.Base, c.Head)
+	if len(c.PullRequests) > 0 {
+		b.WriteString("### Pull Requests\n\n")
+		for _, pull := range c.PullRequests {
+			fmt.Fprintf(&b, "- %s (#%d) by %s\n", pull.Title, pull.Number, pull.Author)
+		}
+		b.WriteString("\n")
+	}
+	var direct []ChangelogCommit
+	for _, commit := range c.Commits {
+		if commit.PullRequest == 0 {
+			direct = append(direct, commit)
+		}
+	}
+	if len(direct) > 0 {
+		b.WriteString("### Commits\n\n")
+		for _, commit := range direct {
+			fmt.Fprintf(&b, "- `%.7s` %s (%s)\n", commit.SHA, commit.Subject, commit.Author)
+		}
+		b.WriteString("\n")
+	}
+	if len(c.Commits) == 0 {
+		b.WriteString("No changes.\n")
+	}
+	return b.String()
+}
+
+// resolveRevision resolves a commit SHA, branch or tag of a clone to a commit.
+func resolveRevision(r *git.Repository, rev string) (plumbing.Hash, error) {
+	hash, err := r.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("%w %q: %v", ErrUnknownRevision, rev, err)
+	}
+	return *hash, nil
+}
+
+// walkCommits visits the ancestors of a commit, including itself, once each.
+// The parents of a commit are skipped when visit returns false.
+func walkCommits(r *git.Repository, from plumbing.Hash, visit func(*object.Commit) bool) error {
+	seen := map[plumbing.Hash]bool{}
+	stack := []plumbing.Hash{from}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		c, err := r.CommitObject(hash)
+		if err != nil {
+			return err
+		}
+		if visit(c) {
+			stack = append(stack, c.ParentHashes...)
+		}
+	}
+	return nil
+}
+
+// attribute assigns a pull request to the commits of the range reachable from a merged parent,
+// leaving out the mainline and the commits already attributed.
+func attribute(included map[plumbing.Hash]*object.Commit, mainline map[plumbing.Hash]bool, pullOf map[plumbing.Hash]int, from plumbing.Hash, number int) {
+	stack := []plumbing.Hash{from}
+	for len(stack) > 0 {
+		hash := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		c, ok := included[hash]
+		if !ok || mainline[hash] || pullOf[hash] != 0 {
+			continue
+		}
+		pullOf[hash] = number
+		stack = append(stack, c.ParentHashes...)
+	}
+}
+
+// subject returns the first line of a commit message.
+func subject(message string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	return strings.TrimSpace(line)
+}
+
+// mergeTitle returns the pull request title GitHub puts after the subject of a merge commit,
+// or the subject when there is none.
+func mergeTitle(message string) string {
+	_, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	if title := subject(body); title != "" {
+		return title
+	}
+	return subject(message)
+}
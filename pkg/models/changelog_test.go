@@ -0,0 +1,145 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testClone is an in-memory repository whose commits are made one minute apart.
+type testClone struct {
+	t    *testing.T
+	repo *git.Repository
+	tree *git.Worktree
+	now  time.Time
+}
+
+// newTestClone creates an empty in-memory repository.
+func newTestClone(t *testing.T) *testClone {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	require.NoError(t, err)
+	tree, err := repo.Worktree()
+	require.NoError(t, err)
+	return &testClone{t: t, repo: repo, tree: tree, now: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+// commit makes an empty commit by author on top of parents, or of HEAD when none are given.
+func (c *testClone) commit(message, author string, parents ...plumbing.Hash) plumbing.Hash {
+	c.now = c.now.Add(time.Minute)
+	signature := &object.Signature{Name: author, Email: author + "@example.com", When: c.now}
+	hash, err := c.tree.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: signature, Parents: parents})
+	require.NoError(c.t, err)
+	return hash
+}
+
+// TestBuildChangelogFromClone tests that merge and squash commits are linked to their pull requests.
+func TestBuildChangelogFromClone(t *testing.T) {
+	clone := newTestClone(t)
+	base := clone.commit("Initial commit", "alice")
+	_, err := clone.repo.CreateTag("v1.0.0", base, &git.CreateTagOptions{
+		Message: "Release 1.0.0",
+		Tagger:  &object.Signature{Name: "alice", When: clone.now},
+	})
+	require.NoError(t, err)
+	clone.commit("Add widget API", "bob", base)
+	feature := clone.commit("Document widget API", "bob")
+	main := clone.commit("Update README", "alice", base)
+	clone.commit("Merge pull request #7 from octocat/widgets\n\nAdd widgets", "alice", main, feature)
+	clone.commit("Fix widget crash (#8)", "carol")
+	clone.commit("Bump version", "alice")
+
+	log, err := BuildChangelogFromClone(clone.repo, "octocat", "hello", "v1.0.0", "HEAD")
+
+	require.NoError(t, err)
+	assert.Equal(t, ChangelogSourceGit, log.Source)
+	require.Len(t, log.Commits, 6)
+	assert.Equal(t, "Bump version", log.Commits[0].Subject)
+	assert.Zero(t, log.Commits[0].PullRequest)
+	assert.Equal(t, 8, log.Commits[1].PullRequest)
+	assert.Equal(t, 7, log.Commits[2].PullRequest)
+	assert.Zero(t, log.Commits[3].PullRequest, "commits of the branch itself are not part of the pull request")
+	assert.Equal(t, 7, log.Commits[4].PullRequest)
+	assert.Equal(t, 7, log.Commits[5].PullRequest)
+	assert.Equal(t, []ChangelogPullRequest{
+		{Number: 8, Title: "Fix widget crash", Author: "carol", Commits: 1},
+		{Number: 7, Title: "Add widgets", Author: "bob", Commits: 3},
+	}, log.PullRequests)
+
+	markdown := log.Markdown()
+	assert.Contains(t, markdown, "- Add widgets (#7) by bob\n")
+	assert.Contains(t, markdown, "Update README (alice)")
+	assert.NotContains(t, markdown, "Document widget API")
+
+	_, err = BuildChangelogFromClone(clone.repo, "octocat", "hello", "v9.9.9", "HEAD")
+	assert.ErrorIs(t, err, ErrUnknownRevision)
+}
+
+// TestBuildChangelog tests building a changelog from the REST API, newest commit first.
+func TestBuildChangelog(t *testing.T) {
+	commit := func(sha, message, login string) *github.RepositoryCommit {
+		return &github.RepositoryCommit{
+			SHA:    github.String(sha),
+			Author: &github.User{Login: github.String(login)},
+			Commit: &github.Commit{Message: github.String(message), Author: &github.CommitAuthor{Name: github.String(login)}},
+		}
+	}
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CompareCommits", mock.Anything, "octocat", "hello", "v1.0.0", "main", mock.Anything).Return(
+		&github.CommitsComparison{Commits: []*github.RepositoryCommit{
+			commit("a1", "Add widget API", "bob"),
+			commit("b2", "Document widget API\n\nDetails", "bob"),
+			commit("c3", "Bump version", "alice"),
+		}},
+		&github.Response{},
+		nil)
+	widgets := &github.PullRequest{
+		Number:   github.Int(7),
+		Title:    github.String("Add widgets"),
+		User:     &github.User{Login: github.String("bob")},
+		HTMLURL:  github.String("https://github.com/octocat/hello/pull/7"),
+		MergedAt: &github.Timestamp{Time: time.Now()},
+	}
+	for _, sha := range []string{"a1", "b2"} {
+		mockClient.On("ListPullRequestsWithCommit", mock.Anything, "octocat", "hello", sha, mock.Anything).Return(
+			[]*github.PullRequest{{Number: github.Int(3)}, widgets}, &github.Response{}, nil)
+	}
+	mockClient.On("ListPullRequestsWithCommit", mock.Anything, "octocat", "hello", "c3", mock.Anything).Return(
+		[]*github.PullRequest{}, &github.Response{}, nil)
+
+	log, err := BuildChangelog(context.Background(), mockClient, "octocat", "hello", "v1.0.0", "main")
+
+	require.NoError(t, err)
+	require.Len(t, log.Commits, 3)
+	assert.Equal(t, "c3", log.Commits[0].SHA)
+	assert.Equal(t, "Document widget API", log.Commits[1].Subject)
+	assert.Equal(t, 7, log.Commits[2].PullRequest)
+	require.Len(t, log.PullRequests, 1)
+	assert.Equal(t, 2, log.PullRequests[0].Commits)
+	assert.Equal(t, "https://github.com/octocat/hello/pull/7", log.PullRequests[0].URL)
+	assert.Contains(t, log.Markdown(), "### Commits\n\n- `c3` Bump version (alice)\n")
+}
+
+// TestCommitFilterToOptions tests validating the commit filters.
+func TestCommitFilterToOptions(t *testing.T) {
+	opt, err := CommitFilter{Path: "pkg/", Since: "2024-06-01", Until: "2024-07-01T00:00:00Z"}.ToOptions(github.ListOptions{PerPage: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "pkg/", opt.Path)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), opt.Since)
+	assert.Equal(t, 10, opt.PerPage)
+
+	_, err = CommitFilter{Since: "2024-07-01", Until: "2024-06-01"}.ToOptions(github.ListOptions{})
+	assert.Error(t, err)
+	_, err = CommitFilter{Until: "yesterday"}.ToOptions(github.ListOptions{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,297 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"time"
+)
+
+// maxAnnotations is the number of annotations GitHub accepts in a single check run request.
+const maxAnnotations = 50
+
+// ErrInvalidCheckRun is returned when the status and conclusion of a check run contradict each other.
+var ErrInvalidCheckRun = errors.New("invalid check run")
+
+// ErrPartiallyAnnotated is returned when a check run was saved but a batch of its annotations failed.
+var ErrPartiallyAnnotated = errors.New("check run saved but not fully annotated")
+
+// AnnotationsError is the error returned, wrapping ErrPartiallyAnnotated and the GitHub error,
+// when a batch of annotations fails after the check run was saved. Sent is the number of
+// annotations of the request added before the failure, so that a client can send the rest to the
+// same check run rather than create another one.
+type AnnotationsError struct {
+	Sent int
+	Err  error
+}
+
+func (e *AnnotationsError) Error() string {
+	return fmt.Sprintf("%v: %d annotations added: %v", ErrPartiallyAnnotated, e.Sent, e.Err)
+}
+
+func (e *AnnotationsError) Unwrap() []error {
+	return []error{ErrPartiallyAnnotated, e.Err}
+}
+
+// StatusRequest is the payload accepted when creating a commit status. Context distinguishes the
+// statuses of different systems on a commit and defaults to "default".
+type StatusRequest struct {
+	State       string `json:"state" binding:"required,oneof=error failure pending success"`
+	TargetURL   string `json:"target_url" binding:"omitempty,http_url"`
+	Description string `json:"description" binding:"max=140"`
+	Context     string `json:"context" binding:"max=255"`
+}
+
+// CheckRunRequest is the payload accepted when creating a check run.
+type CheckRunRequest struct {
+	Name        string          `json:"name" binding:"required,max=255"`
+	HeadSHA     string          `json:"head_sha" binding:"required,len=40,hexadecimal"`
+	Status      string          `json:"status" binding:"omitempty,oneof=queued in_progress completed"`
+	Conclusion  string          `json:"conclusion" binding:"omitempty,oneof=action_required cancelled failure neutral success skipped timed_out"`
+	DetailsURL  string          `json:"details_url" binding:"omitempty,http_url"`
+	ExternalID  string          `json:"external_id"`
+	StartedAt   *time.Time      `json:"started_at"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	Output      *CheckRunOutput `json:"output"`
+}
+
+// CheckRunUpdate is the payload accepted when updating a check run; unset fields are left unchanged.
+type CheckRunUpdate struct {
+	Name        string          `json:"name" binding:"max=255"`
+	Status      string          `json:"status" binding:"omitempty,oneof=queued in_progress completed"`
+	Conclusion  string          `json:"conclusion" binding:"omitempty,oneof=action_required cancelled failure neutral success skipped timed_out"`
+	DetailsURL  string          `json:"details_url" binding:"omitempty,http_url"`
+	ExternalID  string          `json:"external_id"`
+	CompletedAt *time.Time      `json:"completed_at"`
+	Output      *CheckRunOutput `json:"output"`
+}
+
+// CheckRunOutput is the report of a check run: a title, a Markdown summary and text, and any
+// number of annotations, which are sent to GitHub in batches of 50.
+type CheckRunOutput struct {
+	Title       string       `json:"title" binding:"required,max=255"`
+	Summary     string       `json:"summary" binding:"required,max=65535"`
+	Text        string       `json:"text" binding:"max=65535"`
+	Annotations []Annotation `json:"annotations" binding:"dive"`
+}
+
+// Annotation points at lines of a file of the commit with a notice, warning or failure.
+// Columns are only allowed when the annotation starts and ends on the same line.
+type Annotation struct {
+	Path        string `json:"path" binding:"required"`
+	StartLine   int    `json:"start_line" binding:"required,min=1"`
+	EndLine     int    `json:"end_line" binding:"required,gtefield=StartLine"`
+	StartColumn int    `json:"start_column" binding:"omitempty,min=1"`
+	EndColumn   int    `json:"end_column" binding:"omitempty,min=1"`
+	Level       string `json:"annotation_level" binding:"required,oneof=notice warning failure"`
+	Message     string `json:"message" binding:"required,max=65535"`
+	Title       string `json:"title" binding:"max=255"`
+	RawDetails  string `json:"raw_details" binding:"max=65535"`
+}
+
+// CreateStatus creates a commit status.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - sha: The SHA of the commit.
+//   - req: The state, target URL, description and context of the status.
+//
+// Returns:
+//   - *github.RepoStatus: The created status.
+//   - error: An error if the commit does not exist or the status cannot be created.
+func CreateStatus(ctx context.Context, client interfaces.GitHubClient, owner, repo, sha string, req StatusRequest) (*github.RepoStatus, error) {
+	status := &github.RepoStatus{State: github.String(req.State), Context: github.String(req.Context)}
+	if req.Context == "" {
+		status.Context = github.String("default")
+	}
+	if req.TargetURL != "" {
+		status.TargetURL = github.String(req.TargetURL)
+	}
+	if req.Description != "" {
+		status.Description = github.String(req.Description)
+	}
+	created, _, err := client.CreateStatus(ctx, owner, repo, sha, status)
+	return created, err
+}
+
+// CreateCheckRun creates a check run, then adds the annotations beyond the first 50 in batches.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - req: The check run.
+//   - now: The completion time of a run concluded without one.
+//
+// Returns:
+//   - *github.CheckRun: The check run, as returned by the last successful request; also returned
+//     when an annotation batch failed.
+//   - error: An error wrapping ErrInvalidCheckRun, an error if the run cannot be created, or an
+//     *AnnotationsError if an annotation batch fails.
+func CreateCheckRun(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req CheckRunRequest, now time.Time) (*github.CheckRun, error) {
+	if err := checkConclusion(req.Status, req.Conclusion); err != nil {
+		return nil, err
+	}
+	output, rest := req.Output.batch()
+	opts := github.CreateCheckRunOptions{
+		Name:        req.Name,
+		HeadSHA:     req.HeadSHA,
+		DetailsURL:  optional(req.DetailsURL),
+		ExternalID:  optional(req.ExternalID),
+		Status:      optional(req.Status),
+		Conclusion:  optional(req.Conclusion),
+		StartedAt:   timestamp(req.StartedAt),
+		CompletedAt: completedAt(req.Conclusion, req.CompletedAt, now),
+		Output:      output,
+	}
+	run, _, err := client.CreateCheckRun(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, err
+	}
+	return addAnnotations(ctx, client, owner, repo, run, req.Output, rest)
+}
+
+// UpdateCheckRun updates a check run, then adds the annotations beyond the first 50 in batches.
+// Annotations are added to the ones the run already has.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - id: The ID of the check run.
+//   - req: The fields of the check run to update.
+//   - now: The completion time of a run concluded without one.
+//
+// Returns:
+//   - *github.CheckRun: The check run, as returned by the last successful request; also returned
+//     when an annotation batch failed.
+//   - error: An error wrapping ErrInvalidCheckRun, an error if the run does not exist or cannot be
+//     updated, or an *AnnotationsError if an annotation batch fails.
+func UpdateCheckRun(ctx context.Context, client interfaces.GitHubClient, owner, repo string, id int64, req CheckRunUpdate, now time.Time) (*github.CheckRun, error) {
+	if err := checkConclusion(req.Status, req.Conclusion); err != nil {
+		return nil, err
+	}
+	name := req.Name
+	if name == "" {
+		// GitHub requires the name with every update.
+		run, _, err := client.GetCheckRun(ctx, owner, repo, id)
+		if err != nil {
+			return nil, err
+		}
+		name = run.GetName()
+	}
+	output, rest := req.Output.batch()
+	run, _, err := client.UpdateCheckRun(ctx, owner, repo, id, github.UpdateCheckRunOptions{
+		Name:        name,
+		DetailsURL:  optional(req.DetailsURL),
+		ExternalID:  optional(req.ExternalID),
+		Status:      optional(req.Status),
+		Conclusion:  optional(req.Conclusion),
+		CompletedAt: completedAt(req.Conclusion, req.CompletedAt, now),
+		Output:      output,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return addAnnotations(ctx, client, owner, repo, run, req.Output, rest)
+}
+
+// batch converts the output to its GitHub form with the first 50 annotations, and returns the
+// annotations left to add.
+func (o *CheckRunOutput) batch() (*github.CheckRunOutput, []*github.CheckRunAnnotation) {
+	if o == nil {
+		return nil, nil
+	}
+	annotations := make([]*github.CheckRunAnnotation, len(o.Annotations))
+	for i, a := range o.Annotations {
+		annotations[i] = &github.CheckRunAnnotation{
+			Path:            github.String(a.Path),
+			StartLine:       github.Int(a.StartLine),
+			EndLine:         github.Int(a.EndLine),
+			AnnotationLevel: github.String(a.Level),
+			Message:         github.String(a.Message),
+			Title:           optional(a.Title),
+			RawDetails:      optional(a.RawDetails),
+		}
+		if a.StartColumn > 0 {
+			annotations[i].StartColumn = github.Int(a.StartColumn)
+		}
+		if a.EndColumn > 0 {
+			annotations[i].EndColumn = github.Int(a.EndColumn)
+		}
+	}
+	first := annotations
+	if len(first) > maxAnnotations {
+		first = first[:maxAnnotations]
+	}
+	return o.github(first), annotations[len(first):]
+}
+
+// github converts the output to its GitHub form with the given annotations.
+func (o *CheckRunOutput) github(annotations []*github.CheckRunAnnotation) *github.CheckRunOutput {
+	return &github.CheckRunOutput{
+		Title:       github.String(o.Title),
+		Summary:     github.String(o.Summary),
+		Text:        optional(o.Text),
+		Annotations: annotations,
+	}
+}
+
+// addAnnotations adds the last annotations of an output to a check run in batches of 50, repeating
+// the output. If a batch fails, the run is returned with an *AnnotationsError.
+func addAnnotations(ctx context.Context, client interfaces.GitHubClient, owner, repo string, run *github.CheckRun, output *CheckRunOutput, annotations []*github.CheckRunAnnotation) (*github.CheckRun, error) {
+	for len(annotations) > 0 {
+		n := min(len(annotations), maxAnnotations)
+		updated, _, err := client.UpdateCheckRun(ctx, owner, repo, run.GetID(), github.UpdateCheckRunOptions{
+			Name:   run.GetName(),
+			Output: output.github(annotations[:n]),
+		})
+		if err != nil {
+			return run, &AnnotationsError{Sent: len(output.Annotations) - len(annotations), Err: err}
+		}
+		run, annotations = updated, annotations[n:]
+	}
+	return run, nil
+}
+
+// checkConclusion verifies that a completed check run has a conclusion.
+func checkConclusion(status, conclusion string) error {
+	if status == "completed" && conclusion == "" {
+		return fmt.Errorf("%w: a completed check run requires a conclusion", ErrInvalidCheckRun)
+	}
+	if conclusion != "" && status != "" && status != "completed" {
+		return fmt.Errorf("%w: a check run with a conclusion must be completed", ErrInvalidCheckRun)
+	}
+	return nil
+}
+
+// completedAt returns the completion time of a check run, now if it is concluded without one.
+func completedAt(conclusion string, at *time.Time, now time.Time) *github.Timestamp {
+	if at == nil && conclusion != "" {
+		at = &now
+	}
+	return timestamp(at)
+}
+
+// timestamp converts an optional time to its GitHub form.
+func timestamp(t *time.Time) *github.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return &github.Timestamp{Time: *t}
+}
+
+// optional returns a pointer to s, or nil if s is empty.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
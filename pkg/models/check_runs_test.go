@@ -0,0 +1,109 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// annotations returns n warning annotations on consecutive lines of main.go.
+func annotations(n int) []Annotation {
+	list := make([]Annotation, n)
+	for i := range list {
+		list[i] = Annotation{Path: "main.go", StartLine: i + 1, EndLine: i + 1, Level: "warning", Message: fmt.Sprintf("warning %d", i+1)}
+	}
+	return list
+}
+
+// annotationCount matches check run output carrying n annotations, the first on the given line.
+func annotationCount(n, firstLine int) func(*github.CheckRunOutput) bool {
+	return func(o *github.CheckRunOutput) bool {
+		return o != nil && o.GetTitle() == "lint" && len(o.Annotations) == n && o.Annotations[0].GetStartLine() == firstLine
+	}
+}
+
+// TestCreateCheckRunBatchesAnnotations tests that annotations are sent 50 at a time.
+func TestCreateCheckRunBatchesAnnotations(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	run := &github.CheckRun{ID: github.Int64(4), Name: github.String("lint")}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateCheckRun", mock.Anything, "octocat", "hello", mock.MatchedBy(func(opts github.CreateCheckRunOptions) bool {
+		return opts.GetConclusion() == "failure" && opts.GetCompletedAt().Time.Equal(now) && annotationCount(50, 1)(opts.Output)
+	})).Return(run, &github.Response{}, nil).Once()
+	mockClient.On("UpdateCheckRun", mock.Anything, "octocat", "hello", int64(4), mock.MatchedBy(func(opts github.UpdateCheckRunOptions) bool {
+		return opts.Name == "lint" && opts.Conclusion == nil && annotationCount(50, 51)(opts.Output)
+	})).Return(run, &github.Response{}, nil).Once()
+	mockClient.On("UpdateCheckRun", mock.Anything, "octocat", "hello", int64(4), mock.MatchedBy(func(opts github.UpdateCheckRunOptions) bool {
+		return annotationCount(20, 101)(opts.Output)
+	})).Return(run, &github.Response{}, nil).Once()
+
+	req := CheckRunRequest{
+		Name:       "lint",
+		HeadSHA:    "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Conclusion: "failure",
+		Output:     &CheckRunOutput{Title: "lint", Summary: "**120** warnings", Annotations: annotations(120)},
+	}
+	created, err := CreateCheckRun(context.Background(), mockClient, "octocat", "hello", req, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), created.GetID())
+	mockClient.AssertExpectations(t)
+}
+
+// TestCreateCheckRunAnnotationsFail tests that the check run is returned with the number of
+// annotations added when a batch fails after the run was created.
+func TestCreateCheckRunAnnotationsFail(t *testing.T) {
+	run := &github.CheckRun{ID: github.Int64(4), Name: github.String("lint")}
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateCheckRun", mock.Anything, "octocat", "hello", mock.Anything).Return(run, &github.Response{}, nil)
+	mockClient.On("UpdateCheckRun", mock.Anything, "octocat", "hello", int64(4), mock.MatchedBy(func(opts github.UpdateCheckRunOptions) bool {
+		return annotationCount(50, 51)(opts.Output)
+	})).Return(run, &github.Response{}, nil).Once()
+	mockClient.On("UpdateCheckRun", mock.Anything, "octocat", "hello", int64(4), mock.MatchedBy(func(opts github.UpdateCheckRunOptions) bool {
+		return annotationCount(20, 101)(opts.Output)
+	})).Return((*github.CheckRun)(nil), (*github.Response)(nil), errors.New("secondary rate limit")).Once()
+
+	req := CheckRunRequest{
+		Name:    "lint",
+		HeadSHA: "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Output:  &CheckRunOutput{Title: "lint", Summary: "**120** warnings", Annotations: annotations(120)},
+	}
+	created, err := CreateCheckRun(context.Background(), mockClient, "octocat", "hello", req, time.Now())
+	assert.ErrorIs(t, err, ErrPartiallyAnnotated)
+	var partial *AnnotationsError
+	require.ErrorAs(t, err, &partial)
+	assert.Equal(t, 100, partial.Sent)
+	assert.Equal(t, int64(4), created.GetID())
+	mockClient.AssertExpectations(t)
+}
+
+// TestUpdateCheckRunName tests that the name GitHub requires is read from the run when it is not given.
+func TestUpdateCheckRunName(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetCheckRun", mock.Anything, "octocat", "hello", int64(4)).Return(&github.CheckRun{Name: github.String("build")}, &github.Response{}, nil)
+	mockClient.On("UpdateCheckRun", mock.Anything, "octocat", "hello", int64(4), mock.MatchedBy(func(opts github.UpdateCheckRunOptions) bool {
+		return opts.Name == "build" && opts.GetStatus() == "in_progress" && opts.Output == nil
+	})).Return(&github.CheckRun{}, &github.Response{}, nil)
+
+	_, err := UpdateCheckRun(context.Background(), mockClient, "octocat", "hello", 4, CheckRunUpdate{Status: "in_progress"}, time.Now())
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+// TestCheckRunConclusion tests that a completed check run requires a conclusion, and only a completed one has one.
+func TestCheckRunConclusion(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	_, err := CreateCheckRun(context.Background(), mockClient, "octocat", "hello", CheckRunRequest{Name: "build", Status: "completed"}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidCheckRun)
+	_, err = UpdateCheckRun(context.Background(), mockClient, "octocat", "hello", 4, CheckRunUpdate{Name: "build", Status: "queued", Conclusion: "success"}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidCheckRun)
+	mockClient.AssertNotCalled(t, "CreateCheckRun", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/interfaces"
+)
+
+// States of a check reported by CommitCheckStates.
+const (
+	CheckSuccess = "success"
+	CheckPending = "pending"
+	CheckFailure = "failure"
+)
+
+// CommitCheckStates returns the state of every commit status and check run of a commit, keyed by
+// status context or check run name. Statuses in error, and check runs that did not conclude with
+// success, neutral or skipped, are failures; check runs that are not completed are pending. When a
+// status and a check run share a name, a failure prevails over a pending check, and a pending
+// check over a success.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - sha: The SHA of the commit.
+//
+// Returns:
+//   - map[string]string: The state of each check: success, pending or failure.
+//   - error: An error if the statuses or check runs cannot be retrieved.
+func CommitCheckStates(ctx context.Context, client interfaces.GitHubClient, owner, repo, sha string) (map[string]string, error) {
+	states := map[string]string{}
+	set := func(name, state string) {
+		if current, ok := states[name]; !ok || checkSeverity(state) > checkSeverity(current) {
+			states[name] = state
+		}
+	}
+
+	status, err := combinedStatus(ctx, client, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range status.Statuses {
+		switch s.GetState() {
+		case "success":
+			set(s.GetContext(), CheckSuccess)
+		case "pending":
+			set(s.GetContext(), CheckPending)
+		default:
+			set(s.GetContext(), CheckFailure)
+		}
+	}
+
+	runs, err := checkRuns(ctx, client, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		switch {
+		case run.GetStatus() != "completed":
+			set(run.GetName(), CheckPending)
+		case run.GetConclusion() == "success", run.GetConclusion() == "neutral", run.GetConclusion() == "skipped":
+			set(run.GetName(), CheckSuccess)
+		default:
+			set(run.GetName(), CheckFailure)
+		}
+	}
+	return states, nil
+}
+
+// checkSeverity orders check states from success to failure.
+func checkSeverity(state string) int {
+	switch state {
+	case CheckFailure:
+		return 2
+	case CheckPending:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"fmt"
+	"github.com/google/go-github/v50/github"
+)
+
+// CommitFilter holds the query parameters accepted when listing the commits of a repository.
+type CommitFilter struct {
+	SHA    string `form:"sha"`
+	Path   string `form:"path"`
+	Author string `form:"author"`
+	Since  string `form:"since"`
+	Until  string `form:"until"`
+}
+
+// ToOptions validates the filter and converts it to the options of the GitHub commits API.
+// Since and Until accept an RFC 3339 timestamp or a YYYY-MM-DD date.
+//
+// Parameters:
+//   - page: The pagination options of the request.
+//
+// Returns:
+//   - *github.CommitsListOptions: The options to list commits with.
+//   - error: An error describing the first invalid field.
+func (f CommitFilter) ToOptions(page github.ListOptions) (*github.CommitsListOptions, error) {
+	opt := &github.CommitsListOptions{SHA: f.SHA, Path: f.Path, Author: f.Author, ListOptions: page}
+	if f.Since != "" {
+		since, err := ParseTime(f.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		opt.Since = since
+	}
+	if f.Until != "" {
+		until, err := ParseTime(f.Until)
+		if err != nil {
+			return nil, fmt.Errorf("invalid until: %w", err)
+		}
+		opt.Until = until
+	}
+	if !opt.Since.IsZero() && !opt.Until.IsZero() && !opt.Since.Before(opt.Until) {
+		return nil, fmt.Errorf("invalid range: since must be before until")
+	}
+	return opt, nil
+}
@@ -0,0 +1,252 @@
+package models
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Errors returned when changing a file. Controllers map ErrStaleContent and ErrContentExists
+// to 409 Conflict, as GitHub does for a stale SHA, and ErrNotAFile to 422 Unprocessable Entity.
+var (
+	ErrStaleContent  = errors.New("file has changed since it was read")
+	ErrContentExists = errors.New("file already exists")
+	ErrNotAFile      = errors.New("path is not a file")
+)
+
+// FileChangeRequest is the payload accepted when creating, updating or deleting a file.
+// Content is plain text unless Encoding is "base64". SHA is the blob SHA of the file as last
+// read by the caller; it is required to update or delete a file, and the change is refused
+// with a conflict when the file has changed since.
+type FileChangeRequest struct {
+	Message   string               `json:"message"`
+	Content   string               `json:"content"`
+	Encoding  string               `json:"encoding"`
+	SHA       string               `json:"sha"`
+	Branch    string               `json:"branch"`
+	Author    *github.CommitAuthor `json:"author"`
+	Committer *github.CommitAuthor `json:"committer"`
+}
+
+// Missing returns the names of the required fields missing from the request.
+//
+// Parameters:
+//   - requireSHA: Whether the SHA of the current file is required, i.e. when updating or deleting.
+//
+// Returns:
+//   - []string: The missing fields, empty if the request is complete.
+func (r FileChangeRequest) Missing(requireSHA bool) []string {
+	var missing []string
+	if r.Message == "" {
+		missing = append(missing, "message")
+	}
+	if requireSHA && r.SHA == "" {
+		missing = append(missing, "sha")
+	}
+	return missing
+}
+
+// Options validates the request and converts it to the options sent to GitHub.
+//
+// Returns:
+//   - *github.RepositoryContentFileOptions: The options of the change.
+//   - error: An error if the encoding is unknown or the content is not valid base64.
+func (r FileChangeRequest) Options() (*github.RepositoryContentFileOptions, error) {
+	if err := oneOf("encoding", r.Encoding, "utf-8", "base64"); err != nil {
+		return nil, err
+	}
+	content := []byte(r.Content)
+	if r.Encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("invalid content: %w", err)
+		}
+		content = decoded
+	}
+	opts := &github.RepositoryContentFileOptions{
+		Message:   github.String(r.Message),
+		Content:   content,
+		Author:    r.Author,
+		Committer: r.Committer,
+	}
+	if r.SHA != "" {
+		opts.SHA = github.String(r.SHA)
+	}
+	if r.Branch != "" {
+		opts.Branch = github.String(r.Branch)
+	}
+	return opts, nil
+}
+
+// CreateFile creates a file, refusing to overwrite an existing one.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file.
+//   - req: The change to commit.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: The created file and commit.
+//   - error: An error wrapping ErrContentExists if the path is taken, or the GitHub error.
+func CreateFile(ctx context.Context, client interfaces.GitHubClient, owner, repo, path string, req FileChangeRequest) (*github.RepositoryContentResponse, error) {
+	opts, err := req.Options()
+	if err != nil {
+		return nil, err
+	}
+	opts.SHA = nil
+	current, err := currentSHA(ctx, client, owner, repo, path, req.Branch)
+	switch {
+	case err == nil:
+		return nil, fmt.Errorf("%w at %s with sha %s", ErrContentExists, path, current)
+	case errors.Is(err, ErrNotAFile):
+		return nil, fmt.Errorf("%w at %s", ErrContentExists, path)
+	case !isNotFound(err):
+		return nil, err
+	}
+	created, _, err := client.CreateFile(ctx, owner, repo, path, opts)
+	return created, err
+}
+
+// UpdateFile replaces the content of a file, provided it is still at the SHA the caller read.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file.
+//   - req: The change to commit, with the SHA of the file as last read.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: The updated file and commit.
+//   - error: An error wrapping ErrStaleContent if the file has changed, or the GitHub error.
+func UpdateFile(ctx context.Context, client interfaces.GitHubClient, owner, repo, path string, req FileChangeRequest) (*github.RepositoryContentResponse, error) {
+	opts, err := req.Options()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSHA(ctx, client, owner, repo, path, req); err != nil {
+		return nil, err
+	}
+	updated, _, err := client.UpdateFile(ctx, owner, repo, path, opts)
+	return updated, err
+}
+
+// DeleteFile deletes a file, provided it is still at the SHA the caller read.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - path: The path of the file.
+//   - req: The commit message, branch and SHA of the file as last read; the content is ignored.
+//
+// Returns:
+//   - *github.RepositoryContentResponse: The commit deleting the file.
+//   - error: An error wrapping ErrStaleContent if the file has changed, or the GitHub error.
+func DeleteFile(ctx context.Context, client interfaces.GitHubClient, owner, repo, path string, req FileChangeRequest) (*github.RepositoryContentResponse, error) {
+	req.Content, req.Encoding = "", ""
+	opts, err := req.Options()
+	if err != nil {
+		return nil, err
+	}
+	opts.Content = nil
+	if err := checkSHA(ctx, client, owner, repo, path, req); err != nil {
+		return nil, err
+	}
+	deleted, _, err := client.DeleteFile(ctx, owner, repo, path, opts)
+	return deleted, err
+}
+
+// DownloadArchive opens a tarball or zipball of a repository at a ref.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - format: The archive format, github.Tarball or github.Zipball.
+//   - ref: The ref to archive, or an empty string for the default branch.
+//
+// Returns:
+//   - *http.Response: The response streaming the archive; the caller must close its body.
+//   - error: An error if the link cannot be retrieved or the download fails.
+func DownloadArchive(ctx context.Context, client interfaces.GitHubClient, owner, repo string, format github.ArchiveFormat, ref string) (*http.Response, error) {
+	link, _, err := client.GetArchiveLink(ctx, owner, repo, format, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return nil, err
+	}
+	return fetchLink(ctx, link, "archive")
+}
+
+// fetchLink downloads from a temporary link returned by the GitHub API; what names the download in errors.
+// The caller must close the body of the response.
+func fetchLink(ctx context.Context, link *url.URL, what string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("downloading %s: %s", what, resp.Status)
+	}
+	return resp, nil
+}
+
+// ArchiveFilename returns the file name of an archive of a repository at a ref.
+func ArchiveFilename(repo string, format github.ArchiveFormat, ref string) string {
+	name := repo
+	if ref != "" {
+		name += "-" + strings.ReplaceAll(ref, "/", "-")
+	}
+	if format == github.Zipball {
+		return name + ".zip"
+	}
+	return name + ".tar.gz"
+}
+
+// checkSHA verifies that a file is still at the SHA of a change request.
+func checkSHA(ctx context.Context, client interfaces.GitHubClient, owner, repo, path string, req FileChangeRequest) error {
+	current, err := currentSHA(ctx, client, owner, repo, path, req.Branch)
+	if err != nil {
+		return err
+	}
+	if current != req.SHA {
+		return fmt.Errorf("%w: %s is at sha %s, expected %s", ErrStaleContent, path, current, req.SHA)
+	}
+	return nil
+}
+
+// currentSHA returns the blob SHA of a file on a branch.
+func currentSHA(ctx context.Context, client interfaces.GitHubClient, owner, repo, path, branch string) (string, error) {
+	file, dir, _, err := client.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: branch})
+	if err != nil {
+		return "", err
+	}
+	if file == nil || dir != nil || file.GetType() != "file" {
+		return "", fmt.Errorf("%w: %s", ErrNotAFile, path)
+	}
+	return file.GetSHA(), nil
+}
+
+// isNotFound reports whether err is a 404 Not Found answer of the GitHub API.
+func isNotFound(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusNotFound
+}
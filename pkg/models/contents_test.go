@@ -0,0 +1,135 @@
+package models
+
+import (
+	"context"
+	"encoding/base64"
+	"github-api/pkg/mocks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fileAt returns the contents of a file at the given blob SHA.
+func fileAt(sha string) *github.RepositoryContent {
+	return &github.RepositoryContent{Type: github.String("file"), Path: github.String("README.md"), SHA: github.String(sha)}
+}
+
+// TestFileChangeRequestOptions tests the decoding of the content and the validation of the encoding.
+func TestFileChangeRequestOptions(t *testing.T) {
+	opts, err := FileChangeRequest{Message: "Add logo", Content: base64.StdEncoding.EncodeToString([]byte{0, 1, 2}), Encoding: "base64", Branch: "main"}.Options()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 1, 2}, opts.Content)
+	assert.Equal(t, "main", opts.GetBranch())
+	assert.Nil(t, opts.SHA)
+
+	opts, err = FileChangeRequest{Message: "Edit", Content: "hello", SHA: "abc"}.Options()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("hello"), opts.Content)
+	assert.Equal(t, "abc", opts.GetSHA())
+
+	_, err = FileChangeRequest{Message: "Edit", Content: "not base64!", Encoding: "base64"}.Options()
+	assert.Error(t, err)
+	_, err = FileChangeRequest{Message: "Edit", Encoding: "latin1"}.Options()
+	assert.Error(t, err)
+
+	assert.Equal(t, []string{"message", "sha"}, FileChangeRequest{}.Missing(true))
+	assert.Empty(t, FileChangeRequest{Message: "Add"}.Missing(false))
+}
+
+// TestCreateFile tests that a file is created only when nothing exists at the path.
+func TestCreateFile(t *testing.T) {
+	ref := &github.RepositoryContentGetOptions{Ref: "main"}
+	req := FileChangeRequest{Message: "Add README", Content: "hello", Branch: "main"}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetContents", mock.Anything, "octocat", "hello", "README.md", ref).Return(
+		(*github.RepositoryContent)(nil), ([]*github.RepositoryContent)(nil), (*github.Response)(nil),
+		&github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}})
+	created := &github.RepositoryContentResponse{Content: fileAt("new")}
+	mockClient.On("CreateFile", mock.Anything, "octocat", "hello", "README.md", mock.MatchedBy(func(opts *github.RepositoryContentFileOptions) bool {
+		return string(opts.Content) == "hello" && opts.GetBranch() == "main" && opts.SHA == nil
+	})).Return(created, &github.Response{}, nil)
+
+	result, err := CreateFile(context.Background(), mockClient, "octocat", "hello", "README.md", req)
+	require.NoError(t, err)
+	assert.Equal(t, created, result)
+
+	existing := new(mocks.MockGitHubClient)
+	existing.On("GetContents", mock.Anything, "octocat", "hello", "README.md", ref).Return(
+		fileAt("abc"), ([]*github.RepositoryContent)(nil), &github.Response{}, nil)
+	_, err = CreateFile(context.Background(), existing, "octocat", "hello", "README.md", req)
+	assert.ErrorIs(t, err, ErrContentExists)
+	existing.AssertNotCalled(t, "CreateFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestUpdateFileStale tests that a file is updated at the expected SHA and left alone when it has changed.
+func TestUpdateFileStale(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetContents", mock.Anything, "octocat", "hello", "README.md", &github.RepositoryContentGetOptions{}).Return(
+		fileAt("def"), ([]*github.RepositoryContent)(nil), &github.Response{}, nil)
+	updated := &github.RepositoryContentResponse{Content: fileAt("ghi")}
+	mockClient.On("UpdateFile", mock.Anything, "octocat", "hello", "README.md", mock.Anything).Return(updated, &github.Response{}, nil)
+
+	_, err := UpdateFile(context.Background(), mockClient, "octocat", "hello", "README.md", FileChangeRequest{Message: "Edit", Content: "hi", SHA: "abc"})
+	assert.ErrorIs(t, err, ErrStaleContent)
+	mockClient.AssertNotCalled(t, "UpdateFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	result, err := UpdateFile(context.Background(), mockClient, "octocat", "hello", "README.md", FileChangeRequest{Message: "Edit", Content: "hi", SHA: "def"})
+	require.NoError(t, err)
+	assert.Equal(t, updated, result)
+
+	_, err = DeleteFile(context.Background(), mockClient, "octocat", "hello", "README.md", FileChangeRequest{Message: "Remove", SHA: "abc"})
+	assert.ErrorIs(t, err, ErrStaleContent)
+}
+
+// TestDeleteFileDirectory tests that a directory cannot be deleted as a file.
+func TestDeleteFileDirectory(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetContents", mock.Anything, "octocat", "hello", "docs", &github.RepositoryContentGetOptions{}).Return(
+		(*github.RepositoryContent)(nil), []*github.RepositoryContent{fileAt("abc")}, &github.Response{}, nil)
+
+	_, err := DeleteFile(context.Background(), mockClient, "octocat", "hello", "docs", FileChangeRequest{Message: "Remove", SHA: "abc"})
+	assert.ErrorIs(t, err, ErrNotAFile)
+}
+
+// TestDownloadArchive tests that the archive is downloaded from the link returned by GitHub.
+func TestDownloadArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/octocat/hello/legacy.tar.gz/v1.0.0" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-gzip")
+		_, _ = w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+	link, err := url.Parse(server.URL + "/octocat/hello/legacy.tar.gz/v1.0.0")
+	require.NoError(t, err)
+	missing, err := url.Parse(server.URL + "/missing")
+	require.NoError(t, err)
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetArchiveLink", mock.Anything, "octocat", "hello", github.Tarball, &github.RepositoryContentGetOptions{Ref: "v1.0.0"}).Return(link, &github.Response{}, nil)
+	mockClient.On("GetArchiveLink", mock.Anything, "octocat", "hello", github.Zipball, mock.Anything).Return(missing, &github.Response{}, nil)
+
+	resp, err := DownloadArchive(context.Background(), mockClient, "octocat", "hello", github.Tarball, "v1.0.0")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "archive", string(body))
+	assert.Equal(t, "application/x-gzip", resp.Header.Get("Content-Type"))
+
+	_, err = DownloadArchive(context.Background(), mockClient, "octocat", "hello", github.Zipball, "")
+	assert.Error(t, err)
+
+	assert.Equal(t, "hello-v1.0.0.tar.gz", ArchiveFilename("hello", github.Tarball, "v1.0.0"))
+	assert.Equal(t, "hello-feature-x.zip", ArchiveFilename("hello", github.Zipball, "feature/x"))
+}
@@ -0,0 +1,196 @@
+package models
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"golang.org/x/crypto/ssh"
+	"strings"
+)
+
+// keyConcurrency is the number of repositories whose deploy key is rotated concurrently.
+const keyConcurrency = 4
+
+// States of the rotation of the deploy key of a repository.
+const (
+	RotationApplied = "rotated"
+	RotationFailed  = "failed"
+)
+
+// ErrInvalidDeployKey is returned when a deploy key request gives both or neither of a public key
+// and generate, or a public key that is not in the authorized_keys format.
+var ErrInvalidDeployKey = errors.New("invalid deploy key")
+
+// DeployKeyRequest is the payload accepted when adding a deploy key to a repository. Either Key,
+// an SSH public key in the authorized_keys format, or Generate is set; with Generate, an ed25519
+// keypair is created and its private key returned once. Deploy keys are read-only unless ReadOnly
+// is false.
+type DeployKeyRequest struct {
+	Title    string `json:"title" binding:"required,max=255"`
+	Key      string `json:"key"`
+	Generate bool   `json:"generate"`
+	ReadOnly *bool  `json:"read_only"`
+}
+
+// RotateKeysRequest is the payload accepted when rotating a deploy key across repositories. In
+// each repository, a new ed25519 deploy key titled Title is added, then the previous keys with the
+// same title are removed. Deploy keys are read-only unless ReadOnly is false.
+type RotateKeysRequest struct {
+	Repos    []string `json:"repos" binding:"required,min=1,unique,dive,fullname"`
+	Title    string   `json:"title" binding:"required,max=255"`
+	ReadOnly *bool    `json:"read_only"`
+}
+
+// DeployKey is a deploy key of a repository. PrivateKey is only set when the key was generated by
+// the request that returns it; it is not stored and cannot be retrieved again.
+type DeployKey struct {
+	*github.Key
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// KeyRotation records the rotation of the deploy key of a repository. Key is set as soon as the new
+// key is added, even if removing a previous key then failed.
+type KeyRotation struct {
+	Repository string     `json:"repository"`
+	Status     string     `json:"status"`
+	Key        *DeployKey `json:"key,omitempty"`
+	Removed    []int64    `json:"removed"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// GenerateKeyPair creates an ed25519 SSH keypair.
+//
+// Parameters:
+//   - comment: The comment of the private key, such as the title of the deploy key.
+//
+// Returns:
+//   - string: The public key, in the authorized_keys format.
+//   - string: The private key, in the OpenSSH PEM format.
+//   - error: An error if the keypair cannot be generated.
+func GenerateKeyPair(comment string) (string, string, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	sshPublic, err := ssh.NewPublicKey(public)
+	if err != nil {
+		return "", "", err
+	}
+	block, err := ssh.MarshalPrivateKey(private, comment)
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublic))), string(pem.EncodeToMemory(block)), nil
+}
+
+// AddDeployKey adds a deploy key to a repository, generating its keypair if requested.
+//
+// Parameters:
+//   - ctx: The context for the request.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - req: The title, public key or generate flag, and access of the deploy key.
+//
+// Returns:
+//   - *DeployKey: The deploy key, with its private key if it was generated.
+//   - error: An error wrapping ErrInvalidDeployKey, or an error if the key cannot be added.
+func AddDeployKey(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req DeployKeyRequest) (*DeployKey, error) {
+	if (req.Key == "") == !req.Generate {
+		return nil, fmt.Errorf("%w: set either key or generate", ErrInvalidDeployKey)
+	}
+	if req.Generate {
+		return createDeployKey(ctx, client, owner, repo, req.Title, req.ReadOnly)
+	}
+	if _, _, _, _, err := ssh.ParseAuthorizedKey([]byte(req.Key)); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDeployKey, err)
+	}
+	key, _, err := client.CreateKey(ctx, owner, repo, &github.Key{
+		Title:    github.String(req.Title),
+		Key:      github.String(strings.TrimSpace(req.Key)),
+		ReadOnly: github.Bool(readOnly(req.ReadOnly)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeployKey{Key: key}, nil
+}
+
+// Apply rotates the deploy key of each requested repository. A repository where the new key cannot
+// be added keeps its previous keys. Since GitHub rejects a key already used by another repository,
+// each repository receives its own keypair.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//
+// Returns:
+//   - []KeyRotation: The rotation of each repository, in the requested order.
+func (r RotateKeysRequest) Apply(ctx context.Context, client interfaces.GitHubClient) []KeyRotation {
+	rotations := make([]KeyRotation, len(r.Repos))
+	_ = forEachLimit(len(r.Repos), keyConcurrency, func(i int) error {
+		rotations[i] = r.rotate(ctx, client, r.Repos[i])
+		return nil
+	})
+	return rotations
+}
+
+// rotate adds a new deploy key to a repository, then removes its previous keys with the same title.
+func (r RotateKeysRequest) rotate(ctx context.Context, client interfaces.GitHubClient, fullName string) KeyRotation {
+	rotation := KeyRotation{Repository: fullName, Status: RotationFailed, Removed: []int64{}}
+	owner, name, err := SplitFullName(fullName)
+	if err != nil {
+		rotation.Error = err.Error()
+		return rotation
+	}
+	previous, err := allPages(func(opt *github.ListOptions) ([]*github.Key, *github.Response, error) {
+		return client.ListKeys(ctx, owner, name, opt)
+	})
+	if err != nil {
+		rotation.Error = err.Error()
+		return rotation
+	}
+	if rotation.Key, err = createDeployKey(ctx, client, owner, name, r.Title, r.ReadOnly); err != nil {
+		rotation.Error = err.Error()
+		return rotation
+	}
+	for _, key := range previous {
+		if key.GetTitle() != r.Title {
+			continue
+		}
+		if _, err := client.DeleteKey(ctx, owner, name, key.GetID()); err != nil {
+			rotation.Error = fmt.Sprintf("removing key %d: %v", key.GetID(), err)
+			return rotation
+		}
+		rotation.Removed = append(rotation.Removed, key.GetID())
+	}
+	rotation.Status = RotationApplied
+	return rotation
+}
+
+// createDeployKey generates an ed25519 keypair and adds its public key to a repository.
+func createDeployKey(ctx context.Context, client interfaces.GitHubClient, owner, repo, title string, readOnlyKey *bool) (*DeployKey, error) {
+	public, private, err := GenerateKeyPair(title)
+	if err != nil {
+		return nil, err
+	}
+	key, _, err := client.CreateKey(ctx, owner, repo, &github.Key{
+		Title:    github.String(title),
+		Key:      github.String(public),
+		ReadOnly: github.Bool(readOnly(readOnlyKey)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &DeployKey{Key: key, PrivateKey: private}, nil
+}
+
+// readOnly returns whether a deploy key is read-only, which it is unless explicitly set otherwise.
+func readOnly(value *bool) bool {
+	return value == nil || *value
+}
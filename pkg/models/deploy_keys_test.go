@@ -0,0 +1,82 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// TestGenerateKeyPair tests that the private key is an OpenSSH ed25519 key matching the public key.
+func TestGenerateKeyPair(t *testing.T) {
+	public, private, err := GenerateKeyPair("deploy")
+	require.NoError(t, err)
+
+	signer, err := ssh.ParsePrivateKey([]byte(private))
+	require.NoError(t, err)
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(public))
+	require.NoError(t, err)
+	assert.Equal(t, ssh.KeyAlgoED25519, parsed.Type())
+	assert.Equal(t, parsed.Marshal(), signer.PublicKey().Marshal())
+}
+
+// TestAddDeployKey tests that a generated key is added read-only with its private key returned,
+// and that a request must give exactly one valid public key or generate.
+func TestAddDeployKey(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateKey", mock.Anything, "octocat", "hello", mock.MatchedBy(func(key *github.Key) bool {
+		return key.GetTitle() == "ci" && key.GetReadOnly() && len(key.GetKey()) > 0
+	})).Return(&github.Key{ID: github.Int64(3)}, &github.Response{}, nil).Once()
+
+	key, err := AddDeployKey(context.Background(), mockClient, "octocat", "hello", DeployKeyRequest{Title: "ci", Generate: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), key.GetID())
+	assert.Contains(t, key.PrivateKey, "OPENSSH PRIVATE KEY")
+
+	for _, req := range []DeployKeyRequest{
+		{Title: "ci"},
+		{Title: "ci", Key: "ssh-ed25519 AAAA", Generate: true},
+		{Title: "ci", Key: "not a key"},
+	} {
+		_, err := AddDeployKey(context.Background(), mockClient, "octocat", "hello", req)
+		assert.ErrorIs(t, err, ErrInvalidDeployKey)
+	}
+	mockClient.AssertExpectations(t)
+}
+
+// TestRotateKeys tests that previous keys with the title are removed once the new key is added,
+// and that a repository keeps its keys when the new one cannot be added.
+func TestRotateKeys(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListKeys", mock.Anything, "octocat", "hello", mock.Anything).Return([]*github.Key{
+		{ID: github.Int64(1), Title: github.String("ci")},
+		{ID: github.Int64(2), Title: github.String("other")},
+	}, &github.Response{}, nil)
+	mockClient.On("CreateKey", mock.Anything, "octocat", "hello", mock.Anything).Return(&github.Key{ID: github.Int64(3)}, &github.Response{}, nil)
+	mockClient.On("DeleteKey", mock.Anything, "octocat", "hello", int64(1)).Return(&github.Response{}, nil).Once()
+	mockClient.On("ListKeys", mock.Anything, "octocat", "world", mock.Anything).Return([]*github.Key{
+		{ID: github.Int64(4), Title: github.String("ci")},
+	}, &github.Response{}, nil)
+	mockClient.On("CreateKey", mock.Anything, "octocat", "world", mock.Anything).Return((*github.Key)(nil), (*github.Response)(nil), errors.New("key is already in use"))
+
+	req := RotateKeysRequest{Repos: []string{"octocat/hello", "octocat/world"}, Title: "ci"}
+	rotations := req.Apply(context.Background(), mockClient)
+	require.Len(t, rotations, 2)
+
+	assert.Equal(t, RotationApplied, rotations[0].Status)
+	assert.Equal(t, int64(3), rotations[0].Key.GetID())
+	assert.NotEmpty(t, rotations[0].Key.PrivateKey)
+	assert.Equal(t, []int64{1}, rotations[0].Removed)
+
+	assert.Equal(t, RotationFailed, rotations[1].Status)
+	assert.Nil(t, rotations[1].Key)
+	assert.Empty(t, rotations[1].Removed)
+	mockClient.AssertNotCalled(t, "DeleteKey", mock.Anything, "octocat", "world", int64(4))
+	mockClient.AssertExpectations(t)
+}
@@ -0,0 +1,246 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+)
+
+// forkConcurrency is the number of forks compared with their upstream concurrently.
+const forkConcurrency = 4
+
+var (
+	// ErrNotAFork is returned when a repository expected to be a fork is not one.
+	ErrNotAFork = errors.New("repository is not a fork")
+	// ErrSyncConflict is returned when a branch of a fork cannot be synced with its upstream
+	// without a merge conflict.
+	ErrSyncConflict = errors.New("fork cannot be synced without conflicts")
+)
+
+// ForkRequest is the payload accepted when forking a repository. The fork is created in the
+// authenticated user's account unless Organization is set, and keeps the name of the upstream
+// repository unless Name is set. With DefaultBranchOnly, only the default branch is copied.
+type ForkRequest struct {
+	Organization      string `json:"organization" binding:"omitempty,login"`
+	Name              string `json:"name" binding:"omitempty,reponame"`
+	DefaultBranchOnly bool   `json:"default_branch_only"`
+}
+
+// SyncRequest is the payload accepted when syncing a branch of a fork; the default branch of the
+// fork is synced unless Branch is set.
+type SyncRequest struct {
+	Branch string `json:"branch" binding:"omitempty,branch"`
+}
+
+// ForkDivergence compares a branch of a fork with the branch of its upstream repository.
+// Status is identical, ahead, behind or diverged, as the fork branch is to the upstream branch.
+type ForkDivergence struct {
+	Fork           string `json:"fork"`
+	Branch         string `json:"branch"`
+	Upstream       string `json:"upstream"`
+	UpstreamBranch string `json:"upstream_branch"`
+	Status         string `json:"status"`
+	AheadBy        int    `json:"ahead_by"`
+	BehindBy       int    `json:"behind_by"`
+}
+
+// String describes the divergence, e.g. "octocat/hello:main is 2 commits ahead of and 3 behind octo-org/hello:main".
+func (d ForkDivergence) String() string {
+	return fmt.Sprintf("%s:%s is %d commits ahead of and %d behind %s:%s", d.Fork, d.Branch, d.AheadBy, d.BehindBy, d.Upstream, d.UpstreamBranch)
+}
+
+// Fork is a fork of a repository with the divergence of its default branch, or the error that
+// prevented comparing it.
+type Fork struct {
+	*github.Repository
+	Divergence *ForkDivergence `json:"divergence,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+// ForkSync is the outcome of syncing a branch of a fork with its upstream repository.
+type ForkSync struct {
+	MergeType  string          `json:"merge_type"`
+	BaseBranch string          `json:"base_branch"`
+	Message    string          `json:"message"`
+	Divergence *ForkDivergence `json:"divergence"`
+}
+
+// CreateFork forks a repository and waits until the fork is reachable.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the upstream repository.
+//   - repo: The name of the upstream repository.
+//   - req: The organization, name and default-branch-only flag of the fork.
+//
+// Returns:
+//   - *github.Repository: The fork, as GitHub announced it if it is not reachable yet.
+//   - error: An error wrapping ErrMovePending if the fork is not reachable in time, or the GitHub error.
+func CreateFork(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req ForkRequest) (*github.Repository, error) {
+	fork, _, err := client.CreateFork(ctx, owner, repo, &github.RepositoryCreateForkOptions{
+		Organization:      req.Organization,
+		Name:              req.Name,
+		DefaultBranchOnly: req.DefaultBranchOnly,
+	})
+	var accepted *github.AcceptedError
+	if errors.As(err, &accepted) {
+		// GitHub answers 202 Accepted with the fork it is creating in the background.
+		fork = &github.Repository{}
+		err = json.Unmarshal(accepted.Raw, fork)
+	}
+	if err != nil {
+		return nil, err
+	}
+	found, err := waitForRepository(ctx, client, fork.GetOwner().GetLogin(), fork.GetName(), moveInterval, moveTimeout)
+	if errors.Is(err, ErrMovePending) {
+		return fork, err
+	}
+	return found, err
+}
+
+// ListForks lists a page of the forks of a repository. With divergence, the default branch of
+// each fork is compared with the default branch of the repository.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the upstream repository.
+//   - repo: The name of the upstream repository.
+//   - opts: The sort order and pagination of the forks.
+//   - divergence: Whether to compare the forks with the repository.
+//
+// Returns:
+//   - []Fork: The forks, with their divergence or the error comparing them.
+//   - *github.Response: The response of the page, holding the pagination links.
+//   - error: An error if the repository or its forks cannot be retrieved.
+func ListForks(ctx context.Context, client interfaces.GitHubClient, owner, repo string, opts *github.RepositoryListForksOptions, divergence bool) ([]Fork, *github.Response, error) {
+	repos, resp, err := client.ListForks(ctx, owner, repo, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	forks := make([]Fork, len(repos))
+	for i, r := range repos {
+		forks[i] = Fork{Repository: r}
+	}
+	if !divergence || len(forks) == 0 {
+		return forks, resp, nil
+	}
+
+	upstream, _, err := client.GetRepositories(ctx, owner, repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	_ = forEachLimit(len(forks), forkConcurrency, func(i int) error {
+		d, err := compareFork(ctx, client, upstream, forks[i].Repository, "")
+		if err != nil {
+			forks[i].Error = err.Error()
+			return nil
+		}
+		forks[i].Divergence = d
+		return nil
+	})
+	return forks, resp, nil
+}
+
+// CompareFork compares a branch of a fork with the same branch of its upstream repository,
+// or the default branch of the fork with the default branch of the upstream repository.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the fork.
+//   - repo: The name of the fork.
+//   - branch: The branch to compare, or an empty string for the default branch.
+//
+// Returns:
+//   - *ForkDivergence: The divergence of the branch.
+//   - error: ErrNotAFork if the repository is not a fork, or the GitHub error.
+func CompareFork(ctx context.Context, client interfaces.GitHubClient, owner, repo, branch string) (*ForkDivergence, error) {
+	fork, _, err := client.GetRepositories(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if fork.Parent == nil {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotAFork, owner, repo)
+	}
+	return compareFork(ctx, client, fork.Parent, fork, branch)
+}
+
+// SyncFork syncs a branch of a fork with its upstream repository through the merge-upstream API,
+// then compares it with the upstream branch. A fork branch with commits of its own is merged with
+// the upstream branch; a conflict is reported with the divergence of the branch.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the fork.
+//   - repo: The name of the fork.
+//   - branch: The branch to sync, or an empty string for the default branch.
+//
+// Returns:
+//   - *ForkSync: The merge type of the sync and the divergence that remains.
+//   - error: ErrNotAFork, an error wrapping ErrSyncConflict, or the GitHub error.
+func SyncFork(ctx context.Context, client interfaces.GitHubClient, owner, repo, branch string) (*ForkSync, error) {
+	fork, _, err := client.GetRepositories(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if fork.Parent == nil {
+		return nil, fmt.Errorf("%w: %s/%s", ErrNotAFork, owner, repo)
+	}
+	synced := branch
+	if synced == "" {
+		synced = fork.GetDefaultBranch()
+	}
+	result, _, err := client.MergeUpstream(ctx, owner, repo, &github.RepoMergeUpstreamRequest{Branch: github.String(synced)})
+	var ghErr *github.ErrorResponse
+	if errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusConflict {
+		d, compareErr := compareFork(ctx, client, fork.Parent, fork, branch)
+		if compareErr != nil {
+			return nil, fmt.Errorf("%w: %s", ErrSyncConflict, ghErr.Message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrSyncConflict, d)
+	}
+	if err != nil {
+		return nil, err
+	}
+	d, err := compareFork(ctx, client, fork.Parent, fork, branch)
+	if err != nil {
+		return nil, err
+	}
+	return &ForkSync{
+		MergeType:  result.GetMergeType(),
+		BaseBranch: result.GetBaseBranch(),
+		Message:    result.GetMessage(),
+		Divergence: d,
+	}, nil
+}
+
+// compareFork compares a branch of a fork with the branch of the upstream repository it tracks,
+// through a comparison in the upstream repository with the fork branch as head.
+func compareFork(ctx context.Context, client interfaces.GitHubClient, upstream, fork *github.Repository, branch string) (*ForkDivergence, error) {
+	upstreamBranch := branch
+	if branch == "" {
+		branch, upstreamBranch = fork.GetDefaultBranch(), upstream.GetDefaultBranch()
+	}
+	forkOwner := fork.GetOwner().GetLogin()
+	comparison, _, err := client.CompareCommits(ctx, upstream.GetOwner().GetLogin(), upstream.GetName(),
+		upstreamBranch, forkOwner+":"+branch, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return nil, err
+	}
+	return &ForkDivergence{
+		Fork:           forkOwner + "/" + fork.GetName(),
+		Branch:         branch,
+		Upstream:       upstream.GetOwner().GetLogin() + "/" + upstream.GetName(),
+		UpstreamBranch: upstreamBranch,
+		Status:         comparison.GetStatus(),
+		AheadBy:        comparison.GetAheadBy(),
+		BehindBy:       comparison.GetBehindBy(),
+	}, nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// forkOf returns a fork of a repository, both with main as default branch.
+func forkOf(owner, name string, upstream *github.Repository) *github.Repository {
+	fork := repoAt(owner, name)
+	fork.DefaultBranch = github.String("main")
+	fork.Fork = github.Bool(true)
+	fork.Parent = upstream
+	return fork
+}
+
+// TestCreateForkAccepted tests that a fork announced with 202 Accepted is awaited.
+func TestCreateForkAccepted(t *testing.T) {
+	fastMoves(t, time.Second)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateFork", mock.Anything, "octo-org", "hello", &github.RepositoryCreateForkOptions{Name: "hello-fork", DefaultBranchOnly: true}).
+		Return((*github.Repository)(nil), &github.Response{}, &github.AcceptedError{Raw: []byte(`{"name": "hello-fork", "owner": {"login": "octocat"}}`)})
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "hello-fork").Return(repoAt("octocat", "hello-fork"), &github.Response{}, nil)
+
+	fork, err := CreateFork(context.Background(), mockClient, "octo-org", "hello", ForkRequest{Name: "hello-fork", DefaultBranchOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, "hello-fork", fork.GetName())
+}
+
+// TestListForksDivergence tests that each fork is compared with the repository, and comparison errors are kept per fork.
+func TestListForksDivergence(t *testing.T) {
+	upstream := repoAt("octo-org", "hello")
+	upstream.DefaultBranch = github.String("main")
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListForks", mock.Anything, "octo-org", "hello", mock.Anything).Return([]*github.Repository{
+		forkOf("octocat", "hello", nil), forkOf("gone", "hello", nil),
+	}, &github.Response{}, nil)
+	mockClient.On("GetRepositories", mock.Anything, "octo-org", "hello").Return(upstream, &github.Response{}, nil)
+	mockClient.On("CompareCommits", mock.Anything, "octo-org", "hello", "main", "octocat:main", mock.Anything).
+		Return(&github.CommitsComparison{Status: github.String("diverged"), AheadBy: github.Int(2), BehindBy: github.Int(3)}, &github.Response{}, nil)
+	mockClient.On("CompareCommits", mock.Anything, "octo-org", "hello", "main", "gone:main", mock.Anything).
+		Return((*github.CommitsComparison)(nil), &github.Response{}, errors.New("no common ancestor"))
+
+	forks, _, err := ListForks(context.Background(), mockClient, "octo-org", "hello", &github.RepositoryListForksOptions{}, true)
+	require.NoError(t, err)
+	require.Len(t, forks, 2)
+	assert.Equal(t, &ForkDivergence{
+		Fork: "octocat/hello", Branch: "main", Upstream: "octo-org/hello", UpstreamBranch: "main",
+		Status: "diverged", AheadBy: 2, BehindBy: 3,
+	}, forks[0].Divergence)
+	assert.Nil(t, forks[1].Divergence)
+	assert.NotEmpty(t, forks[1].Error)
+}
+
+// TestSyncForkConflict tests that a conflicting sync reports the divergence of the branch.
+func TestSyncForkConflict(t *testing.T) {
+	upstream := repoAt("octo-org", "hello")
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "hello").Return(forkOf("octocat", "hello", upstream), &github.Response{}, nil)
+	mockClient.On("MergeUpstream", mock.Anything, "octocat", "hello", &github.RepoMergeUpstreamRequest{Branch: github.String("develop")}).
+		Return((*github.RepoMergeUpstreamResult)(nil), &github.Response{}, &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusConflict}, Message: "merge conflict"})
+	mockClient.On("CompareCommits", mock.Anything, "octo-org", "hello", "develop", "octocat:develop", mock.Anything).
+		Return(&github.CommitsComparison{Status: github.String("diverged"), AheadBy: github.Int(1), BehindBy: github.Int(4)}, &github.Response{}, nil)
+
+	_, err := SyncFork(context.Background(), mockClient, "octocat", "hello", "develop")
+	assert.ErrorIs(t, err, ErrSyncConflict)
+	assert.Contains(t, err.Error(), "1 commits ahead of and 4 behind octo-org/hello:develop")
+
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "source").Return(repoAt("octocat", "source"), &github.Response{}, nil)
+	_, err = SyncFork(context.Background(), mockClient, "octocat", "source", "")
+	assert.True(t, errors.Is(err, ErrNotAFork))
+}
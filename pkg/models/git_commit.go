@@ -0,0 +1,190 @@
+package models
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"net/http"
+	"path"
+	"strings"
+)
+
+const (
+	// commitAttempts is the number of times a multi-file commit is rebuilt on a branch that moved.
+	commitAttempts = 3
+	// blobConcurrency is the number of blobs uploaded concurrently.
+	blobConcurrency = 4
+)
+
+// ErrBranchMoved is returned when a branch kept moving while a multi-file commit was created on it.
+var ErrBranchMoved = errors.New("branch moved while committing")
+
+// FileChange is one file added, modified or deleted by a multi-file commit.
+// Content is plain text unless Encoding is "base64", which allows binary files. Mode is
+// "100644" (default) for a regular file or "100755" for an executable one.
+type FileChange struct {
+	Path     string `json:"path"`
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+	Mode     string `json:"mode"`
+	Delete   bool   `json:"delete"`
+}
+
+// CommitRequest is the payload accepted when creating a single commit changing several files.
+type CommitRequest struct {
+	Branch    string               `json:"branch"`
+	Message   string               `json:"message"`
+	Files     []FileChange         `json:"files"`
+	Author    *github.CommitAuthor `json:"author"`
+	Committer *github.CommitAuthor `json:"committer"`
+}
+
+// Missing returns the names of the required fields missing from the request.
+//
+// Returns:
+//   - []string: The missing fields, empty if the request is complete.
+func (r CommitRequest) Missing() []string {
+	var missing []string
+	if r.Branch == "" {
+		missing = append(missing, "branch")
+	}
+	if r.Message == "" {
+		missing = append(missing, "message")
+	}
+	if len(r.Files) == 0 {
+		missing = append(missing, "files")
+	}
+	return missing
+}
+
+// Validate checks the paths, encodings and modes of the changed files.
+//
+// Returns:
+//   - error: An error describing the first invalid file, or nil if all files are valid.
+func (r CommitRequest) Validate() error {
+	seen := make(map[string]bool, len(r.Files))
+	for _, file := range r.Files {
+		if file.Path == "" || strings.HasPrefix(file.Path, "/") || path.Clean(file.Path) != file.Path ||
+			file.Path == "." || file.Path == ".." || strings.HasPrefix(file.Path, "../") {
+			return fmt.Errorf("invalid path %q", file.Path)
+		}
+		if seen[file.Path] {
+			return fmt.Errorf("duplicate path %q", file.Path)
+		}
+		seen[file.Path] = true
+		if file.Delete {
+			continue
+		}
+		if err := oneOf("encoding", file.Encoding, "utf-8", "base64"); err != nil {
+			return fmt.Errorf("%s: %w", file.Path, err)
+		}
+		if err := oneOf("mode", file.Mode, "100644", "100755"); err != nil {
+			return fmt.Errorf("%s: %w", file.Path, err)
+		}
+		if file.Encoding == "base64" {
+			if _, err := base64.StdEncoding.DecodeString(file.Content); err != nil {
+				return fmt.Errorf("%s: invalid content: %w", file.Path, err)
+			}
+		}
+	}
+	return nil
+}
+
+// CreateCommit creates a single commit changing several files on a branch through the Git Data API.
+// The blobs are uploaded first, then a tree is built on the tree of the branch head, committed
+// with the head as parent, and the branch is fast-forwarded to the commit. If the branch moved
+// in between, the tree and commit are rebuilt on the new head, up to commitAttempts times, so
+// the change is applied atomically and never overwrites commits pushed concurrently.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - req: The branch, message and changed files of the commit.
+//
+// Returns:
+//   - *github.Commit: The created commit.
+//   - error: An error wrapping ErrBranchMoved if the branch never stood still, or the GitHub error.
+func CreateCommit(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req CommitRequest) (*github.Commit, error) {
+	entries, err := createBlobs(ctx, client, owner, repo, req.Files)
+	if err != nil {
+		return nil, err
+	}
+	ref := "refs/heads/" + req.Branch
+	for attempt := 0; attempt < commitAttempts; attempt++ {
+		head, _, err := client.GetRef(ctx, owner, repo, ref)
+		if err != nil {
+			return nil, err
+		}
+		parent, _, err := client.GetGitCommit(ctx, owner, repo, head.GetObject().GetSHA())
+		if err != nil {
+			return nil, err
+		}
+		tree, _, err := client.CreateTree(ctx, owner, repo, parent.GetTree().GetSHA(), entries)
+		if err != nil {
+			return nil, err
+		}
+		commit, _, err := client.CreateGitCommit(ctx, owner, repo, &github.Commit{
+			Message:   github.String(req.Message),
+			Tree:      &github.Tree{SHA: tree.SHA},
+			Parents:   []*github.Commit{{SHA: parent.SHA}},
+			Author:    req.Author,
+			Committer: req.Committer,
+		})
+		if err != nil {
+			return nil, err
+		}
+		_, _, err = client.UpdateRef(ctx, owner, repo, &github.Reference{
+			Ref:    github.String(ref),
+			Object: &github.GitObject{SHA: commit.SHA},
+		}, false)
+		if err == nil {
+			return commit, nil
+		}
+		if !isNotFastForward(err) {
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("%w: %s changed %d times", ErrBranchMoved, req.Branch, commitAttempts)
+}
+
+// createBlobs uploads the content of the added and modified files and returns the tree entries
+// of all changes. Blobs do not depend on the branch head, so they survive a retry.
+func createBlobs(ctx context.Context, client interfaces.GitHubClient, owner, repo string, files []FileChange) ([]*github.TreeEntry, error) {
+	entries := make([]*github.TreeEntry, len(files))
+	err := forEachLimit(len(files), blobConcurrency, func(i int) error {
+		file := files[i]
+		entry := &github.TreeEntry{Path: github.String(file.Path), Mode: github.String("100644"), Type: github.String("blob")}
+		entries[i] = entry
+		if file.Delete {
+			// An entry without SHA nor content removes the path from the tree.
+			return nil
+		}
+		if file.Mode != "" {
+			entry.Mode = github.String(file.Mode)
+		}
+		content := file.Content
+		if file.Encoding != "base64" {
+			content = base64.StdEncoding.EncodeToString([]byte(file.Content))
+		}
+		blob, _, err := client.CreateBlob(ctx, owner, repo, &github.Blob{Content: github.String(content), Encoding: github.String("base64")})
+		if err != nil {
+			return err
+		}
+		entry.SHA = blob.SHA
+		return nil
+	})
+	return entries, err
+}
+
+// isNotFastForward reports whether err is the answer of the GitHub API to a reference update
+// that is not a fast-forward.
+func isNotFastForward(err error) bool {
+	var ghErr *github.ErrorResponse
+	return errors.As(err, &ghErr) && ghErr.Response != nil && ghErr.Response.StatusCode == http.StatusUnprocessableEntity &&
+		strings.Contains(strings.ToLower(ghErr.Message), "fast forward")
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"context"
+	"encoding/base64"
+	"github-api/pkg/mocks"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// headAt returns the reference of the main branch at the given commit.
+func headAt(sha string) *github.Reference {
+	return &github.Reference{Ref: github.String("refs/heads/main"), Object: &github.GitObject{SHA: github.String(sha)}}
+}
+
+// notFastForward is the error GitHub answers when a reference update is not a fast-forward.
+var notFastForward = &github.ErrorResponse{
+	Response: &http.Response{StatusCode: http.StatusUnprocessableEntity},
+	Message:  "Update is not a fast forward",
+}
+
+// TestCommitRequestValidate tests the validation of the changed files.
+func TestCommitRequestValidate(t *testing.T) {
+	assert.Equal(t, []string{"branch", "message", "files"}, CommitRequest{}.Missing())
+
+	valid := CommitRequest{Branch: "main", Message: "Update", Files: []FileChange{
+		{Path: "README.md", Content: "hello"},
+		{Path: "bin/run", Content: "IyEvYmluL3No", Encoding: "base64", Mode: "100755"},
+		{Path: "old.txt", Delete: true},
+	}}
+	assert.NoError(t, valid.Validate())
+
+	for _, file := range []FileChange{
+		{Path: "/abs"},
+		{Path: "a/../b"},
+		{Path: "../escape"},
+		{Path: "dir/"},
+		{Path: "a", Encoding: "latin1"},
+		{Path: "a", Mode: "040000"},
+		{Path: "a", Content: "not base64!", Encoding: "base64"},
+	} {
+		assert.Error(t, CommitRequest{Files: []FileChange{file}}.Validate(), file.Path)
+	}
+	assert.Error(t, CommitRequest{Files: []FileChange{{Path: "a"}, {Path: "a", Delete: true}}}.Validate())
+}
+
+// TestCreateCommitRetriesOnMovedBranch tests that the commit is rebuilt on the new head when the branch moved.
+func TestCreateCommitRetriesOnMovedBranch(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateBlob", mock.Anything, "octocat", "hello", &github.Blob{
+		Content:  github.String(base64.StdEncoding.EncodeToString([]byte("hello"))),
+		Encoding: github.String("base64"),
+	}).Return(&github.Blob{SHA: github.String("blob1")}, &github.Response{}, nil).Once()
+
+	entries := []*github.TreeEntry{
+		{Path: github.String("README.md"), Mode: github.String("100644"), Type: github.String("blob"), SHA: github.String("blob1")},
+		{Path: github.String("old.txt"), Mode: github.String("100644"), Type: github.String("blob")},
+	}
+	mockClient.On("GetRef", mock.Anything, "octocat", "hello", "refs/heads/main").Return(headAt("c1"), &github.Response{}, nil).Once()
+	mockClient.On("GetRef", mock.Anything, "octocat", "hello", "refs/heads/main").Return(headAt("c2"), &github.Response{}, nil).Once()
+	for _, sha := range []string{"c1", "c2"} {
+		mockClient.On("GetGitCommit", mock.Anything, "octocat", "hello", sha).Return(
+			&github.Commit{SHA: github.String(sha), Tree: &github.Tree{SHA: github.String("tree-" + sha)}}, &github.Response{}, nil)
+		mockClient.On("CreateTree", mock.Anything, "octocat", "hello", "tree-"+sha, entries).Return(
+			&github.Tree{SHA: github.String("new-tree-" + sha)}, &github.Response{}, nil)
+		mockClient.On("CreateGitCommit", mock.Anything, "octocat", "hello", mock.MatchedBy(func(commit *github.Commit) bool {
+			return commit.GetTree().GetSHA() == "new-tree-"+sha && commit.Parents[0].GetSHA() == sha && commit.GetMessage() == "Update"
+		})).Return(&github.Commit{SHA: github.String("on-" + sha)}, &github.Response{}, nil)
+	}
+	mockClient.On("UpdateRef", mock.Anything, "octocat", "hello", mock.MatchedBy(func(ref *github.Reference) bool {
+		return ref.GetObject().GetSHA() == "on-c1"
+	}), false).Return((*github.Reference)(nil), (*github.Response)(nil), notFastForward)
+	mockClient.On("UpdateRef", mock.Anything, "octocat", "hello", mock.MatchedBy(func(ref *github.Reference) bool {
+		return ref.GetRef() == "refs/heads/main" && ref.GetObject().GetSHA() == "on-c2"
+	}), false).Return(headAt("on-c2"), &github.Response{}, nil)
+
+	commit, err := CreateCommit(context.Background(), mockClient, "octocat", "hello", CommitRequest{
+		Branch:  "main",
+		Message: "Update",
+		Files:   []FileChange{{Path: "README.md", Content: "hello"}, {Path: "old.txt", Delete: true}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "on-c2", commit.GetSHA())
+	mockClient.AssertExpectations(t)
+}
+
+// TestCreateCommitBranchKeepsMoving tests that the commit is abandoned when the branch never stands still.
+func TestCreateCommitBranchKeepsMoving(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRef", mock.Anything, "octocat", "hello", "refs/heads/main").Return(headAt("c1"), &github.Response{}, nil)
+	mockClient.On("GetGitCommit", mock.Anything, "octocat", "hello", "c1").Return(
+		&github.Commit{SHA: github.String("c1"), Tree: &github.Tree{SHA: github.String("t1")}}, &github.Response{}, nil)
+	mockClient.On("CreateTree", mock.Anything, "octocat", "hello", "t1", mock.Anything).Return(&github.Tree{SHA: github.String("t2")}, &github.Response{}, nil)
+	mockClient.On("CreateGitCommit", mock.Anything, "octocat", "hello", mock.Anything).Return(&github.Commit{SHA: github.String("c2")}, &github.Response{}, nil)
+	mockClient.On("UpdateRef", mock.Anything, "octocat", "hello", mock.Anything, false).Return((*github.Reference)(nil), (*github.Response)(nil), notFastForward)
+
+	_, err := CreateCommit(context.Background(), mockClient, "octocat", "hello", CommitRequest{
+		Branch: "main", Message: "Remove", Files: []FileChange{{Path: "old.txt", Delete: true}},
+	})
+	assert.ErrorIs(t, err, ErrBranchMoved)
+	mockClient.AssertNumberOfCalls(t, "UpdateRef", commitAttempts)
+	mockClient.AssertNotCalled(t, "CreateBlob", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
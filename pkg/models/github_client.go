@@ -2,7 +2,14 @@ package models
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
 	"github.com/google/go-github/v50/github"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
 )
 
 // GitHubClientWrapper is a wrapper around the GitHub client to provide
@@ -86,3 +93,1681 @@ func (w *GitHubClientWrapper) ListPullRequests(ctx context.Context, owner, repo
 func (w *GitHubClientWrapper) ListRepos(ctx context.Context, owner string, opt *github.RepositoryListOptions) ([]*github.Repository, *github.Response, error) {
 	return w.Client.Repositories.List(ctx, owner, opt)
 }
+
+// ListIssuesByRepo lists issues for a specified repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opt: Options for filtering and paginating the issues.
+// Returns:
+// - A slice of pointers to the listed issues.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListIssuesByRepo(ctx context.Context, owner, repo string, opt *github.IssueListByRepoOptions) ([]*github.Issue, *github.Response, error) {
+	return w.Client.Issues.ListByRepo(ctx, owner, repo, opt)
+}
+
+// GetIssue retrieves a single issue by its number.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue.
+// Returns:
+// - A pointer to the retrieved issue.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetIssue(ctx context.Context, owner, repo string, number int) (*github.Issue, *github.Response, error) {
+	return w.Client.Issues.Get(ctx, owner, repo, number)
+}
+
+// CreateIssue creates a new issue in the specified repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - issue: The fields of the issue to be created.
+// Returns:
+// - A pointer to the created issue.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateIssue(ctx context.Context, owner, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return w.Client.Issues.Create(ctx, owner, repo, issue)
+}
+
+// EditIssue edits an existing issue, including its state.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue.
+// - issue: The fields of the issue to be updated.
+// Returns:
+// - A pointer to the updated issue.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditIssue(ctx context.Context, owner, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	return w.Client.Issues.Edit(ctx, owner, repo, number, issue)
+}
+
+// ListIssueComments lists the comments on an issue.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue.
+// - opt: Options for filtering and paginating the comments.
+// Returns:
+// - A slice of pointers to the listed comments.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListIssueComments(ctx context.Context, owner, repo string, number int, opt *github.IssueListCommentsOptions) ([]*github.IssueComment, *github.Response, error) {
+	return w.Client.Issues.ListComments(ctx, owner, repo, number, opt)
+}
+
+// GetIssueComment retrieves a single issue comment by its ID.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - commentID: The ID of the comment.
+// Returns:
+// - A pointer to the retrieved comment.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetIssueComment(ctx context.Context, owner, repo string, commentID int64) (*github.IssueComment, *github.Response, error) {
+	return w.Client.Issues.GetComment(ctx, owner, repo, commentID)
+}
+
+// CreateIssueComment adds a comment to an issue.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue.
+// - comment: The comment to be created.
+// Returns:
+// - A pointer to the created comment.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateIssueComment(ctx context.Context, owner, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return w.Client.Issues.CreateComment(ctx, owner, repo, number, comment)
+}
+
+// EditIssueComment updates the body of an issue comment.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - commentID: The ID of the comment.
+// - comment: The updated comment.
+// Returns:
+// - A pointer to the updated comment.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditIssueComment(ctx context.Context, owner, repo string, commentID int64, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	return w.Client.Issues.EditComment(ctx, owner, repo, commentID, comment)
+}
+
+// DeleteIssueComment deletes an issue comment.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - commentID: The ID of the comment.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteIssueComment(ctx context.Context, owner, repo string, commentID int64) (*github.Response, error) {
+	return w.Client.Issues.DeleteComment(ctx, owner, repo, commentID)
+}
+
+// ListLabels lists the labels defined in a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opt: Options for paginating the labels.
+// Returns:
+// - A slice of pointers to the listed labels.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListLabels(ctx context.Context, owner, repo string, opt *github.ListOptions) ([]*github.Label, *github.Response, error) {
+	return w.Client.Issues.ListLabels(ctx, owner, repo, opt)
+}
+
+// CreateLabel creates a label in a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - label: The name, color and description of the label.
+// Returns:
+// - A pointer to the created label.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateLabel(ctx context.Context, owner, repo string, label *github.Label) (*github.Label, *github.Response, error) {
+	return w.Client.Issues.CreateLabel(ctx, owner, repo, label)
+}
+
+// EditLabel updates a label, renaming it when the name of label differs from name.
+// Issues and pull requests keep the label when it is renamed.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - name: The current name of the label.
+// - label: The new name, color and description of the label.
+// Returns:
+// - A pointer to the updated label.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditLabel(ctx context.Context, owner, repo, name string, label *github.Label) (*github.Label, *github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/labels/%v", owner, repo, url.PathEscape(name))
+	body := struct {
+		NewName     *string `json:"new_name,omitempty"`
+		Color       *string `json:"color,omitempty"`
+		Description *string `json:"description,omitempty"`
+	}{label.Name, label.Color, label.Description}
+
+	req, err := w.Client.NewRequest(http.MethodPatch, u, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	updated := new(github.Label)
+	resp, err := w.Client.Do(ctx, req, updated)
+	if err != nil {
+		return nil, resp, err
+	}
+	return updated, resp, nil
+}
+
+// DeleteLabel deletes a label from a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - name: The name of the label.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteLabel(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	return w.Client.Issues.DeleteLabel(ctx, owner, repo, name)
+}
+
+// ListMilestones lists the milestones of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opt: Options for filtering, sorting and paginating the milestones.
+// Returns:
+// - A slice of pointers to the listed milestones.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListMilestones(ctx context.Context, owner, repo string, opt *github.MilestoneListOptions) ([]*github.Milestone, *github.Response, error) {
+	return w.Client.Issues.ListMilestones(ctx, owner, repo, opt)
+}
+
+// GetMilestone retrieves a single milestone by its number.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the milestone.
+// Returns:
+// - A pointer to the retrieved milestone.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetMilestone(ctx context.Context, owner, repo string, number int) (*github.Milestone, *github.Response, error) {
+	return w.Client.Issues.GetMilestone(ctx, owner, repo, number)
+}
+
+// CreateMilestone creates a milestone in a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - milestone: The title, description, state and due date of the milestone.
+// Returns:
+// - A pointer to the created milestone.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateMilestone(ctx context.Context, owner, repo string, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	return w.Client.Issues.CreateMilestone(ctx, owner, repo, milestone)
+}
+
+// EditMilestone updates a milestone.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the milestone.
+// - milestone: The fields of the milestone to be updated.
+// Returns:
+// - A pointer to the updated milestone.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditMilestone(ctx context.Context, owner, repo string, number int, milestone *github.Milestone) (*github.Milestone, *github.Response, error) {
+	return w.Client.Issues.EditMilestone(ctx, owner, repo, number, milestone)
+}
+
+// DeleteMilestone deletes a milestone from a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the milestone.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteMilestone(ctx context.Context, owner, repo string, number int) (*github.Response, error) {
+	return w.Client.Issues.DeleteMilestone(ctx, owner, repo, number)
+}
+
+// GetPullRequest retrieves a single pull request by its number.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// Returns:
+// - A pointer to the retrieved pull request.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetPullRequest(ctx context.Context, owner, repo string, number int) (*github.PullRequest, *github.Response, error) {
+	return w.Client.PullRequests.Get(ctx, owner, repo, number)
+}
+
+// CreatePullRequest opens a new pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - pull: The head, base, title, body and draft flag of the pull request.
+// Returns:
+// - A pointer to the created pull request.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreatePullRequest(ctx context.Context, owner, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error) {
+	return w.Client.PullRequests.Create(ctx, owner, repo, pull)
+}
+
+// EditPullRequest updates the title, body, state, base or maintainer_can_modify flag of a pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - pull: The fields of the pull request to be updated.
+// Returns:
+// - A pointer to the updated pull request.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditPullRequest(ctx context.Context, owner, repo string, number int, pull *github.PullRequest) (*github.PullRequest, *github.Response, error) {
+	return w.Client.PullRequests.Edit(ctx, owner, repo, number, pull)
+}
+
+// RequestReviewers requests reviews on a pull request from users and teams.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - reviewers: The logins of the users and the slugs of the teams to request.
+// Returns:
+// - A pointer to the updated pull request.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) RequestReviewers(ctx context.Context, owner, repo string, number int, reviewers github.ReviewersRequest) (*github.PullRequest, *github.Response, error) {
+	return w.Client.PullRequests.RequestReviewers(ctx, owner, repo, number, reviewers)
+}
+
+// MergePullRequest merges a pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - commitMessage: The extra detail of the merge commit message, or empty for the default.
+// - options: The merge method, commit title and the SHA the head must match.
+// Returns:
+// - A pointer to the result of the merge.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) MergePullRequest(ctx context.Context, owner, repo string, number int, commitMessage string, options *github.PullRequestOptions) (*github.PullRequestMergeResult, *github.Response, error) {
+	return w.Client.PullRequests.Merge(ctx, owner, repo, number, commitMessage, options)
+}
+
+// ListPullRequestFiles lists the files changed by a pull request, with their patch and line stats.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - opts: The pagination options.
+// Returns:
+// - A slice of the changed files.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListPullRequestFiles(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+	return w.Client.PullRequests.ListFiles(ctx, owner, repo, number, opts)
+}
+
+// ListPullRequestCommits lists the commits of a pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - opts: The pagination options.
+// Returns:
+// - A slice of the commits.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListPullRequestCommits(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return w.Client.PullRequests.ListCommits(ctx, owner, repo, number, opts)
+}
+
+// ListPullRequestReviews lists the reviews of a pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - opts: The pagination options.
+// Returns:
+// - A slice of the reviews.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListPullRequestReviews(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+	return w.Client.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+}
+
+// ListPullRequestComments lists the review comments of a pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - opts: The sorting and pagination options.
+// Returns:
+// - A slice of the review comments.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListPullRequestComments(ctx context.Context, owner, repo string, number int, opts *github.PullRequestListCommentsOptions) ([]*github.PullRequestComment, *github.Response, error) {
+	return w.Client.PullRequests.ListComments(ctx, owner, repo, number, opts)
+}
+
+// GetCombinedStatus retrieves the combined commit status of a reference.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The SHA, branch or tag name of the commit.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the combined status and its individual statuses.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetCombinedStatus(ctx context.Context, owner, repo, ref string, opts *github.ListOptions) (*github.CombinedStatus, *github.Response, error) {
+	return w.Client.Repositories.GetCombinedStatus(ctx, owner, repo, ref, opts)
+}
+
+// ListCheckRunsForRef lists the check runs of a reference.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The SHA, branch or tag name of the commit.
+// - opts: The filters and pagination options.
+// Returns:
+// - A pointer to the total count and the check runs.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListCheckRunsForRef(ctx context.Context, owner, repo, ref string, opts *github.ListCheckRunsOptions) (*github.ListCheckRunsResults, *github.Response, error) {
+	return w.Client.Checks.ListCheckRunsForRef(ctx, owner, repo, ref, opts)
+}
+
+// ListTeamRepos lists the repositories a team of an organization has access to.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - slug: The slug of the team.
+// - opts: The pagination options.
+// Returns:
+// - A slice of the repositories of the team.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListTeamRepos(ctx context.Context, org, slug string, opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+	return w.Client.Teams.ListTeamReposBySlug(ctx, org, slug, opts)
+}
+
+// AddLabelsToIssue adds labels to an issue or pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue or pull request.
+// - labels: The names of the labels to add.
+// Returns:
+// - A slice of the labels of the issue after the change.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) AddLabelsToIssue(ctx context.Context, owner, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	return w.Client.Issues.AddLabelsToIssue(ctx, owner, repo, number, labels)
+}
+
+// RemoveLabelForIssue removes a label from an issue or pull request.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue or pull request.
+// - label: The name of the label to remove.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) RemoveLabelForIssue(ctx context.Context, owner, repo string, number int, label string) (*github.Response, error) {
+	return w.Client.Issues.RemoveLabelForIssue(ctx, owner, repo, number, label)
+}
+
+// ListIssueEvents lists the events of an issue or pull request, such as labels being added or removed.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the issue or pull request.
+// - opts: The pagination options.
+// Returns:
+// - A slice of the events of the issue.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListIssueEvents(ctx context.Context, owner, repo string, number int, opts *github.ListOptions) ([]*github.IssueEvent, *github.Response, error) {
+	return w.Client.Issues.ListIssueEvents(ctx, owner, repo, number, opts)
+}
+
+// GetRequiredStatusChecks retrieves the status checks required by the protection of a branch.
+// github.ErrBranchNotProtected is returned if the branch is not protected.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - branch: The name of the protected branch.
+// Returns:
+// - A pointer to the required status checks.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetRequiredStatusChecks(ctx context.Context, owner, repo, branch string) (*github.RequiredStatusChecks, *github.Response, error) {
+	return w.Client.Repositories.GetRequiredStatusChecks(ctx, owner, repo, branch)
+}
+
+// UpdatePullRequestBranch merges the base branch into the head branch of a pull request.
+// GitHub updates the branch asynchronously; its 202 Accepted answer is not reported as an error.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - number: The number of the pull request.
+// - opts: The head SHA the branch is expected to be at.
+// Returns:
+// - A pointer to the message returned by GitHub.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdatePullRequestBranch(ctx context.Context, owner, repo string, number int, opts *github.PullRequestBranchUpdateOptions) (*github.PullRequestBranchUpdateResponse, *github.Response, error) {
+	result, resp, err := w.Client.PullRequests.UpdateBranch(ctx, owner, repo, number, opts)
+	var accepted *github.AcceptedError
+	if errors.As(err, &accepted) {
+		return &github.PullRequestBranchUpdateResponse{Message: github.String("Updating pull request branch.")}, resp, nil
+	}
+	return result, resp, err
+}
+
+// ListReleases lists the releases of a repository, including drafts visible to the token.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: Options for paginating the results.
+// Returns:
+// - A slice of pointers to the listed releases.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListReleases(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryRelease, *github.Response, error) {
+	return w.Client.Repositories.ListReleases(ctx, owner, repo, opts)
+}
+
+// GetRelease retrieves a single release by its ID.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release.
+// Returns:
+// - A pointer to the retrieved release.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetRelease(ctx context.Context, owner, repo string, id int64) (*github.RepositoryRelease, *github.Response, error) {
+	return w.Client.Repositories.GetRelease(ctx, owner, repo, id)
+}
+
+// GetReleaseByTag retrieves the published release of a tag.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - tag: The name of the tag.
+// Returns:
+// - A pointer to the retrieved release.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*github.RepositoryRelease, *github.Response, error) {
+	return w.Client.Repositories.GetReleaseByTag(ctx, owner, repo, tag)
+}
+
+// CreateRelease creates a release in a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - release: The tag, target, name, body and flags of the release.
+// Returns:
+// - A pointer to the created release.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateRelease(ctx context.Context, owner, repo string, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	return w.Client.Repositories.CreateRelease(ctx, owner, repo, release)
+}
+
+// EditRelease updates a release.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release.
+// - release: The fields of the release to be updated.
+// Returns:
+// - A pointer to the updated release.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditRelease(ctx context.Context, owner, repo string, id int64, release *github.RepositoryRelease) (*github.RepositoryRelease, *github.Response, error) {
+	return w.Client.Repositories.EditRelease(ctx, owner, repo, id, release)
+}
+
+// DeleteRelease deletes a release. The tag of the release is kept.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteRelease(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return w.Client.Repositories.DeleteRelease(ctx, owner, repo, id)
+}
+
+// ListReleaseAssets lists the assets of a release.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release.
+// - opts: Options for paginating the results.
+// Returns:
+// - A slice of pointers to the listed assets.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListReleaseAssets(ctx context.Context, owner, repo string, id int64, opts *github.ListOptions) ([]*github.ReleaseAsset, *github.Response, error) {
+	return w.Client.Repositories.ListReleaseAssets(ctx, owner, repo, id, opts)
+}
+
+// GetReleaseAsset retrieves the metadata of a release asset.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release asset.
+// Returns:
+// - A pointer to the retrieved asset.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetReleaseAsset(ctx context.Context, owner, repo string, id int64) (*github.ReleaseAsset, *github.Response, error) {
+	return w.Client.Repositories.GetReleaseAsset(ctx, owner, repo, id)
+}
+
+// UploadReleaseAsset uploads a file as an asset of a release.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release.
+// - opts: The name, label and media type of the asset.
+// - file: The file to upload.
+// Returns:
+// - A pointer to the uploaded asset.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UploadReleaseAsset(ctx context.Context, owner, repo string, id int64, opts *github.UploadOptions, file *os.File) (*github.ReleaseAsset, *github.Response, error) {
+	return w.Client.Repositories.UploadReleaseAsset(ctx, owner, repo, id, opts, file)
+}
+
+// DownloadReleaseAsset downloads the content of a release asset.
+// Redirects to the storage holding the asset are followed.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release asset.
+// Returns:
+// - The content of the asset, which the caller must close.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DownloadReleaseAsset(ctx context.Context, owner, repo string, id int64) (io.ReadCloser, error) {
+	rc, _, err := w.Client.Repositories.DownloadReleaseAsset(ctx, owner, repo, id, http.DefaultClient)
+	return rc, err
+}
+
+// DeleteReleaseAsset deletes a release asset.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the release asset.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteReleaseAsset(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return w.Client.Repositories.DeleteReleaseAsset(ctx, owner, repo, id)
+}
+
+// ListTags lists the tags of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: Options for paginating the results.
+// Returns:
+// - A slice of pointers to the listed tags.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListTags(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.RepositoryTag, *github.Response, error) {
+	return w.Client.Repositories.ListTags(ctx, owner, repo, opts)
+}
+
+// CreateTag creates an annotated tag object. The tag is only visible once a reference points to it.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - tag: The name, message and object of the tag.
+// Returns:
+// - A pointer to the created tag object.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateTag(ctx context.Context, owner, repo string, tag *github.Tag) (*github.Tag, *github.Response, error) {
+	return w.Client.Git.CreateTag(ctx, owner, repo, tag)
+}
+
+// CreateRef creates a Git reference, such as refs/tags/v1.0.0.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The full name of the reference and the object it points to.
+// Returns:
+// - A pointer to the created reference.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateRef(ctx context.Context, owner, repo string, ref *github.Reference) (*github.Reference, *github.Response, error) {
+	return w.Client.Git.CreateRef(ctx, owner, repo, ref)
+}
+
+// CompareCommits compares two commits, branches or tags.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - base: The commit, branch or tag to compare from.
+// - head: The commit, branch or tag to compare to.
+// - opts: Options for paginating the results.
+// Returns:
+// - A pointer to the comparison, with one page of its commits.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CompareCommits(ctx context.Context, owner, repo, base, head string, opts *github.ListOptions) (*github.CommitsComparison, *github.Response, error) {
+	return w.Client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+}
+
+// ListCommits lists the commits of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: Options for filtering the commits by ref, path, author and date, and paginating them.
+// Returns:
+// - A slice of pointers to the listed commits.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListCommits(ctx context.Context, owner, repo string, opts *github.CommitsListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+	return w.Client.Repositories.ListCommits(ctx, owner, repo, opts)
+}
+
+// GetCommit retrieves a single commit with its stats and changed files.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - sha: The SHA, branch or tag of the commit.
+// - opts: Options for paginating the changed files.
+// Returns:
+// - A pointer to the retrieved commit.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetCommit(ctx context.Context, owner, repo, sha string, opts *github.ListOptions) (*github.RepositoryCommit, *github.Response, error) {
+	return w.Client.Repositories.GetCommit(ctx, owner, repo, sha, opts)
+}
+
+// ListPullRequestsWithCommit lists the pull requests a commit belongs to.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - sha: The SHA of the commit.
+// - opts: Options for paginating the pull requests.
+// Returns:
+// - A slice of pointers to the listed pull requests.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListPullRequestsWithCommit(ctx context.Context, owner, repo, sha string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error) {
+	return w.Client.PullRequests.ListPullRequestsWithCommit(ctx, owner, repo, sha, opts)
+}
+
+// GetContents retrieves a file or the entries of a directory at a ref.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - path: The path of the file or directory in the repository.
+// - opts: The ref to read, defaulting to the default branch.
+// Returns:
+// - A pointer to the file, or nil if the path is a directory.
+// - The entries of the directory, or nil if the path is a file.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
+	return w.Client.Repositories.GetContents(ctx, owner, repo, path, opts)
+}
+
+// DownloadContents downloads the raw content of a file at a ref, including files too large for GetContents.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - path: The path of the file in the repository.
+// - opts: The ref to read, defaulting to the default branch.
+// Returns:
+// - The content of the file, which the caller must close.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DownloadContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (io.ReadCloser, *github.Response, error) {
+	return w.Client.Repositories.DownloadContents(ctx, owner, repo, path, opts)
+}
+
+// CreateFile creates a file in a repository with a new commit.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - path: The path of the file in the repository.
+// - opts: The commit message, content, expected file SHA and target branch.
+// Returns:
+// - A pointer to the created file and commit.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return w.Client.Repositories.CreateFile(ctx, owner, repo, path, opts)
+}
+
+// UpdateFile replaces the content of a file with a new commit.
+// GitHub answers 409 Conflict if opts.SHA is not the current SHA of the file.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - path: The path of the file in the repository.
+// - opts: The commit message, content, expected file SHA and target branch.
+// Returns:
+// - A pointer to the updated file and commit.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return w.Client.Repositories.UpdateFile(ctx, owner, repo, path, opts)
+}
+
+// DeleteFile deletes a file with a new commit.
+// GitHub answers 409 Conflict if opts.SHA is not the current SHA of the file.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - path: The path of the file in the repository.
+// - opts: The commit message, content, expected file SHA and target branch.
+// Returns:
+// - A pointer to the commit deleting the file.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error) {
+	return w.Client.Repositories.DeleteFile(ctx, owner, repo, path, opts)
+}
+
+// GetArchiveLink retrieves the download link of a tarball or zipball of a repository at a ref.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - format: The archive format: github.Tarball or github.Zipball.
+// - opts: The ref to archive, defaulting to the default branch.
+// Returns:
+// - The temporary download link of the archive.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetArchiveLink(ctx context.Context, owner, repo string, format github.ArchiveFormat, opts *github.RepositoryContentGetOptions) (*url.URL, *github.Response, error) {
+	return w.Client.Repositories.GetArchiveLink(ctx, owner, repo, format, opts, true)
+}
+
+// GetRef retrieves a Git reference, such as heads/main.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The name of the reference, with or without the refs/ prefix.
+// Returns:
+// - A pointer to the reference and the object it points to.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetRef(ctx context.Context, owner, repo, ref string) (*github.Reference, *github.Response, error) {
+	return w.Client.Git.GetRef(ctx, owner, repo, ref)
+}
+
+// UpdateRef points a Git reference to another object.
+// Without force, GitHub answers 422 Unprocessable Entity if the update is not a fast-forward.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The full name of the reference and the object it must point to.
+// - force: Whether to allow an update that is not a fast-forward.
+// Returns:
+// - A pointer to the updated reference.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateRef(ctx context.Context, owner, repo string, ref *github.Reference, force bool) (*github.Reference, *github.Response, error) {
+	return w.Client.Git.UpdateRef(ctx, owner, repo, ref, force)
+}
+
+// GetGitCommit retrieves a Git commit object, with its tree and parents.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - sha: The SHA of the commit.
+// Returns:
+// - A pointer to the commit.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetGitCommit(ctx context.Context, owner, repo, sha string) (*github.Commit, *github.Response, error) {
+	return w.Client.Git.GetCommit(ctx, owner, repo, sha)
+}
+
+// CreateGitCommit creates a Git commit object from a tree and its parents.
+// The commit is not on any branch until a reference is updated to point to it.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - commit: The message, tree, parents, author and committer of the commit.
+// Returns:
+// - A pointer to the created commit.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateGitCommit(ctx context.Context, owner, repo string, commit *github.Commit) (*github.Commit, *github.Response, error) {
+	return w.Client.Git.CreateCommit(ctx, owner, repo, commit)
+}
+
+// CreateBlob creates a Git blob object.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - blob: The content of the blob and its encoding, utf-8 or base64.
+// Returns:
+// - A pointer to the created blob, with its SHA.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateBlob(ctx context.Context, owner, repo string, blob *github.Blob) (*github.Blob, *github.Response, error) {
+	return w.Client.Git.CreateBlob(ctx, owner, repo, blob)
+}
+
+// CreateTree creates a Git tree object by applying entries to a base tree.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - baseTree: The SHA of the tree to start from, or an empty string for an empty tree.
+// - entries: The entries to add or replace; an entry with neither SHA nor content deletes its path.
+// Returns:
+// - A pointer to the created tree.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateTree(ctx context.Context, owner, repo, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error) {
+	return w.Client.Git.CreateTree(ctx, owner, repo, baseTree, entries)
+}
+
+// CreateFromTemplate generates a repository from a template repository.
+// The template must be marked as a template repository and readable by the authenticated user.
+// Parameters:
+// - ctx: The context for the request.
+// - templateOwner: The owner of the template repository.
+// - templateRepo: The name of the template repository.
+// - req: The name, owner, description and visibility of the new repository.
+// Returns:
+// - A pointer to the generated repository.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateFromTemplate(ctx context.Context, templateOwner, templateRepo string, req *github.TemplateRepoRequest) (*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.CreateFromTemplate(ctx, templateOwner, templateRepo, req)
+}
+
+// EditRepository updates the settings of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - repository: The settings to change; nil fields are left unchanged.
+// Returns:
+// - A pointer to the updated repository.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) EditRepository(ctx context.Context, owner, repo string, repository *github.Repository) (*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.Edit(ctx, owner, repo, repository)
+}
+
+// ReplaceAllTopics replaces the topics of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - topics: The new topics, lowercase; an empty list removes all topics.
+// Returns:
+// - The topics of the repository.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ReplaceAllTopics(ctx context.Context, owner, repo string, topics []string) ([]string, *github.Response, error) {
+	return w.Client.Repositories.ReplaceAllTopics(ctx, owner, repo, topics)
+}
+
+// RenameBranch renames a branch, updating the default branch and open pull requests.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - branch: The current name of the branch.
+// - newName: The new name of the branch.
+// Returns:
+// - A pointer to the renamed branch.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) RenameBranch(ctx context.Context, owner, repo, branch, newName string) (*github.Branch, *github.Response, error) {
+	return w.Client.Repositories.RenameBranch(ctx, owner, repo, branch, newName)
+}
+
+// TransferRepository starts the transfer of a repository to another user or organization.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - transfer: The new owner and the teams of the organization given access.
+// Returns:
+// - A pointer to the repository, at its old location while the transfer is pending.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) TransferRepository(ctx context.Context, owner, repo string, transfer github.TransferRequest) (*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.Transfer(ctx, owner, repo, transfer)
+}
+
+// CreateFork starts forking a repository into the authenticated user's account or an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The organization, name and default-branch-only flag of the fork.
+// Returns:
+// - A pointer to the fork, unset while GitHub is still creating it.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateFork(ctx context.Context, owner, repo string, opts *github.RepositoryCreateForkOptions) (*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.CreateFork(ctx, owner, repo, opts)
+}
+
+// ListForks lists the forks of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The sort order and pagination of the forks.
+// Returns:
+// - A slice of forks.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListForks(ctx context.Context, owner, repo string, opts *github.RepositoryListForksOptions) ([]*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.ListForks(ctx, owner, repo, opts)
+}
+
+// MergeUpstream syncs a branch of a fork with the upstream repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the fork.
+// - repo: The name of the fork.
+// - request: The branch to sync.
+// Returns:
+// - A pointer to the merge type and message of the sync.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) MergeUpstream(ctx context.Context, owner, repo string, request *github.RepoMergeUpstreamRequest) (*github.RepoMergeUpstreamResult, *github.Response, error) {
+	return w.Client.Repositories.MergeUpstream(ctx, owner, repo, request)
+}
+
+// ListAllTopics lists the topics of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// Returns:
+// - The topics of the repository.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListAllTopics(ctx context.Context, owner, repo string) ([]string, *github.Response, error) {
+	return w.Client.Repositories.ListAllTopics(ctx, owner, repo)
+}
+
+// ListOrgRepos lists the repositories of an organization, including the private ones the user can see.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - opt: The type, sort order and pagination of the repositories.
+// Returns:
+// - A slice of repositories.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListOrgRepos(ctx context.Context, org string, opt *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
+	return w.Client.Repositories.ListByOrg(ctx, org, opt)
+}
+
+// GetCustomPropertyValues retrieves the values of the organization custom properties of a repository.
+// The go-github version in use predates custom properties, so the request is built by hand.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// Returns:
+// - A slice of property values, set or not.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetCustomPropertyValues(ctx context.Context, owner, repo string) ([]*interfaces.CustomPropertyValue, *github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/properties/values", owner, repo)
+	req, err := w.Client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values []*interfaces.CustomPropertyValue
+	resp, err := w.Client.Do(ctx, req, &values)
+	if err != nil {
+		return nil, resp, err
+	}
+	return values, resp, nil
+}
+
+// UpdateCustomPropertyValues creates or updates the values of organization custom properties of a repository.
+// Properties not listed keep their value; a nil value unsets a property.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - values: The property values to set.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateCustomPropertyValues(ctx context.Context, owner, repo string, values []*interfaces.CustomPropertyValue) (*github.Response, error) {
+	u := fmt.Sprintf("repos/%v/%v/properties/values", owner, repo)
+	body := struct {
+		Properties []*interfaces.CustomPropertyValue `json:"properties"`
+	}{values}
+	req, err := w.Client.NewRequest(http.MethodPatch, u, body)
+	if err != nil {
+		return nil, err
+	}
+	return w.Client.Do(ctx, req, nil)
+}
+
+// ListCustomPropertyValues lists the custom property values of the repositories of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - opt: The pagination of the repositories.
+// Returns:
+// - A slice of repositories with their property values.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListCustomPropertyValues(ctx context.Context, org string, opt *github.ListOptions) ([]*interfaces.RepoCustomPropertyValue, *github.Response, error) {
+	u := fmt.Sprintf("orgs/%v/properties/values?page=%d&per_page=%d", org, opt.Page, opt.PerPage)
+	req, err := w.Client.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var values []*interfaces.RepoCustomPropertyValue
+	resp, err := w.Client.Do(ctx, req, &values)
+	if err != nil {
+		return nil, resp, err
+	}
+	return values, resp, nil
+}
+
+// GetRepoPublicKey retrieves the public key secrets of a repository are encrypted with.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// Returns:
+// - A pointer to the key ID and base64-encoded public key.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetRepoPublicKey(ctx context.Context, owner, repo string) (*github.PublicKey, *github.Response, error) {
+	return w.Client.Actions.GetRepoPublicKey(ctx, owner, repo)
+}
+
+// GetOrgPublicKey retrieves the public key secrets of an organization are encrypted with.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// Returns:
+// - A pointer to the key ID and base64-encoded public key.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetOrgPublicKey(ctx context.Context, org string) (*github.PublicKey, *github.Response, error) {
+	return w.Client.Actions.GetOrgPublicKey(ctx, org)
+}
+
+// ListRepoSecrets lists the Actions secrets of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the secret names, without their values, and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListRepoSecrets(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	return w.Client.Actions.ListRepoSecrets(ctx, owner, repo, opts)
+}
+
+// ListOrgSecrets lists the Actions secrets of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the secret names, without their values, and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListOrgSecrets(ctx context.Context, org string, opts *github.ListOptions) (*github.Secrets, *github.Response, error) {
+	return w.Client.Actions.ListOrgSecrets(ctx, org, opts)
+}
+
+// CreateOrUpdateRepoSecret creates or updates an Actions secret of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - secret: The name, key ID and encrypted value of the secret.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateOrUpdateRepoSecret(ctx context.Context, owner, repo string, secret *github.EncryptedSecret) (*github.Response, error) {
+	return w.Client.Actions.CreateOrUpdateRepoSecret(ctx, owner, repo, secret)
+}
+
+// CreateOrUpdateOrgSecret creates or updates an Actions secret of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - secret: The name, key ID, encrypted value, visibility and selected repositories of the secret.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateOrUpdateOrgSecret(ctx context.Context, org string, secret *github.EncryptedSecret) (*github.Response, error) {
+	return w.Client.Actions.CreateOrUpdateOrgSecret(ctx, org, secret)
+}
+
+// DeleteRepoSecret deletes an Actions secret of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - name: The name of the secret.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteRepoSecret(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	return w.Client.Actions.DeleteRepoSecret(ctx, owner, repo, name)
+}
+
+// DeleteOrgSecret deletes an Actions secret of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - name: The name of the secret.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteOrgSecret(ctx context.Context, org, name string) (*github.Response, error) {
+	return w.Client.Actions.DeleteOrgSecret(ctx, org, name)
+}
+
+// ListRepoVariables lists the Actions variables of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the variables and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListRepoVariables(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	return w.Client.Actions.ListRepoVariables(ctx, owner, repo, opts)
+}
+
+// ListOrgVariables lists the Actions variables of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the variables and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListOrgVariables(ctx context.Context, org string, opts *github.ListOptions) (*github.ActionsVariables, *github.Response, error) {
+	return w.Client.Actions.ListOrgVariables(ctx, org, opts)
+}
+
+// CreateRepoVariable creates an Actions variable of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - variable: The name and value of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateRepoVariable(ctx context.Context, owner, repo string, variable *github.ActionsVariable) (*github.Response, error) {
+	return w.Client.Actions.CreateRepoVariable(ctx, owner, repo, variable)
+}
+
+// CreateOrgVariable creates an Actions variable of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - variable: The name, value, visibility and selected repositories of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateOrgVariable(ctx context.Context, org string, variable *github.ActionsVariable) (*github.Response, error) {
+	return w.Client.Actions.CreateOrgVariable(ctx, org, variable)
+}
+
+// UpdateRepoVariable updates an Actions variable of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - variable: The name and value of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateRepoVariable(ctx context.Context, owner, repo string, variable *github.ActionsVariable) (*github.Response, error) {
+	return w.Client.Actions.UpdateRepoVariable(ctx, owner, repo, variable)
+}
+
+// UpdateOrgVariable updates an Actions variable of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - variable: The name, value, visibility and selected repositories of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateOrgVariable(ctx context.Context, org string, variable *github.ActionsVariable) (*github.Response, error) {
+	return w.Client.Actions.UpdateOrgVariable(ctx, org, variable)
+}
+
+// DeleteRepoVariable deletes an Actions variable of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - name: The name of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteRepoVariable(ctx context.Context, owner, repo, name string) (*github.Response, error) {
+	return w.Client.Actions.DeleteRepoVariable(ctx, owner, repo, name)
+}
+
+// DeleteOrgVariable deletes an Actions variable of an organization.
+// Parameters:
+// - ctx: The context for the request.
+// - org: The login of the organization.
+// - name: The name of the variable.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteOrgVariable(ctx context.Context, org, name string) (*github.Response, error) {
+	return w.Client.Actions.DeleteOrgVariable(ctx, org, name)
+}
+
+// ListWorkflows lists the workflows of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the workflows and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListWorkflows(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.Workflows, *github.Response, error) {
+	return w.Client.Actions.ListWorkflows(ctx, owner, repo, opts)
+}
+
+// ListRepositoryWorkflowRuns lists the workflow runs of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The branch, event, status and actor filters and the pagination.
+// Returns:
+// - A pointer to the workflow runs and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListRepositoryWorkflowRuns(ctx context.Context, owner, repo string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return w.Client.Actions.ListRepositoryWorkflowRuns(ctx, owner, repo, opts)
+}
+
+// ListWorkflowRunsByFileName lists the runs of a workflow.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - workflow: The file name or ID of the workflow.
+// - opts: The branch, event, status and actor filters and the pagination.
+// Returns:
+// - A pointer to the workflow runs and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListWorkflowRunsByFileName(ctx context.Context, owner, repo, workflow string, opts *github.ListWorkflowRunsOptions) (*github.WorkflowRuns, *github.Response, error) {
+	return w.Client.Actions.ListWorkflowRunsByFileName(ctx, owner, repo, workflow, opts)
+}
+
+// GetWorkflowRunByID retrieves a workflow run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// Returns:
+// - A pointer to the workflow run.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.WorkflowRun, *github.Response, error) {
+	return w.Client.Actions.GetWorkflowRunByID(ctx, owner, repo, runID)
+}
+
+// ListWorkflowJobs lists the jobs of a workflow run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// - opts: Whether to list the jobs of the latest or of all attempts, and the pagination.
+// Returns:
+// - A pointer to the jobs and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListWorkflowJobs(ctx context.Context, owner, repo string, runID int64, opts *github.ListWorkflowJobsOptions) (*github.Jobs, *github.Response, error) {
+	return w.Client.Actions.ListWorkflowJobs(ctx, owner, repo, runID, opts)
+}
+
+// GetWorkflowJobLogs retrieves the download link of the logs of a workflow job.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - jobID: The ID of the job.
+// Returns:
+// - The temporary download link of the logs.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetWorkflowJobLogs(ctx context.Context, owner, repo string, jobID int64) (*url.URL, *github.Response, error) {
+	return w.Client.Actions.GetWorkflowJobLogs(ctx, owner, repo, jobID, true)
+}
+
+// RerunWorkflowByID re-runs all the jobs of a workflow run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) RerunWorkflowByID(ctx context.Context, owner, repo string, runID int64) (*github.Response, error) {
+	return w.Client.Actions.RerunWorkflowByID(ctx, owner, repo, runID)
+}
+
+// RerunFailedJobsByID re-runs the failed jobs of a workflow run and the jobs depending on them.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) RerunFailedJobsByID(ctx context.Context, owner, repo string, runID int64) (*github.Response, error) {
+	return w.Client.Actions.RerunFailedJobsByID(ctx, owner, repo, runID)
+}
+
+// CancelWorkflowRunByID cancels a workflow run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CancelWorkflowRunByID(ctx context.Context, owner, repo string, runID int64) (*github.Response, error) {
+	return w.Client.Actions.CancelWorkflowRunByID(ctx, owner, repo, runID)
+}
+
+// CreateWorkflowDispatchEventByFileName triggers a workflow with a workflow_dispatch event.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - workflow: The file name or ID of the workflow.
+// - event: The ref to run the workflow on and its inputs.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateWorkflowDispatchEventByFileName(ctx context.Context, owner, repo, workflow string, event github.CreateWorkflowDispatchEventRequest) (*github.Response, error) {
+	return w.Client.Actions.CreateWorkflowDispatchEventByFileName(ctx, owner, repo, workflow, event)
+}
+
+// ListArtifacts lists the artifacts of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the artifacts and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListArtifacts(ctx context.Context, owner, repo string, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	return w.Client.Actions.ListArtifacts(ctx, owner, repo, opts)
+}
+
+// ListWorkflowRunArtifacts lists the artifacts of a workflow run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - runID: The ID of the workflow run.
+// - opts: The pagination options.
+// Returns:
+// - A pointer to the artifacts and their total count.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListWorkflowRunArtifacts(ctx context.Context, owner, repo string, runID int64, opts *github.ListOptions) (*github.ArtifactList, *github.Response, error) {
+	return w.Client.Actions.ListWorkflowRunArtifacts(ctx, owner, repo, runID, opts)
+}
+
+// GetArtifact retrieves an artifact.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - artifactID: The ID of the artifact.
+// Returns:
+// - A pointer to the artifact.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetArtifact(ctx context.Context, owner, repo string, artifactID int64) (*github.Artifact, *github.Response, error) {
+	return w.Client.Actions.GetArtifact(ctx, owner, repo, artifactID)
+}
+
+// DownloadArtifact retrieves the download link of the zip archive of an artifact.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - artifactID: The ID of the artifact.
+// Returns:
+// - The temporary download link of the archive.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DownloadArtifact(ctx context.Context, owner, repo string, artifactID int64) (*url.URL, *github.Response, error) {
+	return w.Client.Actions.DownloadArtifact(ctx, owner, repo, artifactID, true)
+}
+
+// CreateStatus creates a commit status for a ref.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - ref: The SHA, branch or tag of the commit.
+// - status: The state, target URL, description and context of the status.
+// Returns:
+// - A pointer to the created status.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateStatus(ctx context.Context, owner, repo, ref string, status *github.RepoStatus) (*github.RepoStatus, *github.Response, error) {
+	return w.Client.Repositories.CreateStatus(ctx, owner, repo, ref, status)
+}
+
+// CreateCheckRun creates a check run for a commit.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The name, head SHA, status, conclusion and output of the check run.
+// Returns:
+// - A pointer to the check run.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateCheckRun(ctx context.Context, owner, repo string, opts github.CreateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return w.Client.Checks.CreateCheckRun(ctx, owner, repo, opts)
+}
+
+// GetCheckRun retrieves a check run.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - checkRunID: The ID of the check run.
+// Returns:
+// - A pointer to the check run.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) GetCheckRun(ctx context.Context, owner, repo string, checkRunID int64) (*github.CheckRun, *github.Response, error) {
+	return w.Client.Checks.GetCheckRun(ctx, owner, repo, checkRunID)
+}
+
+// UpdateCheckRun updates a check run; annotations in its output are added to the existing ones.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - checkRunID: The ID of the check run.
+// - opts: The name, status, conclusion and output of the check run.
+// Returns:
+// - A pointer to the check run.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) UpdateCheckRun(ctx context.Context, owner, repo string, checkRunID int64, opts github.UpdateCheckRunOptions) (*github.CheckRun, *github.Response, error) {
+	return w.Client.Checks.UpdateCheckRun(ctx, owner, repo, checkRunID, opts)
+}
+
+// ListKeys lists the deploy keys of a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - opts: The pagination options.
+// Returns:
+// - A slice of pointers to the deploy keys.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) ListKeys(ctx context.Context, owner, repo string, opts *github.ListOptions) ([]*github.Key, *github.Response, error) {
+	return w.Client.Repositories.ListKeys(ctx, owner, repo, opts)
+}
+
+// CreateKey adds a deploy key to a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - key: The title, public key and access of the deploy key.
+// Returns:
+// - A pointer to the deploy key.
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) CreateKey(ctx context.Context, owner, repo string, key *github.Key) (*github.Key, *github.Response, error) {
+	return w.Client.Repositories.CreateKey(ctx, owner, repo, key)
+}
+
+// DeleteKey removes a deploy key from a repository.
+// Parameters:
+// - ctx: The context for the request.
+// - owner: The owner of the repository.
+// - repo: The name of the repository.
+// - id: The ID of the deploy key.
+// Returns:
+// - A pointer to the GitHub response object.
+// - An error, if any occurred.
+func (w *GitHubClientWrapper) DeleteKey(ctx context.Context, owner, repo string, id int64) (*github.Response, error) {
+	return w.Client.Repositories.DeleteKey(ctx, owner, repo, id)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"github.com/google/go-github/v50/github"
+	"sync"
+)
+
+// allPages calls list for every page of a paginated endpoint and concatenates the results.
+func allPages[T any](list func(opt *github.ListOptions) ([]T, *github.Response, error)) ([]T, error) {
+	all := []T{}
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		items, resp, err := list(opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// forEachLimit calls fn for each index in [0, n), running at most limit calls at once.
+//
+// Returns:
+//   - error: The error of the lowest index whose call failed, or nil.
+func forEachLimit(n, limit int, fn func(i int) error) error {
+	errs := make([]error, n)
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = fn(i)
+		}()
+	}
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package models
+
+import (
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
+	"strings"
+	"time"
+)
+
+// IssueFilter holds the query parameters accepted when listing the issues of a repository.
+type IssueFilter struct {
+	State     string `form:"state"`
+	Labels    string `form:"labels"`
+	Assignee  string `form:"assignee"`
+	Milestone string `form:"milestone"`
+	Creator   string `form:"creator"`
+	Mentioned string `form:"mentioned"`
+	Since     string `form:"since"`
+	Sort      string `form:"sort"`
+	Direction string `form:"direction"`
+}
+
+// IssueFilterFromContext binds the issue filter from the query string of the request.
+//
+// Parameters:
+//   - c: The gin.Context of the request.
+//
+// Returns:
+//   - IssueFilter: The bound filter.
+//   - error: An error if the query string cannot be bound.
+func IssueFilterFromContext(c *gin.Context) (IssueFilter, error) {
+	var filter IssueFilter
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		return IssueFilter{}, err
+	}
+	return filter, nil
+}
+
+// ToOptions validates the filter and converts it to the options of the GitHub issues API.
+// Labels are a comma-separated list and Since accepts an RFC 3339 timestamp or a YYYY-MM-DD date.
+//
+// Parameters:
+//   - page: The pagination options of the request.
+//
+// Returns:
+//   - *github.IssueListByRepoOptions: The options to list issues with.
+//   - error: An error describing the first invalid field.
+func (f IssueFilter) ToOptions(page github.ListOptions) (*github.IssueListByRepoOptions, error) {
+	if err := oneOf("state", f.State, "open", "closed", "all"); err != nil {
+		return nil, err
+	}
+	if err := oneOf("sort", f.Sort, "created", "updated", "comments"); err != nil {
+		return nil, err
+	}
+	if err := oneOf("direction", f.Direction, "asc", "desc"); err != nil {
+		return nil, err
+	}
+
+	opt := &github.IssueListByRepoOptions{
+		State:       f.State,
+		Assignee:    f.Assignee,
+		Milestone:   f.Milestone,
+		Creator:     f.Creator,
+		Mentioned:   f.Mentioned,
+		Sort:        f.Sort,
+		Direction:   f.Direction,
+		ListOptions: page,
+	}
+	for _, label := range strings.Split(f.Labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			opt.Labels = append(opt.Labels, label)
+		}
+	}
+	if f.Since != "" {
+		since, err := ParseTime(f.Since)
+		if err != nil {
+			return nil, fmt.Errorf("invalid since: %w", err)
+		}
+		opt.Since = since
+	}
+	return opt, nil
+}
+
+// ParseTime parses a timestamp given either in RFC 3339 format or as a YYYY-MM-DD date.
+func ParseTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
+
+// oneOf returns an error if value is set and is not one of the allowed values.
+func oneOf(field, value string, allowed ...string) error {
+	if value == "" {
+		return nil
+	}
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %q: must be one of %s", field, value, strings.Join(allowed, ", "))
+}
+
+// OnlyIssues removes pull requests from a list of issues.
+// The GitHub issues API returns pull requests as issues; they are served by the pull request endpoints instead.
+func OnlyIssues(issues []*github.Issue) []*github.Issue {
+	filtered := make([]*github.Issue, 0, len(issues))
+	for _, issue := range issues {
+		if !issue.IsPullRequest() {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// IssueStateRequest is the optional body accepted when closing or reopening an issue.
+type IssueStateRequest struct {
+	StateReason string `json:"state_reason"`
+}
+
+// ToIssueRequest builds the edit request moving an issue to the given state.
+//
+// Parameters:
+//   - state: The target state, either "open" or "closed".
+//
+// Returns:
+//   - *github.IssueRequest: The edit request.
+//   - error: An error if the state reason is not valid for the target state.
+func (r IssueStateRequest) ToIssueRequest(state string) (*github.IssueRequest, error) {
+	allowed := []string{"completed", "not_planned"}
+	if state == "open" {
+		allowed = []string{"reopened"}
+	}
+	if err := oneOf("state_reason", r.StateReason, allowed...); err != nil {
+		return nil, err
+	}
+	req := &github.IssueRequest{State: github.String(state)}
+	if r.StateReason != "" {
+		req.StateReason = github.String(r.StateReason)
+	}
+	return req, nil
+}
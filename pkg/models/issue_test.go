@@ -0,0 +1,56 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIssueFilterToOptions tests that a valid filter is converted to GitHub list options.
+func TestIssueFilterToOptions(t *testing.T) {
+	filter := IssueFilter{State: "closed", Labels: "bug, triage", Assignee: "octocat", Milestone: "3", Since: "2024-05-01"}
+
+	opt, err := filter.ToOptions(github.ListOptions{Page: 2, PerPage: 50})
+	require.NoError(t, err)
+	assert.Equal(t, "closed", opt.State)
+	assert.Equal(t, []string{"bug", "triage"}, opt.Labels)
+	assert.Equal(t, "octocat", opt.Assignee)
+	assert.Equal(t, "3", opt.Milestone)
+	assert.Equal(t, time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), opt.Since)
+	assert.Equal(t, 2, opt.Page)
+}
+
+// TestIssueFilterToOptionsInvalid tests that invalid filter values are rejected.
+func TestIssueFilterToOptionsInvalid(t *testing.T) {
+	_, err := IssueFilter{State: "pending"}.ToOptions(github.ListOptions{})
+	assert.Error(t, err)
+
+	_, err = IssueFilter{Since: "yesterday"}.ToOptions(github.ListOptions{})
+	assert.Error(t, err)
+}
+
+// TestOnlyIssues tests that pull requests are removed from issue lists.
+func TestOnlyIssues(t *testing.T) {
+	issues := []*github.Issue{
+		{Number: github.Int(1)},
+		{Number: github.Int(2), PullRequestLinks: &github.PullRequestLinks{URL: github.String("https://api.github.com/pulls/2")}},
+	}
+
+	filtered := OnlyIssues(issues)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, 1, filtered[0].GetNumber())
+}
+
+// TestIssueStateRequest tests the state reasons accepted when closing and reopening issues.
+func TestIssueStateRequest(t *testing.T) {
+	req, err := IssueStateRequest{StateReason: "not_planned"}.ToIssueRequest("closed")
+	require.NoError(t, err)
+	assert.Equal(t, "closed", req.GetState())
+	assert.Equal(t, "not_planned", req.GetStateReason())
+
+	_, err = IssueStateRequest{StateReason: "completed"}.ToIssueRequest("open")
+	assert.Error(t, err)
+}
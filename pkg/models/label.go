@@ -0,0 +1,227 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"regexp"
+	"strings"
+)
+
+// Label sync actions reported in a LabelChange.
+const (
+	LabelCreate = "create"
+	LabelUpdate = "update"
+	LabelRename = "rename"
+	LabelDelete = "delete"
+)
+
+// labelColorPattern matches the six hexadecimal digits GitHub expects for label colors.
+var labelColorPattern = regexp.MustCompile(`^[0-9a-f]{6}$`)
+
+// LabelSpec describes a label of the canonical label set.
+// Aliases are former names of the label: an existing label with one of these names
+// is renamed instead of creating a new label, so issues keep their labels.
+type LabelSpec struct {
+	Name        string   `json:"name"`
+	Color       string   `json:"color"`
+	Description string   `json:"description"`
+	Aliases     []string `json:"aliases"`
+}
+
+// LabelSyncRequest is the payload accepted by the label sync endpoints.
+// When Prune is set, labels that are not part of the canonical set are deleted.
+// When DryRun is set, the changes are computed and returned without being applied.
+type LabelSyncRequest struct {
+	Labels []LabelSpec `json:"labels"`
+	Prune  bool        `json:"prune"`
+	DryRun bool        `json:"dry_run"`
+}
+
+// LabelChange is a single step of a label sync plan.
+type LabelChange struct {
+	Action      string `json:"action"`
+	Name        string `json:"name"`
+	From        string `json:"from,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Description string `json:"description,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// LabelSyncResult reports the changes planned or applied to a repository.
+type LabelSyncResult struct {
+	Repository string        `json:"repository"`
+	DryRun     bool          `json:"dry_run"`
+	Changes    []LabelChange `json:"changes"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// Validate checks the canonical label set and normalizes label colors to lowercase
+// hexadecimal without a leading '#'. Names and aliases must be unique, ignoring case.
+//
+// Returns:
+//   - error: An error describing the first invalid label.
+func (r *LabelSyncRequest) Validate() error {
+	if len(r.Labels) == 0 {
+		return fmt.Errorf("labels must not be empty")
+	}
+	seen := map[string]bool{}
+	for i := range r.Labels {
+		label := &r.Labels[i]
+		label.Name = strings.TrimSpace(label.Name)
+		if label.Name == "" {
+			return fmt.Errorf("label %d has no name", i)
+		}
+		label.Color = strings.ToLower(strings.TrimPrefix(label.Color, "#"))
+		if !labelColorPattern.MatchString(label.Color) {
+			return fmt.Errorf("label %q has invalid color %q", label.Name, label.Color)
+		}
+		for _, name := range append([]string{label.Name}, label.Aliases...) {
+			key := strings.ToLower(name)
+			if seen[key] {
+				return fmt.Errorf("label name %q is used more than once", name)
+			}
+			seen[key] = true
+		}
+	}
+	return nil
+}
+
+// PlanLabelSync computes the changes needed to turn the existing labels of a repository
+// into the canonical label set. Renames are listed first, followed by updates, creations
+// and, when prune is set, deletions.
+//
+// Parameters:
+//   - existing: The labels currently defined in the repository.
+//   - labels: The canonical label set.
+//   - prune: Whether labels outside the canonical set are deleted.
+//
+// Returns:
+//   - []LabelChange: The changes to apply, empty if the repository is already in sync.
+func PlanLabelSync(existing []*github.Label, labels []LabelSpec, prune bool) []LabelChange {
+	byName := make(map[string]*github.Label, len(existing))
+	for _, label := range existing {
+		byName[strings.ToLower(label.GetName())] = label
+	}
+	matched := map[string]bool{}
+
+	var renames, updates, creates, deletes []LabelChange
+	for _, spec := range labels {
+		change := LabelChange{Name: spec.Name, Color: spec.Color, Description: spec.Description}
+
+		current, ok := byName[strings.ToLower(spec.Name)]
+		if !ok {
+			for _, alias := range spec.Aliases {
+				if label, found := byName[strings.ToLower(alias)]; found && !matched[strings.ToLower(alias)] {
+					current, ok = label, true
+					break
+				}
+			}
+		}
+		if !ok {
+			change.Action = LabelCreate
+			creates = append(creates, change)
+			continue
+		}
+		matched[strings.ToLower(current.GetName())] = true
+
+		switch {
+		case current.GetName() != spec.Name:
+			change.Action = LabelRename
+			change.From = current.GetName()
+			renames = append(renames, change)
+		case !strings.EqualFold(current.GetColor(), spec.Color) || current.GetDescription() != spec.Description:
+			change.Action = LabelUpdate
+			updates = append(updates, change)
+		}
+	}
+
+	if prune {
+		for _, label := range existing {
+			if !matched[strings.ToLower(label.GetName())] {
+				deletes = append(deletes, LabelChange{Action: LabelDelete, Name: label.GetName()})
+			}
+		}
+	}
+
+	plan := append(renames, updates...)
+	plan = append(plan, creates...)
+	return append(plan, deletes...)
+}
+
+// Sync plans the label changes for a repository and applies them unless the request is a dry run.
+// A failing change does not stop the remaining ones; its error is recorded on the change.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - LabelSyncResult: The planned or applied changes for the repository.
+func (r *LabelSyncRequest) Sync(ctx context.Context, client interfaces.GitHubClient, owner, repo string) LabelSyncResult {
+	result := LabelSyncResult{Repository: owner + "/" + repo, DryRun: r.DryRun, Changes: []LabelChange{}}
+
+	existing, err := ListAllLabels(ctx, client, owner, repo)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	plan := PlanLabelSync(existing, r.Labels, r.Prune)
+	if r.DryRun {
+		result.Changes = append(result.Changes, plan...)
+		return result
+	}
+
+	for _, change := range plan {
+		label := &github.Label{
+			Name:        github.String(change.Name),
+			Color:       github.String(change.Color),
+			Description: github.String(change.Description),
+		}
+		switch change.Action {
+		case LabelCreate:
+			_, _, err = client.CreateLabel(ctx, owner, repo, label)
+		case LabelUpdate:
+			_, _, err = client.EditLabel(ctx, owner, repo, change.Name, label)
+		case LabelRename:
+			_, _, err = client.EditLabel(ctx, owner, repo, change.From, label)
+		case LabelDelete:
+			_, err = client.DeleteLabel(ctx, owner, repo, change.Name)
+		}
+		if err != nil {
+			change.Error = err.Error()
+		}
+		result.Changes = append(result.Changes, change)
+	}
+	return result
+}
+
+// ListAllLabels lists every label of a repository, following pagination.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - []*github.Label: All labels of the repository.
+//   - error: An error if any page cannot be retrieved.
+func ListAllLabels(ctx context.Context, client interfaces.GitHubClient, owner, repo string) ([]*github.Label, error) {
+	var all []*github.Label
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		labels, resp, err := client.ListLabels(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, labels...)
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
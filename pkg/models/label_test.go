@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/mocks"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// existingLabels returns the labels of a repository used by the label sync tests.
+func existingLabels() []*github.Label {
+	return []*github.Label{
+		{Name: github.String("bug"), Color: github.String("d73a4a"), Description: github.String("Something isn't working")},
+		{Name: github.String("enhancement"), Color: github.String("a2eeef")},
+		{Name: github.String("wontfix"), Color: github.String("ffffff")},
+	}
+}
+
+// canonicalLabels returns the canonical label set used by the label sync tests.
+func canonicalLabels() []LabelSpec {
+	return []LabelSpec{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"},
+		{Name: "feature", Color: "0e8a16", Aliases: []string{"enhancement"}},
+		{Name: "needs-triage", Color: "fbca04"},
+	}
+}
+
+// TestPlanLabelSync tests that the plan renames aliased labels, creates missing ones and prunes the rest.
+func TestPlanLabelSync(t *testing.T) {
+	plan := PlanLabelSync(existingLabels(), canonicalLabels(), true)
+
+	require.Len(t, plan, 3)
+	assert.Equal(t, LabelChange{Action: LabelRename, Name: "feature", From: "enhancement", Color: "0e8a16"}, plan[0])
+	assert.Equal(t, LabelChange{Action: LabelCreate, Name: "needs-triage", Color: "fbca04"}, plan[1])
+	assert.Equal(t, LabelChange{Action: LabelDelete, Name: "wontfix"}, plan[2])
+}
+
+// TestPlanLabelSyncWithoutPrune tests that labels outside the canonical set are kept unless pruning.
+func TestPlanLabelSyncWithoutPrune(t *testing.T) {
+	labels := canonicalLabels()
+	labels[0].Color = "ee0701"
+
+	plan := PlanLabelSync(existingLabels(), labels, false)
+
+	require.Len(t, plan, 3)
+	assert.Equal(t, LabelUpdate, plan[1].Action)
+	assert.Equal(t, "bug", plan[1].Name)
+	for _, change := range plan {
+		assert.NotEqual(t, LabelDelete, change.Action)
+	}
+}
+
+// TestLabelSyncRequestValidate tests that colors are normalized and duplicate names rejected.
+func TestLabelSyncRequestValidate(t *testing.T) {
+	req := LabelSyncRequest{Labels: []LabelSpec{{Name: "bug", Color: "#D73A4A"}}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "d73a4a", req.Labels[0].Color)
+
+	req = LabelSyncRequest{Labels: []LabelSpec{{Name: "bug", Color: "d73a4a"}, {Name: "feature", Color: "0e8a16", Aliases: []string{"Bug"}}}}
+	assert.Error(t, req.Validate())
+
+	req = LabelSyncRequest{Labels: []LabelSpec{{Name: "bug", Color: "red"}}}
+	assert.Error(t, req.Validate())
+}
+
+// TestLabelSyncDryRun tests that a dry run reports the plan without modifying the repository.
+func TestLabelSyncDryRun(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListLabels", mock.Anything, "octocat", "hello", mock.Anything).Return(
+		existingLabels(),
+		&github.Response{},
+		nil)
+
+	req := LabelSyncRequest{Labels: canonicalLabels(), DryRun: true}
+	result := req.Sync(context.Background(), mockClient, "octocat", "hello")
+
+	assert.Empty(t, result.Error)
+	assert.True(t, result.DryRun)
+	assert.Len(t, result.Changes, 2)
+	mockClient.AssertNotCalled(t, "CreateLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockClient.AssertNotCalled(t, "EditLabel", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,249 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// MilestoneProgress reports the completion of a milestone in a single repository.
+// Issue and pull request counts are kept apart, while PercentComplete covers both.
+type MilestoneProgress struct {
+	Repository         string     `json:"repository"`
+	Number             int        `json:"number"`
+	Title              string     `json:"title"`
+	State              string     `json:"state"`
+	DueOn              *time.Time `json:"due_on"`
+	OpenIssues         int        `json:"open_issues"`
+	ClosedIssues       int        `json:"closed_issues"`
+	OpenPullRequests   int        `json:"open_pull_requests"`
+	ClosedPullRequests int        `json:"closed_pull_requests"`
+	PercentComplete    float64    `json:"percent_complete"`
+	Overdue            bool       `json:"overdue"`
+}
+
+// MilestoneSummary aggregates the milestones sharing a title across repositories,
+// such as a release milestone created in every repository taking part in the release.
+// DueOn is the earliest due date among them.
+type MilestoneSummary struct {
+	Title              string     `json:"title"`
+	Repositories       []string   `json:"repositories"`
+	DueOn              *time.Time `json:"due_on"`
+	OpenIssues         int        `json:"open_issues"`
+	ClosedIssues       int        `json:"closed_issues"`
+	OpenPullRequests   int        `json:"open_pull_requests"`
+	ClosedPullRequests int        `json:"closed_pull_requests"`
+	PercentComplete    float64    `json:"percent_complete"`
+	Overdue            bool       `json:"overdue"`
+}
+
+// RepositoryError reports a repository that could not be processed by a multi-repository operation.
+type RepositoryError struct {
+	Repository string `json:"repository"`
+	Error      string `json:"error"`
+}
+
+// MilestoneReport is the progress report of the milestones of a set of repositories.
+type MilestoneReport struct {
+	Milestones []MilestoneProgress `json:"milestones"`
+	Summary    []MilestoneSummary  `json:"summary"`
+	Errors     []RepositoryError   `json:"errors,omitempty"`
+}
+
+// MilestoneFilter holds the query parameters accepted when listing milestones.
+type MilestoneFilter struct {
+	State     string `form:"state"`
+	Sort      string `form:"sort"`
+	Direction string `form:"direction"`
+}
+
+// ToOptions validates the filter and converts it to the options of the GitHub milestones API.
+//
+// Parameters:
+//   - page: The pagination options of the request.
+//
+// Returns:
+//   - *github.MilestoneListOptions: The options to list milestones with.
+//   - error: An error describing the first invalid field.
+func (f MilestoneFilter) ToOptions(page github.ListOptions) (*github.MilestoneListOptions, error) {
+	if err := oneOf("state", f.State, "open", "closed", "all"); err != nil {
+		return nil, err
+	}
+	if err := oneOf("sort", f.Sort, "due_on", "completeness"); err != nil {
+		return nil, err
+	}
+	if err := oneOf("direction", f.Direction, "asc", "desc"); err != nil {
+		return nil, err
+	}
+	return &github.MilestoneListOptions{State: f.State, Sort: f.Sort, Direction: f.Direction, ListOptions: page}, nil
+}
+
+// BuildMilestoneReport computes the progress of the milestones of each repository.
+// Every repository is first looked up with GetRepositories, so renamed repositories are
+// reported under their current name and missing ones are listed in the report errors.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - repos: The repositories to report on, as "owner/name".
+//   - state: The state of the milestones to include: open, closed or all.
+//   - now: The time against which due dates are compared.
+//
+// Returns:
+//   - MilestoneReport: The per-repository progress and its aggregation by milestone title.
+func BuildMilestoneReport(ctx context.Context, client interfaces.GitHubClient, repos []string, state string, now time.Time) MilestoneReport {
+	report := MilestoneReport{Milestones: []MilestoneProgress{}, Summary: []MilestoneSummary{}}
+	for _, fullName := range repos {
+		owner, name, err := SplitFullName(fullName)
+		if err != nil {
+			report.Errors = append(report.Errors, RepositoryError{Repository: fullName, Error: err.Error()})
+			continue
+		}
+		repo, _, err := client.GetRepositories(ctx, owner, name)
+		if err != nil {
+			report.Errors = append(report.Errors, RepositoryError{Repository: fullName, Error: err.Error()})
+			continue
+		}
+		progress, err := repositoryMilestones(ctx, client, repo.GetOwner().GetLogin(), repo.GetName(), state, now)
+		if err != nil {
+			report.Errors = append(report.Errors, RepositoryError{Repository: fullName, Error: err.Error()})
+			continue
+		}
+		report.Milestones = append(report.Milestones, progress...)
+	}
+	report.Summary = summarizeMilestones(report.Milestones, now)
+	return report
+}
+
+// repositoryMilestones computes the progress of every milestone of a repository in the given state.
+func repositoryMilestones(ctx context.Context, client interfaces.GitHubClient, owner, repo, state string, now time.Time) ([]MilestoneProgress, error) {
+	var progress []MilestoneProgress
+	opt := &github.MilestoneListOptions{State: state, Sort: "due_on", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		milestones, resp, err := client.ListMilestones(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, milestone := range milestones {
+			p, err := milestoneProgress(ctx, client, owner, repo, milestone, now)
+			if err != nil {
+				return nil, err
+			}
+			progress = append(progress, p)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return progress, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// milestoneProgress counts the issues and pull requests of a milestone.
+func milestoneProgress(ctx context.Context, client interfaces.GitHubClient, owner, repo string, milestone *github.Milestone, now time.Time) (MilestoneProgress, error) {
+	p := MilestoneProgress{
+		Repository: owner + "/" + repo,
+		Number:     milestone.GetNumber(),
+		Title:      milestone.GetTitle(),
+		State:      milestone.GetState(),
+	}
+	if milestone.DueOn != nil {
+		due := milestone.GetDueOn().Time
+		p.DueOn = &due
+	}
+
+	opt := &github.IssueListByRepoOptions{
+		Milestone:   strconv.Itoa(milestone.GetNumber()),
+		State:       "all",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+	for {
+		issues, resp, err := client.ListIssuesByRepo(ctx, owner, repo, opt)
+		if err != nil {
+			return MilestoneProgress{}, err
+		}
+		for _, issue := range issues {
+			closed := issue.GetState() == "closed"
+			switch {
+			case issue.IsPullRequest() && closed:
+				p.ClosedPullRequests++
+			case issue.IsPullRequest():
+				p.OpenPullRequests++
+			case closed:
+				p.ClosedIssues++
+			default:
+				p.OpenIssues++
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	open := p.OpenIssues + p.OpenPullRequests
+	p.PercentComplete = percentComplete(open, p.ClosedIssues+p.ClosedPullRequests)
+	p.Overdue = isOverdue(p.DueOn, p.State == "open" && open > 0, now)
+	return p, nil
+}
+
+// summarizeMilestones aggregates milestone progress by title, ordered by due date and then title.
+func summarizeMilestones(milestones []MilestoneProgress, now time.Time) []MilestoneSummary {
+	byTitle := map[string]*MilestoneSummary{}
+	var order []string
+	for _, m := range milestones {
+		s, ok := byTitle[m.Title]
+		if !ok {
+			s = &MilestoneSummary{Title: m.Title}
+			byTitle[m.Title] = s
+			order = append(order, m.Title)
+		}
+		s.Repositories = append(s.Repositories, m.Repository)
+		s.OpenIssues += m.OpenIssues
+		s.ClosedIssues += m.ClosedIssues
+		s.OpenPullRequests += m.OpenPullRequests
+		s.ClosedPullRequests += m.ClosedPullRequests
+		if m.DueOn != nil && (s.DueOn == nil || m.DueOn.Before(*s.DueOn)) {
+			s.DueOn = m.DueOn
+		}
+	}
+
+	summary := make([]MilestoneSummary, 0, len(order))
+	for _, title := range order {
+		s := byTitle[title]
+		open := s.OpenIssues + s.OpenPullRequests
+		s.PercentComplete = percentComplete(open, s.ClosedIssues+s.ClosedPullRequests)
+		s.Overdue = isOverdue(s.DueOn, open > 0, now)
+		summary = append(summary, *s)
+	}
+	sort.SliceStable(summary, func(i, j int) bool {
+		a, b := summary[i].DueOn, summary[j].DueOn
+		switch {
+		case a == nil || b == nil:
+			return a != nil && b == nil
+		case !a.Equal(*b):
+			return a.Before(*b)
+		default:
+			return summary[i].Title < summary[j].Title
+		}
+	})
+	return summary
+}
+
+// percentComplete returns the share of closed items, rounded to one decimal place.
+// A milestone without any item is reported as 0% complete.
+func percentComplete(open, closed int) float64 {
+	total := open + closed
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(closed)*1000/float64(total)) / 10
+}
+
+// isOverdue reports whether a milestone with outstanding work is past its due date.
+func isOverdue(dueOn *time.Time, outstanding bool, now time.Time) bool {
+	return outstanding && dueOn != nil && dueOn.Before(now)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBuildMilestoneReport tests the per-repository progress and the aggregation by title.
+func TestBuildMilestoneReport(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	due := github.Timestamp{Time: now.AddDate(0, 0, -7)}
+	prLinks := &github.PullRequestLinks{URL: github.String("https://api.github.com/pulls/1")}
+
+	mockClient := new(mocks.MockGitHubClient)
+	for _, name := range []string{"api", "web"} {
+		mockClient.On("GetRepositories", mock.Anything, "octocat", name).Return(
+			&github.Repository{Name: github.String(name), Owner: &github.User{Login: github.String("octocat")}},
+			&github.Response{},
+			nil)
+		mockClient.On("ListMilestones", mock.Anything, "octocat", name, mock.Anything).Return(
+			[]*github.Milestone{{Number: github.Int(1), Title: github.String("v1.0"), State: github.String("open"), DueOn: &due}},
+			&github.Response{},
+			nil)
+	}
+	mockClient.On("ListIssuesByRepo", mock.Anything, "octocat", "api", mock.Anything).Return(
+		[]*github.Issue{
+			{State: github.String("closed")},
+			{State: github.String("closed")},
+			{State: github.String("open")},
+			{State: github.String("closed"), PullRequestLinks: prLinks},
+		},
+		&github.Response{},
+		nil)
+	mockClient.On("ListIssuesByRepo", mock.Anything, "octocat", "web", mock.Anything).Return(
+		[]*github.Issue{{State: github.String("closed")}},
+		&github.Response{},
+		nil)
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "gone").Return(
+		(*github.Repository)(nil),
+		&github.Response{},
+		errors.New("not found"))
+
+	report := BuildMilestoneReport(context.Background(), mockClient, []string{"octocat/api", "octocat/web", "octocat/gone"}, "open", now)
+
+	require.Len(t, report.Milestones, 2)
+	api := report.Milestones[0]
+	assert.Equal(t, "octocat/api", api.Repository)
+	assert.Equal(t, 1, api.OpenIssues)
+	assert.Equal(t, 2, api.ClosedIssues)
+	assert.Equal(t, 1, api.ClosedPullRequests)
+	assert.Equal(t, 75.0, api.PercentComplete)
+	assert.True(t, api.Overdue)
+	assert.False(t, report.Milestones[1].Overdue)
+
+	require.Len(t, report.Summary, 1)
+	assert.Equal(t, []string{"octocat/api", "octocat/web"}, report.Summary[0].Repositories)
+	assert.Equal(t, 80.0, report.Summary[0].PercentComplete)
+	assert.True(t, report.Summary[0].Overdue)
+
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, "octocat/gone", report.Errors[0].Repository)
+}
+
+// TestSplitFullName tests parsing repository full names.
+func TestSplitFullName(t *testing.T) {
+	owner, name, err := SplitFullName("octocat/hello-world")
+	require.NoError(t, err)
+	assert.Equal(t, "octocat", owner)
+	assert.Equal(t, "hello-world", name)
+
+	_, _, err = SplitFullName("hello-world")
+	assert.Error(t, err)
+}
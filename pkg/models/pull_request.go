@@ -0,0 +1,195 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Errors returned by CheckMergeable. Controllers map ErrHeadChanged and ErrMergeConflict
+// to 409 Conflict and ErrNotMergeable to 405 Method Not Allowed, as GitHub does.
+var (
+	ErrHeadChanged   = errors.New("pull request head does not match the expected SHA")
+	ErrMergeConflict = errors.New("pull request has merge conflicts")
+	ErrNotMergeable  = errors.New("pull request is not mergeable")
+)
+
+// shaPattern matches a full hexadecimal commit SHA.
+var shaPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// MissingPullRequestFields returns the names of the required fields missing from a new pull request.
+// A title is not required when the pull request is created from an existing issue.
+//
+// Parameters:
+//   - pull: The pull request to be created.
+//
+// Returns:
+//   - []string: The missing fields, empty if the pull request is complete.
+func MissingPullRequestFields(pull *github.NewPullRequest) []string {
+	var missing []string
+	if pull.GetHead() == "" {
+		missing = append(missing, "head")
+	}
+	if pull.GetBase() == "" {
+		missing = append(missing, "base")
+	}
+	if pull.GetTitle() == "" && pull.GetIssue() == 0 {
+		missing = append(missing, "title")
+	}
+	return missing
+}
+
+// PullRequestUpdateRequest is the payload accepted when editing a pull request.
+// Fields that are omitted are left unchanged.
+type PullRequestUpdateRequest struct {
+	Title               *string `json:"title"`
+	Body                *string `json:"body"`
+	State               *string `json:"state"`
+	Base                *string `json:"base"`
+	MaintainerCanModify *bool   `json:"maintainer_can_modify"`
+}
+
+// ToPullRequest validates the update and converts it to the pull request sent to GitHub.
+//
+// Returns:
+//   - *github.PullRequest: The fields to update.
+//   - error: An error if the state is neither open nor closed.
+func (r PullRequestUpdateRequest) ToPullRequest() (*github.PullRequest, error) {
+	if r.State != nil {
+		if err := oneOf("state", *r.State, "open", "closed"); err != nil {
+			return nil, err
+		}
+	}
+	pull := &github.PullRequest{
+		Title:               r.Title,
+		Body:                r.Body,
+		State:               r.State,
+		MaintainerCanModify: r.MaintainerCanModify,
+	}
+	if r.Base != nil {
+		pull.Base = &github.PullRequestBranch{Ref: r.Base}
+	}
+	return pull, nil
+}
+
+// PullRequestMergeRequest is the payload accepted when merging a pull request.
+// SHA is the head commit the caller expects to merge; the merge is refused if the
+// pull request head has moved since.
+type PullRequestMergeRequest struct {
+	MergeMethod   string `json:"merge_method"`
+	SHA           string `json:"sha"`
+	CommitTitle   string `json:"commit_title"`
+	CommitMessage string `json:"commit_message"`
+}
+
+// Validate checks the merge request, defaulting the merge method to "merge".
+//
+// Returns:
+//   - error: An error if the SHA is missing or malformed, or the merge method is unknown.
+func (r *PullRequestMergeRequest) Validate() error {
+	if !shaPattern.MatchString(r.SHA) {
+		return fmt.Errorf("invalid sha %q: expected the full head commit SHA", r.SHA)
+	}
+	if r.MergeMethod == "" {
+		r.MergeMethod = "merge"
+	}
+	return oneOf("merge_method", r.MergeMethod, "merge", "squash", "rebase")
+}
+
+// Options returns the merge options sent to GitHub, which also enforce the expected SHA.
+func (r PullRequestMergeRequest) Options() *github.PullRequestOptions {
+	return &github.PullRequestOptions{
+		CommitTitle: r.CommitTitle,
+		SHA:         r.SHA,
+		MergeMethod: r.MergeMethod,
+	}
+}
+
+// CheckMergeable verifies that a pull request can be merged at the expected head SHA.
+// When GitHub has not finished computing mergeability, the check is left to the merge itself.
+//
+// Parameters:
+//   - pull: The pull request as currently returned by GitHub.
+//   - sha: The head commit SHA the caller expects to merge.
+//
+// Returns:
+//   - error: nil if the merge can be attempted, otherwise an error wrapping ErrHeadChanged,
+//     ErrMergeConflict or ErrNotMergeable.
+func CheckMergeable(pull *github.PullRequest, sha string) error {
+	switch {
+	case pull.GetMerged():
+		return fmt.Errorf("%w: already merged", ErrNotMergeable)
+	case pull.GetState() != "open":
+		return fmt.Errorf("%w: pull request is %s", ErrNotMergeable, pull.GetState())
+	case pull.GetDraft():
+		return fmt.Errorf("%w: pull request is a draft", ErrNotMergeable)
+	case !strings.EqualFold(pull.GetHead().GetSHA(), sha):
+		return fmt.Errorf("%w: head is %s, expected %s", ErrHeadChanged, pull.GetHead().GetSHA(), sha)
+	case pull.Mergeable != nil && !pull.GetMergeable() && pull.GetMergeableState() == "dirty":
+		return ErrMergeConflict
+	case pull.Mergeable != nil && !pull.GetMergeable():
+		return fmt.Errorf("%w: mergeable state is %s", ErrNotMergeable, pull.GetMergeableState())
+	}
+	return nil
+}
+
+// ReviewDecision summarizes the latest review of each reviewer of a pull request.
+type ReviewDecision struct {
+	Approvals        []string `json:"approvals"`
+	ChangesRequested []string `json:"changes_requested"`
+}
+
+// GetReviewDecision retrieves the reviews of a pull request and keeps the latest approval or
+// change request of each reviewer other than the author. Comments do not change a decision,
+// and a dismissed review withdraws it.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - pull: The pull request.
+//
+// Returns:
+//   - ReviewDecision: The logins of the reviewers approving and requesting changes, sorted.
+//   - error: An error if the reviews cannot be retrieved.
+func GetReviewDecision(ctx context.Context, client interfaces.GitHubClient, owner, repo string, pull *github.PullRequest) (ReviewDecision, error) {
+	reviews, err := allPages(func(opt *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return client.ListPullRequestReviews(ctx, owner, repo, pull.GetNumber(), opt)
+	})
+	if err != nil {
+		return ReviewDecision{}, err
+	}
+
+	// Reviews are listed in chronological order, so later decisions replace earlier ones.
+	latest := map[string]string{}
+	for _, review := range reviews {
+		login := review.GetUser().GetLogin()
+		if login == pull.GetUser().GetLogin() {
+			continue
+		}
+		switch review.GetState() {
+		case "APPROVED", "CHANGES_REQUESTED":
+			latest[login] = review.GetState()
+		case "DISMISSED":
+			delete(latest, login)
+		}
+	}
+
+	decision := ReviewDecision{Approvals: []string{}, ChangesRequested: []string{}}
+	for login, state := range latest {
+		if state == "APPROVED" {
+			decision.Approvals = append(decision.Approvals, login)
+		} else {
+			decision.ChangesRequested = append(decision.ChangesRequested, login)
+		}
+	}
+	sort.Strings(decision.Approvals)
+	sort.Strings(decision.ChangesRequested)
+	return decision, nil
+}
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"sync"
+)
+
+// Expansions accepted by GetPullRequestDetail.
+const (
+	ExpandFiles          = "files"
+	ExpandCommits        = "commits"
+	ExpandReviews        = "reviews"
+	ExpandReviewComments = "review_comments"
+	ExpandStatus         = "status"
+	ExpandChecks         = "checks"
+)
+
+// PullRequestExpansions lists every expansion, in the order they are reported.
+var PullRequestExpansions = []string{ExpandFiles, ExpandCommits, ExpandReviews, ExpandReviewComments, ExpandStatus, ExpandChecks}
+
+// PullRequestFileStats sums the line changes of the files of a pull request.
+type PullRequestFileStats struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Changes   int `json:"changes"`
+}
+
+// PullRequestDetail is a pull request together with the expansions requested by the caller.
+// Expansions that were not requested are null. Status and CheckRuns describe the head commit.
+type PullRequestDetail struct {
+	PullRequest    *github.PullRequest          `json:"pull_request"`
+	Files          []*github.CommitFile         `json:"files"`
+	FileStats      *PullRequestFileStats        `json:"file_stats"`
+	Commits        []*github.RepositoryCommit   `json:"commits"`
+	Reviews        []*github.PullRequestReview  `json:"reviews"`
+	ReviewComments []*github.PullRequestComment `json:"review_comments"`
+	Status         *github.CombinedStatus       `json:"status"`
+	CheckRuns      []*github.CheckRun           `json:"check_runs"`
+}
+
+// ParseExpansions validates the expansions requested for a pull request.
+// "all" selects every expansion, and duplicates are ignored.
+//
+// Parameters:
+//   - values: The requested expansions.
+//
+// Returns:
+//   - []string: The expansions, in the order of PullRequestExpansions.
+//   - error: An error naming the first unknown expansion.
+func ParseExpansions(values []string) ([]string, error) {
+	requested := map[string]bool{}
+	for _, value := range values {
+		if value == "all" {
+			return PullRequestExpansions, nil
+		}
+		if err := oneOf("expand", value, PullRequestExpansions...); err != nil {
+			return nil, err
+		}
+		requested[value] = true
+	}
+	var expand []string
+	for _, name := range PullRequestExpansions {
+		if requested[name] {
+			expand = append(expand, name)
+		}
+	}
+	return expand, nil
+}
+
+// GetPullRequestDetail retrieves a pull request and fetches the requested expansions concurrently.
+// Every expansion follows pagination. The first expansion to fail cancels the others.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the pull request.
+//   - expand: The expansions to fetch, as returned by ParseExpansions.
+//
+// Returns:
+//   - *PullRequestDetail: The pull request and its expansions.
+//   - error: The error of the pull request lookup or of the first failed expansion.
+func GetPullRequestDetail(ctx context.Context, client interfaces.GitHubClient, owner, repo string, number int, expand []string) (*PullRequestDetail, error) {
+	pull, _, err := client.GetPullRequest(ctx, owner, repo, number)
+	if err != nil {
+		return nil, err
+	}
+	detail := &PullRequestDetail{PullRequest: pull}
+	sha := pull.GetHead().GetSHA()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for _, name := range expand {
+		fetch := detail.fetcher(ctx, client, owner, repo, number, sha, name)
+		if fetch == nil {
+			return nil, fmt.Errorf("unknown expansion %q", name)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fetch(); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = fmt.Errorf("%s: %w", name, err)
+					cancel()
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return detail, nil
+}
+
+// fetcher returns the function filling the field of an expansion, or nil if the expansion is unknown.
+// Each function writes a distinct field, so they can run concurrently.
+func (d *PullRequestDetail) fetcher(ctx context.Context, client interfaces.GitHubClient, owner, repo string, number int, sha, name string) func() error {
+	switch name {
+	case ExpandFiles:
+		return func() (err error) {
+			d.Files, err = allPages(func(opt *github.ListOptions) ([]*github.CommitFile, *github.Response, error) {
+				return client.ListPullRequestFiles(ctx, owner, repo, number, opt)
+			})
+			if err == nil {
+				d.FileStats = fileStats(d.Files)
+			}
+			return err
+		}
+	case ExpandCommits:
+		return func() (err error) {
+			d.Commits, err = allPages(func(opt *github.ListOptions) ([]*github.RepositoryCommit, *github.Response, error) {
+				return client.ListPullRequestCommits(ctx, owner, repo, number, opt)
+			})
+			return err
+		}
+	case ExpandReviews:
+		return func() (err error) {
+			d.Reviews, err = allPages(func(opt *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+				return client.ListPullRequestReviews(ctx, owner, repo, number, opt)
+			})
+			return err
+		}
+	case ExpandReviewComments:
+		return func() (err error) {
+			d.ReviewComments, err = allPages(func(opt *github.ListOptions) ([]*github.PullRequestComment, *github.Response, error) {
+				return client.ListPullRequestComments(ctx, owner, repo, number, &github.PullRequestListCommentsOptions{ListOptions: *opt})
+			})
+			return err
+		}
+	case ExpandStatus:
+		return func() (err error) {
+			d.Status, err = combinedStatus(ctx, client, owner, repo, sha)
+			return err
+		}
+	case ExpandChecks:
+		return func() (err error) {
+			d.CheckRuns, err = checkRuns(ctx, client, owner, repo, sha)
+			return err
+		}
+	}
+	return nil
+}
+
+// combinedStatus retrieves the combined status of a commit, gathering the statuses of every page.
+func combinedStatus(ctx context.Context, client interfaces.GitHubClient, owner, repo, ref string) (*github.CombinedStatus, error) {
+	var combined *github.CombinedStatus
+	statuses, err := allPages(func(opt *github.ListOptions) ([]*github.RepoStatus, *github.Response, error) {
+		status, resp, err := client.GetCombinedStatus(ctx, owner, repo, ref, opt)
+		if err != nil {
+			return nil, resp, err
+		}
+		if combined == nil {
+			combined = status
+		}
+		return status.Statuses, resp, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	combined.Statuses = statuses
+	return combined, nil
+}
+
+// checkRuns lists the check runs of a commit, gathering every page.
+func checkRuns(ctx context.Context, client interfaces.GitHubClient, owner, repo, ref string) ([]*github.CheckRun, error) {
+	return allPages(func(opt *github.ListOptions) ([]*github.CheckRun, *github.Response, error) {
+		result, resp, err := client.ListCheckRunsForRef(ctx, owner, repo, ref, &github.ListCheckRunsOptions{ListOptions: *opt})
+		if err != nil {
+			return nil, resp, err
+		}
+		return result.CheckRuns, resp, nil
+	})
+}
+
+// fileStats sums the line changes of the given files.
+func fileStats(files []*github.CommitFile) *PullRequestFileStats {
+	stats := &PullRequestFileStats{Files: len(files)}
+	for _, file := range files {
+		stats.Additions += file.GetAdditions()
+		stats.Deletions += file.GetDeletions()
+		stats.Changes += file.GetChanges()
+	}
+	return stats
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseExpansions tests the validation and ordering of pull request expansions.
+func TestParseExpansions(t *testing.T) {
+	expand, err := ParseExpansions([]string{"checks", "files", "checks"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ExpandFiles, ExpandChecks}, expand)
+
+	expand, err = ParseExpansions([]string{"files", "all"})
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestExpansions, expand)
+
+	_, err = ParseExpansions([]string{"labels"})
+	assert.Error(t, err)
+}
+
+// TestGetPullRequestDetail tests that the requested expansions are fetched across pages and others left out.
+func TestGetPullRequestDetail(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetPullRequest", mock.Anything, "octocat", "hello", 7).Return(
+		&github.PullRequest{Number: github.Int(7), Head: &github.PullRequestBranch{SHA: github.String(headSHA)}},
+		&github.Response{},
+		nil)
+	mockClient.On("ListPullRequestFiles", mock.Anything, "octocat", "hello", 7, &github.ListOptions{PerPage: 100}).Return(
+		[]*github.CommitFile{{Filename: github.String("main.go"), Additions: github.Int(10), Deletions: github.Int(2), Changes: github.Int(12)}},
+		&github.Response{NextPage: 2},
+		nil).Once()
+	mockClient.On("ListPullRequestFiles", mock.Anything, "octocat", "hello", 7, mock.Anything).Return(
+		[]*github.CommitFile{{Filename: github.String("README.md"), Additions: github.Int(1), Changes: github.Int(1)}},
+		&github.Response{},
+		nil)
+	mockClient.On("ListCheckRunsForRef", mock.Anything, "octocat", "hello", headSHA, mock.Anything).Return(
+		&github.ListCheckRunsResults{Total: github.Int(1), CheckRuns: []*github.CheckRun{{Name: github.String("build")}}},
+		&github.Response{},
+		nil)
+	mockClient.On("GetCombinedStatus", mock.Anything, "octocat", "hello", headSHA, mock.Anything).Return(
+		&github.CombinedStatus{State: github.String("success"), Statuses: []*github.RepoStatus{{Context: github.String("ci")}}},
+		&github.Response{},
+		nil)
+
+	detail, err := GetPullRequestDetail(context.Background(), mockClient, "octocat", "hello", 7, []string{ExpandFiles, ExpandStatus, ExpandChecks})
+
+	require.NoError(t, err)
+	require.Len(t, detail.Files, 2)
+	assert.Equal(t, PullRequestFileStats{Files: 2, Additions: 11, Deletions: 2, Changes: 13}, *detail.FileStats)
+	assert.Equal(t, "success", detail.Status.GetState())
+	assert.Len(t, detail.Status.Statuses, 1)
+	require.Len(t, detail.CheckRuns, 1)
+	assert.Nil(t, detail.Commits)
+	assert.Nil(t, detail.Reviews)
+	mockClient.AssertNotCalled(t, "ListPullRequestCommits", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestGetPullRequestDetailError tests that a failed expansion fails the whole detail.
+func TestGetPullRequestDetailError(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetPullRequest", mock.Anything, "octocat", "hello", 7).Return(
+		&github.PullRequest{Number: github.Int(7)},
+		&github.Response{},
+		nil)
+	mockClient.On("ListPullRequestReviews", mock.Anything, "octocat", "hello", 7, mock.Anything).Return(
+		([]*github.PullRequestReview)(nil),
+		&github.Response{},
+		errors.New("boom"))
+	mockClient.On("ListPullRequestCommits", mock.Anything, "octocat", "hello", 7, mock.Anything).Return(
+		[]*github.RepositoryCommit{},
+		&github.Response{},
+		nil)
+
+	_, err := GetPullRequestDetail(context.Background(), mockClient, "octocat", "hello", 7, []string{ExpandCommits, ExpandReviews})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "reviews")
+}
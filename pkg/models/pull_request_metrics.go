@@ -0,0 +1,427 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// defaultMetricsRange is the range covered by the metrics when no start is given.
+const defaultMetricsRange = 30 * 24 * time.Hour
+
+// metricsConcurrency bounds the number of pull requests fetched at once by multi-repository reports.
+const metricsConcurrency = 8
+
+// Size classes of a pull request, by number of changed lines.
+const (
+	SizeXS = "XS"
+	SizeS  = "S"
+	SizeM  = "M"
+	SizeL  = "L"
+	SizeXL = "XL"
+)
+
+// PullRequestMetricsRow holds the delivery metrics of a single pull request.
+// Durations are in hours and are null when the event has not happened.
+type PullRequestMetricsRow struct {
+	Repository         string     `json:"repository"`
+	Number             int        `json:"number"`
+	Title              string     `json:"title"`
+	Author             string     `json:"author"`
+	CreatedAt          time.Time  `json:"created_at"`
+	FirstReviewAt      *time.Time `json:"first_review_at"`
+	MergedAt           *time.Time `json:"merged_at"`
+	ClosedAt           *time.Time `json:"closed_at"`
+	HoursToFirstReview *float64   `json:"hours_to_first_review"`
+	HoursToMerge       *float64   `json:"hours_to_merge"`
+	Additions          int        `json:"additions"`
+	Deletions          int        `json:"deletions"`
+	Size               string     `json:"size"`
+	ReviewRounds       int        `json:"review_rounds"`
+}
+
+// MetricSummary summarizes a distribution of values.
+type MetricSummary struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P90    float64 `json:"p90"`
+}
+
+// SizeDistribution counts pull requests by size class.
+// XS is under 10 changed lines, S under 50, M under 250, L under 1000 and XL anything larger.
+type SizeDistribution struct {
+	XS int `json:"XS"`
+	S  int `json:"S"`
+	M  int `json:"M"`
+	L  int `json:"L"`
+	XL int `json:"XL"`
+}
+
+// WeeklyThroughput counts the pull requests opened, merged and closed without merging in a week.
+// Week is the Monday starting the week, as YYYY-MM-DD in UTC.
+type WeeklyThroughput struct {
+	Week   string `json:"week"`
+	Opened int    `json:"opened"`
+	Merged int    `json:"merged"`
+	Closed int    `json:"closed"`
+}
+
+// PullRequestMetrics are the delivery metrics of a set of repositories over a date range.
+// PullRequests holds every pull request opened, merged or closed within the range.
+// TimeToMerge and ReviewRounds cover the pull requests merged within the range, and
+// TimeToFirstReview the pull requests that received a review.
+type PullRequestMetrics struct {
+	Since                  time.Time               `json:"since"`
+	Until                  time.Time               `json:"until"`
+	Repositories           []string                `json:"repositories"`
+	TimeToFirstReviewHours MetricSummary           `json:"time_to_first_review_hours"`
+	TimeToMergeHours       MetricSummary           `json:"time_to_merge_hours"`
+	ReviewRounds           MetricSummary           `json:"review_rounds"`
+	Sizes                  SizeDistribution        `json:"sizes"`
+	Throughput             []WeeklyThroughput      `json:"throughput"`
+	PullRequests           []PullRequestMetricsRow `json:"pull_requests"`
+	Errors                 []RepositoryError       `json:"errors,omitempty"`
+}
+
+// ParseDateRange parses the since and until query parameters of a report.
+// Both accept an RFC 3339 timestamp or a YYYY-MM-DD date. Until defaults to now
+// and since to 30 days before until.
+//
+// Parameters:
+//   - since: The start of the range, or empty.
+//   - until: The end of the range, or empty.
+//   - now: The current time.
+//
+// Returns:
+//   - time.Time: The start of the range.
+//   - time.Time: The end of the range.
+//   - error: An error if a value cannot be parsed or the range is empty.
+func ParseDateRange(since, until string, now time.Time) (time.Time, time.Time, error) {
+	end := now
+	if until != "" {
+		t, err := ParseTime(until)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid until: %w", err)
+		}
+		end = t
+	}
+	start := end.Add(-defaultMetricsRange)
+	if since != "" {
+		t, err := ParseTime(since)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid since: %w", err)
+		}
+		start = t
+	}
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range: since %s is not before until %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return start, end, nil
+}
+
+// ListTeamRepositories lists the full names of the repositories of a team, following pagination.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - org: The login of the organization.
+//   - team: The slug of the team.
+//
+// Returns:
+//   - []string: The repositories of the team, as "owner/name".
+//   - error: An error if any page cannot be retrieved.
+func ListTeamRepositories(ctx context.Context, client interfaces.GitHubClient, org, team string) ([]string, error) {
+	repos, err := allPages(func(opt *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return client.ListTeamRepos(ctx, org, team, opt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		names = append(names, repo.GetFullName())
+	}
+	return names, nil
+}
+
+// BuildPullRequestMetrics computes the delivery metrics of the pull requests of each repository
+// active between since and until. Pull requests are listed by last update, so listing stops at the
+// first pull request not updated since the start of the range. The size and reviews of each
+// pull request are then fetched concurrently.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - repos: The repositories to report on, as "owner/name".
+//   - since: The start of the range, inclusive.
+//   - until: The end of the range, exclusive.
+//
+// Returns:
+//   - PullRequestMetrics: The metrics, with the repositories that could not be read listed in Errors.
+func BuildPullRequestMetrics(ctx context.Context, client interfaces.GitHubClient, repos []string, since, until time.Time) PullRequestMetrics {
+	metrics := PullRequestMetrics{Since: since, Until: until, Repositories: []string{}, PullRequests: []PullRequestMetricsRow{}}
+	for _, fullName := range repos {
+		owner, name, err := SplitFullName(fullName)
+		if err == nil {
+			var rows []PullRequestMetricsRow
+			rows, err = repositoryPullRequestMetrics(ctx, client, owner, name, since, until)
+			metrics.PullRequests = append(metrics.PullRequests, rows...)
+		}
+		if err != nil {
+			metrics.Errors = append(metrics.Errors, RepositoryError{Repository: fullName, Error: err.Error()})
+			continue
+		}
+		metrics.Repositories = append(metrics.Repositories, fullName)
+	}
+	metrics.summarize()
+	return metrics
+}
+
+// repositoryPullRequestMetrics computes the metrics of the pull requests of a repository active in the range.
+func repositoryPullRequestMetrics(ctx context.Context, client interfaces.GitHubClient, owner, repo string, since, until time.Time) ([]PullRequestMetricsRow, error) {
+	var active []*github.PullRequest
+	opt := &github.PullRequestListOptions{State: "all", Sort: "updated", Direction: "desc", ListOptions: github.ListOptions{PerPage: 100}}
+	for done := false; !done; {
+		pulls, resp, err := client.ListPullRequests(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			if pull.GetUpdatedAt().Before(since) {
+				done = true
+				break
+			}
+			if inRange(pull.GetCreatedAt().Time, since, until) || (pull.ClosedAt != nil && inRange(pull.GetClosedAt().Time, since, until)) {
+				active = append(active, pull)
+			}
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	rows := make([]PullRequestMetricsRow, len(active))
+	err := forEachLimit(len(active), metricsConcurrency, func(i int) (err error) {
+		rows[i], err = pullRequestMetricsRow(ctx, client, owner, repo, active[i])
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.Before(rows[j].CreatedAt) })
+	return rows, nil
+}
+
+// pullRequestMetricsRow fetches the size and reviews of a pull request and computes its metrics.
+// The list endpoint does not report line counts, so the pull request is retrieved again.
+func pullRequestMetricsRow(ctx context.Context, client interfaces.GitHubClient, owner, repo string, pull *github.PullRequest) (PullRequestMetricsRow, error) {
+	full, _, err := client.GetPullRequest(ctx, owner, repo, pull.GetNumber())
+	if err != nil {
+		return PullRequestMetricsRow{}, err
+	}
+	reviews, err := allPages(func(opt *github.ListOptions) ([]*github.PullRequestReview, *github.Response, error) {
+		return client.ListPullRequestReviews(ctx, owner, repo, pull.GetNumber(), opt)
+	})
+	if err != nil {
+		return PullRequestMetricsRow{}, err
+	}
+
+	author := pull.GetUser().GetLogin()
+	row := PullRequestMetricsRow{
+		Repository: owner + "/" + repo,
+		Number:     pull.GetNumber(),
+		Title:      pull.GetTitle(),
+		Author:     author,
+		CreatedAt:  pull.GetCreatedAt().Time,
+		Additions:  full.GetAdditions(),
+		Deletions:  full.GetDeletions(),
+		Size:       sizeClass(full.GetAdditions() + full.GetDeletions()),
+	}
+	if pull.MergedAt != nil {
+		merged := pull.GetMergedAt().Time
+		row.MergedAt = &merged
+		row.HoursToMerge = hoursBetween(row.CreatedAt, merged)
+	}
+	if pull.ClosedAt != nil {
+		closed := pull.GetClosedAt().Time
+		row.ClosedAt = &closed
+	}
+
+	commits := map[string]bool{}
+	for _, review := range reviews {
+		if review.GetUser().GetLogin() == author || review.GetState() == "PENDING" || review.SubmittedAt == nil {
+			continue
+		}
+		submitted := review.GetSubmittedAt().Time
+		if row.FirstReviewAt == nil || submitted.Before(*row.FirstReviewAt) {
+			row.FirstReviewAt = &submitted
+		}
+		commits[review.GetCommitID()] = true
+	}
+	if row.FirstReviewAt != nil {
+		row.HoursToFirstReview = hoursBetween(row.CreatedAt, *row.FirstReviewAt)
+	}
+	row.ReviewRounds = len(commits)
+	return row, nil
+}
+
+// summarize computes the summaries, size distribution and weekly throughput from the rows.
+func (m *PullRequestMetrics) summarize() {
+	var toFirstReview, toMerge, rounds []float64
+	weeks := map[string]*WeeklyThroughput{}
+	week := func(t time.Time) *WeeklyThroughput {
+		return weeks[weekStart(t).Format(time.DateOnly)]
+	}
+	for start := weekStart(m.Since); start.Before(m.Until); start = start.AddDate(0, 0, 7) {
+		key := start.Format(time.DateOnly)
+		weeks[key] = &WeeklyThroughput{Week: key}
+		m.Throughput = append(m.Throughput, WeeklyThroughput{Week: key})
+	}
+
+	for _, row := range m.PullRequests {
+		switch row.Size {
+		case SizeXS:
+			m.Sizes.XS++
+		case SizeS:
+			m.Sizes.S++
+		case SizeM:
+			m.Sizes.M++
+		case SizeL:
+			m.Sizes.L++
+		default:
+			m.Sizes.XL++
+		}
+		if row.HoursToFirstReview != nil {
+			toFirstReview = append(toFirstReview, *row.HoursToFirstReview)
+		}
+		if inRange(row.CreatedAt, m.Since, m.Until) {
+			week(row.CreatedAt).Opened++
+		}
+		switch {
+		case row.MergedAt != nil && inRange(*row.MergedAt, m.Since, m.Until):
+			toMerge = append(toMerge, *row.HoursToMerge)
+			rounds = append(rounds, float64(row.ReviewRounds))
+			week(*row.MergedAt).Merged++
+		case row.MergedAt == nil && row.ClosedAt != nil && inRange(*row.ClosedAt, m.Since, m.Until):
+			week(*row.ClosedAt).Closed++
+		}
+	}
+	for i := range m.Throughput {
+		m.Throughput[i] = *weeks[m.Throughput[i].Week]
+	}
+	m.TimeToFirstReviewHours = summarize(toFirstReview)
+	m.TimeToMergeHours = summarize(toMerge)
+	m.ReviewRounds = summarize(rounds)
+}
+
+// CSV returns the pull request rows of the metrics as CSV records, starting with a header.
+func (m PullRequestMetrics) CSV() [][]string {
+	records := [][]string{{
+		"repository", "number", "title", "author", "created_at", "first_review_at", "merged_at", "closed_at",
+		"hours_to_first_review", "hours_to_merge", "additions", "deletions", "size", "review_rounds",
+	}}
+	for _, row := range m.PullRequests {
+		records = append(records, []string{
+			row.Repository,
+			strconv.Itoa(row.Number),
+			row.Title,
+			row.Author,
+			row.CreatedAt.UTC().Format(time.RFC3339),
+			formatOptionalTime(row.FirstReviewAt),
+			formatOptionalTime(row.MergedAt),
+			formatOptionalTime(row.ClosedAt),
+			formatOptionalHours(row.HoursToFirstReview),
+			formatOptionalHours(row.HoursToMerge),
+			strconv.Itoa(row.Additions),
+			strconv.Itoa(row.Deletions),
+			row.Size,
+			strconv.Itoa(row.ReviewRounds),
+		})
+	}
+	return records
+}
+
+// summarize returns the count, mean, median and 90th percentile of values, rounded to one decimal place.
+// Percentiles use the nearest-rank method.
+func summarize(values []float64) MetricSummary {
+	if len(values) == 0 {
+		return MetricSummary{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	rank := func(p float64) float64 {
+		return sorted[int(math.Ceil(p*float64(len(sorted))))-1]
+	}
+	return MetricSummary{
+		Count:  len(sorted),
+		Mean:   roundTenth(sum / float64(len(sorted))),
+		Median: roundTenth(rank(0.5)),
+		P90:    roundTenth(rank(0.9)),
+	}
+}
+
+// sizeClass returns the size class of a pull request changing the given number of lines.
+func sizeClass(lines int) string {
+	switch {
+	case lines < 10:
+		return SizeXS
+	case lines < 50:
+		return SizeS
+	case lines < 250:
+		return SizeM
+	case lines < 1000:
+		return SizeL
+	default:
+		return SizeXL
+	}
+}
+
+// weekStart returns the Monday starting the week of t, at midnight UTC.
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-offset, 0, 0, 0, 0, time.UTC)
+}
+
+// inRange reports whether t falls within [since, until).
+func inRange(t, since, until time.Time) bool {
+	return !t.Before(since) && t.Before(until)
+}
+
+// hoursBetween returns the number of hours from start to end, rounded to one decimal place.
+func hoursBetween(start, end time.Time) *float64 {
+	hours := roundTenth(end.Sub(start).Hours())
+	return &hours
+}
+
+// roundTenth rounds a value to one decimal place.
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
+
+// formatOptionalTime formats a time as RFC 3339, or returns an empty string if it is nil.
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// formatOptionalHours formats a number of hours, or returns an empty string if it is nil.
+func formatOptionalHours(hours *float64) string {
+	if hours == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*hours, 'f', 1, 64)
+}
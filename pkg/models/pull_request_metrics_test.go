@@ -0,0 +1,109 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// ts returns a GitHub timestamp at the given offset from the start of June 2024 (a Saturday).
+func ts(offset time.Duration) *github.Timestamp {
+	return &github.Timestamp{Time: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC).Add(offset)}
+}
+
+// TestBuildPullRequestMetrics tests the per pull request metrics and their aggregation.
+func TestBuildPullRequestMetrics(t *testing.T) {
+	since := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 14)
+	author := &github.User{Login: github.String("alice")}
+	reviewer := &github.User{Login: github.String("bob")}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListPullRequests", mock.Anything, "octocat", "api", mock.Anything).Return(
+		[]*github.PullRequest{
+			{Number: github.Int(3), User: author, CreatedAt: ts(72 * time.Hour), UpdatedAt: ts(80 * time.Hour)},
+			{Number: github.Int(2), User: author, CreatedAt: ts(0), UpdatedAt: ts(50 * time.Hour), ClosedAt: ts(48 * time.Hour), MergedAt: ts(48 * time.Hour)},
+			{Number: github.Int(1), User: author, CreatedAt: ts(-240 * time.Hour), UpdatedAt: ts(-24 * time.Hour)},
+		},
+		&github.Response{NextPage: 2},
+		nil)
+	mockClient.On("GetPullRequest", mock.Anything, "octocat", "api", 2).Return(
+		&github.PullRequest{Additions: github.Int(120), Deletions: github.Int(30)},
+		&github.Response{},
+		nil)
+	mockClient.On("GetPullRequest", mock.Anything, "octocat", "api", 3).Return(
+		&github.PullRequest{Additions: github.Int(4)},
+		&github.Response{},
+		nil)
+	mockClient.On("ListPullRequestReviews", mock.Anything, "octocat", "api", 2, mock.Anything).Return(
+		[]*github.PullRequestReview{
+			{User: reviewer, State: github.String("CHANGES_REQUESTED"), CommitID: github.String("a"), SubmittedAt: ts(6 * time.Hour)},
+			{User: author, State: github.String("COMMENTED"), CommitID: github.String("a"), SubmittedAt: ts(2 * time.Hour)},
+			{User: reviewer, State: github.String("APPROVED"), CommitID: github.String("b"), SubmittedAt: ts(40 * time.Hour)},
+		},
+		&github.Response{},
+		nil)
+	mockClient.On("ListPullRequestReviews", mock.Anything, "octocat", "api", 3, mock.Anything).Return(
+		[]*github.PullRequestReview{},
+		&github.Response{},
+		nil)
+
+	metrics := BuildPullRequestMetrics(context.Background(), mockClient, []string{"octocat/api"}, since, until)
+
+	require.Empty(t, metrics.Errors)
+	require.Len(t, metrics.PullRequests, 2)
+	merged := metrics.PullRequests[0]
+	assert.Equal(t, 2, merged.Number)
+	assert.Equal(t, 6.0, *merged.HoursToFirstReview)
+	assert.Equal(t, 48.0, *merged.HoursToMerge)
+	assert.Equal(t, 2, merged.ReviewRounds)
+	assert.Equal(t, SizeM, merged.Size)
+	assert.Nil(t, metrics.PullRequests[1].HoursToMerge)
+
+	assert.Equal(t, MetricSummary{Count: 1, Mean: 48, Median: 48, P90: 48}, metrics.TimeToMergeHours)
+	assert.Equal(t, SizeDistribution{XS: 1, M: 1}, metrics.Sizes)
+	assert.Equal(t, []WeeklyThroughput{
+		{Week: "2024-05-27", Opened: 1},
+		{Week: "2024-06-03", Opened: 1, Merged: 1},
+		{Week: "2024-06-10"},
+	}, metrics.Throughput)
+	mockClient.AssertNotCalled(t, "GetPullRequest", mock.Anything, "octocat", "api", 1)
+
+	records := metrics.CSV()
+	require.Len(t, records, 3)
+	assert.Equal(t, "repository", records[0][0])
+	assert.Equal(t, []string{"octocat/api", "2"}, records[1][:2])
+	assert.Equal(t, "48.0", records[1][9])
+	assert.Equal(t, "", records[2][9])
+}
+
+// TestSummarize tests the nearest-rank summary of a distribution.
+func TestSummarize(t *testing.T) {
+	assert.Equal(t, MetricSummary{}, summarize(nil))
+	assert.Equal(t, MetricSummary{Count: 10, Mean: 5.5, Median: 5, P90: 9},
+		summarize([]float64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}))
+}
+
+// TestParseDateRange tests the defaults and validation of a report range.
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	since, until, err := ParseDateRange("", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, now, until)
+	assert.Equal(t, now.AddDate(0, 0, -30), since)
+
+	since, _, err = ParseDateRange("2024-06-01", "", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), since)
+
+	_, _, err = ParseDateRange("2024-07-01", "2024-06-01", now)
+	assert.Error(t, err)
+	_, _, err = ParseDateRange("yesterday", "", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const headSHA = "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+
+// openPullRequest returns a mergeable open pull request at headSHA.
+func openPullRequest() *github.PullRequest {
+	return &github.PullRequest{
+		State:          github.String("open"),
+		Head:           &github.PullRequestBranch{SHA: github.String(headSHA)},
+		Mergeable:      github.Bool(true),
+		MergeableState: github.String("clean"),
+	}
+}
+
+// TestCheckMergeable tests the errors reported for each pull request state.
+func TestCheckMergeable(t *testing.T) {
+	assert.NoError(t, CheckMergeable(openPullRequest(), headSHA))
+
+	unknown := openPullRequest()
+	unknown.Mergeable = nil
+	assert.NoError(t, CheckMergeable(unknown, headSHA))
+
+	assert.True(t, errors.Is(CheckMergeable(openPullRequest(), "0000000000000000000000000000000000000000"), ErrHeadChanged))
+
+	conflict := openPullRequest()
+	conflict.Mergeable = github.Bool(false)
+	conflict.MergeableState = github.String("dirty")
+	assert.True(t, errors.Is(CheckMergeable(conflict, headSHA), ErrMergeConflict))
+
+	blocked := openPullRequest()
+	blocked.Mergeable = github.Bool(false)
+	blocked.MergeableState = github.String("blocked")
+	assert.True(t, errors.Is(CheckMergeable(blocked, headSHA), ErrNotMergeable))
+
+	closed := openPullRequest()
+	closed.State = github.String("closed")
+	assert.True(t, errors.Is(CheckMergeable(closed, headSHA), ErrNotMergeable))
+
+	draft := openPullRequest()
+	draft.Draft = github.Bool(true)
+	assert.True(t, errors.Is(CheckMergeable(draft, headSHA), ErrNotMergeable))
+}
+
+// TestPullRequestMergeRequestValidate tests the default merge method and the SHA and method checks.
+func TestPullRequestMergeRequestValidate(t *testing.T) {
+	req := PullRequestMergeRequest{SHA: headSHA}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "merge", req.Options().MergeMethod)
+	assert.Equal(t, headSHA, req.Options().SHA)
+
+	req = PullRequestMergeRequest{SHA: headSHA, MergeMethod: "fast-forward"}
+	assert.Error(t, req.Validate())
+
+	req = PullRequestMergeRequest{SHA: "6dcb09b"}
+	assert.Error(t, req.Validate())
+}
+
+// TestPullRequestUpdateRequest tests the conversion of a pull request update.
+func TestPullRequestUpdateRequest(t *testing.T) {
+	pull, err := PullRequestUpdateRequest{Title: github.String("New title"), Base: github.String("main")}.ToPullRequest()
+	require.NoError(t, err)
+	assert.Equal(t, "New title", pull.GetTitle())
+	assert.Equal(t, "main", pull.GetBase().GetRef())
+	assert.Nil(t, pull.State)
+
+	_, err = PullRequestUpdateRequest{State: github.String("merged")}.ToPullRequest()
+	assert.Error(t, err)
+}
+
+// TestMissingPullRequestFields tests the required fields of a new pull request.
+func TestMissingPullRequestFields(t *testing.T) {
+	assert.Equal(t, []string{"head", "base", "title"}, MissingPullRequestFields(&github.NewPullRequest{}))
+	assert.Empty(t, MissingPullRequestFields(&github.NewPullRequest{Head: github.String("feature"), Base: github.String("main"), Issue: github.Int(12)}))
+}
@@ -0,0 +1,331 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"sort"
+	"strings"
+	"time"
+)
+
+// OtherNotesGroup is the release notes group of the pull requests matching no other group.
+const OtherNotesGroup = "Other"
+
+// ReleaseRequest is the payload accepted when creating or editing a release.
+// Fields that are omitted are left unchanged when editing.
+//
+// When creating a release without a body, PreviousTagName generates the body from the pull
+// requests merged since that tag, grouped by NoteGroups as in ReleaseNotesOptions.
+type ReleaseRequest struct {
+	TagName              *string  `json:"tag_name"`
+	TargetCommitish      *string  `json:"target_commitish"`
+	Name                 *string  `json:"name"`
+	Body                 *string  `json:"body"`
+	Draft                *bool    `json:"draft"`
+	Prerelease           *bool    `json:"prerelease"`
+	MakeLatest           *string  `json:"make_latest"`
+	GenerateReleaseNotes *bool    `json:"generate_release_notes"`
+	PreviousTagName      string   `json:"previous_tag_name"`
+	NoteGroups           []string `json:"note_groups"`
+}
+
+// ToRelease validates the request and converts it to the release sent to GitHub.
+//
+// Returns:
+//   - *github.RepositoryRelease: The fields of the release.
+//   - error: An error if make_latest is invalid.
+func (r ReleaseRequest) ToRelease() (*github.RepositoryRelease, error) {
+	if r.MakeLatest != nil {
+		if err := oneOf("make_latest", *r.MakeLatest, "true", "false", "legacy"); err != nil {
+			return nil, err
+		}
+	}
+	return &github.RepositoryRelease{
+		TagName:              r.TagName,
+		TargetCommitish:      r.TargetCommitish,
+		Name:                 r.Name,
+		Body:                 r.Body,
+		Draft:                r.Draft,
+		Prerelease:           r.Prerelease,
+		MakeLatest:           r.MakeLatest,
+		GenerateReleaseNotes: r.GenerateReleaseNotes,
+	}, nil
+}
+
+// NotesRange returns the range of the release notes to generate for a new release, from the
+// previous tag to the target of the release, or to its tag when no target is given.
+//
+// Returns:
+//   - string: The tag to compare from.
+//   - string: The commit, branch or tag to compare to.
+//   - bool: False if no release notes are requested.
+func (r ReleaseRequest) NotesRange() (string, string, bool) {
+	if r.PreviousTagName == "" || r.Body != nil {
+		return "", "", false
+	}
+	if r.GetTargetCommitish() != "" {
+		return r.PreviousTagName, r.GetTargetCommitish(), true
+	}
+	return r.PreviousTagName, r.GetTagName(), true
+}
+
+// GetTagName returns the tag name of the release, or an empty string.
+func (r ReleaseRequest) GetTagName() string {
+	if r.TagName == nil {
+		return ""
+	}
+	return *r.TagName
+}
+
+// GetTargetCommitish returns the target of the release, or an empty string.
+func (r ReleaseRequest) GetTargetCommitish() string {
+	if r.TargetCommitish == nil {
+		return ""
+	}
+	return *r.TargetCommitish
+}
+
+// TagRequest is the payload accepted when creating a tag. A lightweight tag is created
+// unless a message is given, in which case an annotated tag object is created first.
+type TagRequest struct {
+	Tag     string `json:"tag"`
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+}
+
+// Validate checks the tag request.
+//
+// Returns:
+//   - error: An error if the tag name is invalid or the SHA is not a full commit SHA.
+func (r TagRequest) Validate() error {
+	if r.Tag == "" || strings.HasPrefix(r.Tag, "refs/") || strings.ContainsAny(r.Tag, " ~^:?*[\\") {
+		return fmt.Errorf("invalid tag %q", r.Tag)
+	}
+	if !shaPattern.MatchString(r.SHA) {
+		return fmt.Errorf("invalid sha %q: expected a full commit SHA", r.SHA)
+	}
+	return nil
+}
+
+// Create creates the tag in a repository.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//
+// Returns:
+//   - *github.Reference: The reference of the tag, pointing to the commit or to the tag object.
+//   - error: An error if the tag object or the reference cannot be created.
+func (r TagRequest) Create(ctx context.Context, client interfaces.GitHubClient, owner, repo string) (*github.Reference, error) {
+	target := r.SHA
+	if r.Message != "" {
+		tag, _, err := client.CreateTag(ctx, owner, repo, &github.Tag{
+			Tag:     github.String(r.Tag),
+			Message: github.String(r.Message),
+			Object:  &github.GitObject{Type: github.String("commit"), SHA: github.String(r.SHA)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		target = tag.GetSHA()
+	}
+	ref, _, err := client.CreateRef(ctx, owner, repo, &github.Reference{
+		Ref:    github.String("refs/tags/" + r.Tag),
+		Object: &github.GitObject{SHA: github.String(target)},
+	})
+	return ref, err
+}
+
+// ReleaseNotesOptions selects the range and the grouping of release notes.
+// Groups lists label names in order; a pull request is listed under the first group whose
+// label it carries, or under OtherNotesGroup. Without groups, every label of the merged pull
+// requests forms a group, in alphabetical order.
+type ReleaseNotesOptions struct {
+	From   string
+	To     string
+	Groups []string
+}
+
+// ReleaseNote is a merged pull request listed in release notes.
+type ReleaseNote struct {
+	Number   int       `json:"number"`
+	Title    string    `json:"title"`
+	Author   string    `json:"author"`
+	URL      string    `json:"url"`
+	Labels   []string  `json:"labels"`
+	MergedAt time.Time `json:"merged_at"`
+}
+
+// ReleaseNotesGroup lists the merged pull requests of a group, in merge order.
+type ReleaseNotesGroup struct {
+	Title        string        `json:"title"`
+	PullRequests []ReleaseNote `json:"pull_requests"`
+}
+
+// ReleaseNotes are the notes of the pull requests merged between two tags.
+// Body renders the groups as Markdown, ready to be used as the body of a release.
+type ReleaseNotes struct {
+	Repository string              `json:"repository"`
+	From       string              `json:"from"`
+	To         string              `json:"to"`
+	Commits    int                 `json:"commits"`
+	CompareURL string              `json:"compare_url"`
+	Groups     []ReleaseNotesGroup `json:"groups"`
+	Body       string              `json:"body"`
+}
+
+// GenerateReleaseNotes lists the pull requests merged between two tags, grouped by label.
+// A pull request is part of the range when its merge commit is, which holds for merge,
+// squash and rebase merges alike. Closed pull requests are read most recently updated first,
+// down to the merge base of the range.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - opts: The range and the grouping of the notes.
+//
+// Returns:
+//   - ReleaseNotes: The release notes.
+//   - error: An error if the range cannot be compared or the pull requests cannot be listed.
+func GenerateReleaseNotes(ctx context.Context, client interfaces.GitHubClient, owner, repo string, opts ReleaseNotesOptions) (ReleaseNotes, error) {
+	notes := ReleaseNotes{Repository: owner + "/" + repo, From: opts.From, To: opts.To, Groups: []ReleaseNotesGroup{}}
+
+	commits := map[string]bool{}
+	var since time.Time
+	page := &github.ListOptions{PerPage: 100}
+	for {
+		comparison, resp, err := client.CompareCommits(ctx, owner, repo, opts.From, opts.To, page)
+		if err != nil {
+			return ReleaseNotes{}, err
+		}
+		for _, commit := range comparison.Commits {
+			commits[commit.GetSHA()] = true
+		}
+		if page.Page == 0 {
+			notes.Commits = comparison.GetTotalCommits()
+			notes.CompareURL = comparison.GetHTMLURL()
+			since = comparison.GetMergeBaseCommit().GetCommit().GetCommitter().GetDate().Time
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		page.Page = resp.NextPage
+	}
+
+	var merged []ReleaseNote
+	opt := &github.PullRequestListOptions{State: "closed", Sort: "updated", Direction: "desc", ListOptions: github.ListOptions{PerPage: 100}}
+	for len(commits) > 0 {
+		pulls, resp, err := client.ListPullRequests(ctx, owner, repo, opt)
+		if err != nil {
+			return ReleaseNotes{}, err
+		}
+		done := false
+		for _, pull := range pulls {
+			// A pull request last updated before the merge base was merged before it too.
+			if pull.GetUpdatedAt().Time.Before(since) {
+				done = true
+				break
+			}
+			if pull.MergedAt == nil || !commits[pull.GetMergeCommitSHA()] {
+				continue
+			}
+			note := ReleaseNote{
+				Number:   pull.GetNumber(),
+				Title:    pull.GetTitle(),
+				Author:   pull.GetUser().GetLogin(),
+				URL:      pull.GetHTMLURL(),
+				Labels:   []string{},
+				MergedAt: pull.GetMergedAt().Time,
+			}
+			for _, label := range pull.Labels {
+				note.Labels = append(note.Labels, label.GetName())
+			}
+			merged = append(merged, note)
+		}
+		if done || resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		if !merged[i].MergedAt.Equal(merged[j].MergedAt) {
+			return merged[i].MergedAt.Before(merged[j].MergedAt)
+		}
+		return merged[i].Number < merged[j].Number
+	})
+	notes.Groups = groupReleaseNotes(merged, opts.Groups)
+	notes.Body = notes.Markdown()
+	return notes, nil
+}
+
+// groupReleaseNotes sorts pull requests into groups, leaving out empty groups.
+func groupReleaseNotes(merged []ReleaseNote, groups []string) []ReleaseNotesGroup {
+	if len(groups) == 0 {
+		seen := map[string]bool{}
+		for _, note := range merged {
+			for _, label := range note.Labels {
+				if !seen[label] {
+					seen[label] = true
+					groups = append(groups, label)
+				}
+			}
+		}
+		sort.Strings(groups)
+	}
+
+	byTitle := map[string][]ReleaseNote{}
+	for _, note := range merged {
+		title := OtherNotesGroup
+	match:
+		for _, group := range groups {
+			for _, label := range note.Labels {
+				if strings.EqualFold(label, group) {
+					title = group
+					break match
+				}
+			}
+		}
+		byTitle[title] = append(byTitle[title], note)
+	}
+
+	result := []ReleaseNotesGroup{}
+	titles := append(append([]string{}, groups...), OtherNotesGroup)
+	for _, title := range titles {
+		if notes, ok := byTitle[title]; ok {
+			result = append(result, ReleaseNotesGroup{Title: title, PullRequests: notes})
+			delete(byTitle, title)
+		}
+	}
+	return result
+}
+
+// Markdown renders the release notes as Markdown, one section per group.
+//
+// Returns:
+//   - string: The rendered notes.
+func (n ReleaseNotes) Markdown() string {
+	var b strings.Builder
+	for _, group := range n.Groups {
+		fmt.Fprintf(&b, "## %s\n\n", group.Title)
+		for _, note := range group.PullRequests {
+			fmt.Fprintf(&b, "- %s by @%s in #%d\n", note.Title, note.Author, note.Number)
+		}
+		b.WriteString("\n")
+	}
+	if len(n.Groups) == 0 {
+		b.WriteString("No pull requests were merged in this release.\n\n")
+	}
+	changelog := n.From + "..." + n.To
+	if n.CompareURL != "" {
+		changelog = n.CompareURL
+	}
+	fmt.Fprintf(&b, "**Full Changelog**: %s\n", changelog)
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mergedPull returns a merged pull request with the given merge commit and labels.
+func mergedPull(number int, sha string, mergedAt time.Time, labels ...string) *github.PullRequest {
+	pull := &github.PullRequest{
+		Number:         github.Int(number),
+		Title:          github.String("Change " + sha),
+		User:           &github.User{Login: github.String("octocat")},
+		MergeCommitSHA: github.String(sha),
+		MergedAt:       &github.Timestamp{Time: mergedAt},
+		UpdatedAt:      &github.Timestamp{Time: mergedAt},
+	}
+	for _, label := range labels {
+		pull.Labels = append(pull.Labels, &github.Label{Name: github.String(label)})
+	}
+	return pull
+}
+
+// TestGenerateReleaseNotes tests that only pull requests merged in the range are listed, grouped by label.
+func TestGenerateReleaseNotes(t *testing.T) {
+	base := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CompareCommits", mock.Anything, "octocat", "hello", "v1.0.0", "v1.1.0", &github.ListOptions{PerPage: 100}).Return(
+		&github.CommitsComparison{
+			TotalCommits:    github.Int(3),
+			HTMLURL:         github.String("https://github.com/octocat/hello/compare/v1.0.0...v1.1.0"),
+			MergeBaseCommit: &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: base}}}},
+			Commits:         []*github.RepositoryCommit{{SHA: github.String("a")}, {SHA: github.String("b")}},
+		},
+		&github.Response{NextPage: 2},
+		nil)
+	mockClient.On("CompareCommits", mock.Anything, "octocat", "hello", "v1.0.0", "v1.1.0", &github.ListOptions{PerPage: 100, Page: 2}).Return(
+		&github.CommitsComparison{
+			TotalCommits:    github.Int(3),
+			MergeBaseCommit: &github.RepositoryCommit{Commit: &github.Commit{Committer: &github.CommitAuthor{Date: &github.Timestamp{Time: base}}}},
+			Commits:         []*github.RepositoryCommit{{SHA: github.String("c")}},
+		},
+		&github.Response{},
+		nil)
+	mockClient.On("ListPullRequests", mock.Anything, "octocat", "hello", mock.Anything).Return(
+		[]*github.PullRequest{
+			mergedPull(4, "c", base.Add(72*time.Hour), "bug", "feature"),
+			mergedPull(3, "other-branch", base.Add(48*time.Hour), "feature"),
+			{Number: github.Int(5), UpdatedAt: &github.Timestamp{Time: base.Add(36 * time.Hour)}},
+			mergedPull(2, "b", base.Add(24*time.Hour), "docs"),
+			mergedPull(1, "a", base.Add(time.Hour)),
+			mergedPull(0, "old", base.Add(-time.Hour)),
+		},
+		&github.Response{NextPage: 2},
+		nil).Once()
+
+	notes, err := GenerateReleaseNotes(context.Background(), mockClient, "octocat", "hello", ReleaseNotesOptions{
+		From: "v1.0.0", To: "v1.1.0", Groups: []string{"feature", "bug"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, notes.Commits)
+	require.Len(t, notes.Groups, 2)
+	assert.Equal(t, "feature", notes.Groups[0].Title)
+	require.Len(t, notes.Groups[0].PullRequests, 1)
+	assert.Equal(t, 4, notes.Groups[0].PullRequests[0].Number)
+	assert.Equal(t, OtherNotesGroup, notes.Groups[1].Title)
+	require.Len(t, notes.Groups[1].PullRequests, 2)
+	assert.Equal(t, 1, notes.Groups[1].PullRequests[0].Number)
+	assert.Equal(t, 2, notes.Groups[1].PullRequests[1].Number)
+	assert.Contains(t, notes.Body, "## feature\n\n- Change c by @octocat in #4\n")
+	assert.Contains(t, notes.Body, "**Full Changelog**: https://github.com/octocat/hello/compare/v1.0.0...v1.1.0")
+	mockClient.AssertExpectations(t)
+}
+
+// TestGroupReleaseNotesByLabel tests that every label forms a group when no groups are given.
+func TestGroupReleaseNotesByLabel(t *testing.T) {
+	merged := []ReleaseNote{
+		{Number: 1, Labels: []string{"feature"}},
+		{Number: 2, Labels: []string{"bug", "feature"}},
+		{Number: 3, Labels: []string{}},
+	}
+
+	groups := groupReleaseNotes(merged, nil)
+
+	require.Len(t, groups, 3)
+	assert.Equal(t, "bug", groups[0].Title)
+	assert.Equal(t, 2, groups[0].PullRequests[0].Number)
+	assert.Equal(t, "feature", groups[1].Title)
+	assert.Equal(t, 1, groups[1].PullRequests[0].Number)
+	assert.Equal(t, OtherNotesGroup, groups[2].Title)
+}
+
+// TestTagRequestCreate tests that a message creates an annotated tag object before the reference.
+func TestTagRequestCreate(t *testing.T) {
+	sha := "6dcb09b5b57875f334f61aebed695e2e4193db5e"
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateTag", mock.Anything, "octocat", "hello", mock.MatchedBy(func(tag *github.Tag) bool {
+		return tag.GetTag() == "v1.1.0" && tag.GetObject().GetSHA() == sha && tag.GetObject().GetType() == "commit"
+	})).Return(&github.Tag{SHA: github.String("tag-object")}, &github.Response{}, nil)
+	mockClient.On("CreateRef", mock.Anything, "octocat", "hello", mock.MatchedBy(func(ref *github.Reference) bool {
+		return ref.GetRef() == "refs/tags/v1.1.0" && ref.GetObject().GetSHA() == "tag-object"
+	})).Return(&github.Reference{Ref: github.String("refs/tags/v1.1.0")}, &github.Response{}, nil)
+
+	req := TagRequest{Tag: "v1.1.0", SHA: sha, Message: "Release 1.1.0"}
+	require.NoError(t, req.Validate())
+	ref, err := req.Create(context.Background(), mockClient, "octocat", "hello")
+
+	require.NoError(t, err)
+	assert.Equal(t, "refs/tags/v1.1.0", ref.GetRef())
+	mockClient.AssertExpectations(t)
+
+	assert.Error(t, TagRequest{Tag: "v1 .0", SHA: sha}.Validate())
+	assert.Error(t, TagRequest{Tag: "v1.0", SHA: "main"}.Validate())
+}
+
+// TestReleaseRequestNotesRange tests which range the notes of a new release are generated for.
+func TestReleaseRequestNotesRange(t *testing.T) {
+	req := ReleaseRequest{TagName: github.String("v1.1.0"), PreviousTagName: "v1.0.0"}
+	from, to, ok := req.NotesRange()
+	assert.True(t, ok)
+	assert.Equal(t, "v1.0.0", from)
+	assert.Equal(t, "v1.1.0", to)
+
+	req.TargetCommitish = github.String("main")
+	_, to, _ = req.NotesRange()
+	assert.Equal(t, "main", to)
+
+	req.Body = github.String("Hand-written notes")
+	_, _, ok = req.NotesRange()
+	assert.False(t, ok)
+
+	_, err := ReleaseRequest{MakeLatest: github.String("yes")}.ToRelease()
+	assert.Error(t, err)
+}
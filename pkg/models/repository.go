@@ -2,11 +2,14 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"github-api/pkg/interfaces"
+	"github-api/pkg/validation"
 	"github.com/gin-gonic/gin"
 	"github.com/go-git/go-git/v5"
 	"github.com/google/go-github/v50/github"
 	"os"
+	"strings"
 )
 
 // RepositoryModel represents a GitHub repository with its basic details.
@@ -15,24 +18,55 @@ type RepositoryModel struct {
 	*github.Repository
 }
 
+// repositoryFields are the validated fields of a RepositoryModel.
+type repositoryFields struct {
+	Name       *string `json:"name" binding:"required,reponame"`
+	Visibility *string `json:"visibility" binding:"omitempty,oneof=public private internal"`
+	URL        *string `json:"url" binding:"omitempty,url"`
+	HTMLURL    *string `json:"html_url" binding:"omitempty,url"`
+	Homepage   *string `json:"homepage" binding:"omitempty,url"`
+}
+
 // ConvertFromContext creates a new RepositoryModel instance by binding JSON data from the provided
-// gin.Context. It parses the incoming JSON payload into a RepositoryModel struct.
+// gin.Context. It parses the incoming JSON payload into a RepositoryModel struct and validates it.
 //
 // Parameters:
 //   - c: The gin.Context containing the JSON payload to be bound.
 //
 // Returns:
 //   - Repository: The populated Repository struct.
-//   - error: An error if the JSON binding fails, otherwise nil.
+//   - error: An error if the JSON binding or the validation fails, otherwise nil. validation.Fields
+//     lists the invalid fields of a validation error.
 func ConvertFromContext(c *gin.Context) (RepositoryModel, error) {
 	var repo RepositoryModel
 
 	if err := c.ShouldBindJSON(&repo); err != nil {
 		return RepositoryModel{}, err
 	}
+	if err := repo.Validate(); err != nil {
+		return RepositoryModel{}, err
+	}
 	return repo, nil
 }
 
+// Validate checks the name of the repository against the characters and length GitHub accepts,
+// its visibility and the format of its URLs.
+//
+// Returns:
+//   - error: The validation errors, which validation.Fields lists, or nil if the repository is valid.
+func (r RepositoryModel) Validate() error {
+	if r.Repository == nil {
+		return validation.Struct(repositoryFields{})
+	}
+	return validation.Struct(repositoryFields{
+		Name:       r.Name,
+		Visibility: r.Visibility,
+		URL:        r.URL,
+		HTMLURL:    r.HTMLURL,
+		Homepage:   r.Homepage,
+	})
+}
+
 // RepoExists checks if the repository exists on GitHub.
 //
 // Parameters:
@@ -71,27 +105,6 @@ func (r *RepositoryModel) CloneRepo() error {
 	return err
 }
 
-// CreateNew creates a new GitHub repository using the provided GitHub client.
-// It initializes a repository object with the current Repository structs
-// Name and Private fields, and then sends a request to create the repository
-// in the authenticated user's account.
-//
-// Parameters:
-//   - client: A GitHub client instance used to interact with the GitHub API.
-//
-// Returns:
-//   - An error if the repository creation fails, otherwise nil.
-func (r *RepositoryModel) CreateNew(client interfaces.GitHubClient) error {
-
-	repo := &github.Repository{
-		Name:    github.String(*r.Name),
-		Private: github.Bool(*r.Private),
-	}
-
-	_, _, err := client.CreateRepository(context.Background(), "", repo)
-	return err
-}
-
 // DeleteRepo deletes the repository associated with the Repository struct
 // using the provided GitHub client. It sends a request to the GitHub API
 // to delete the repository identified by its owner and name.
@@ -100,6 +113,9 @@ func (r *RepositoryModel) CreateNew(client interfaces.GitHubClient) error {
 //   - client: A pointer to a github.Client instance used to interact with
 //     the GitHub API.
 //
+// On success the model's Owner is set to the authenticated user the
+// repository was deleted from.
+//
 // Returns:
 //   - error: An error if the deletion fails, or nil if the operation is
 //     successful.
@@ -109,5 +125,59 @@ func (r *RepositoryModel) DeleteRepo(client interfaces.GitHubClient) error {
 		return err
 	}
 	_, err = client.DeleteRepository(context.Background(), *username.Login, *r.Name)
-	return err
+	if err != nil {
+		return err
+	}
+	r.Owner = username
+	return nil
+}
+
+// ListAllRepos lists every repository of an owner, following pagination.
+// An empty owner lists the repositories of the authenticated user.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner whose repositories will be listed.
+//   - opt: Options for filtering and sorting the repositories; the page fields are managed by the function.
+//
+// Returns:
+//   - []*github.Repository: All repositories matching the options.
+//   - error: An error if any page cannot be retrieved.
+func ListAllRepos(ctx context.Context, client interfaces.GitHubClient, owner string, opt *github.RepositoryListOptions) ([]*github.Repository, error) {
+	if opt == nil {
+		opt = &github.RepositoryListOptions{}
+	}
+	opt.PerPage = 100
+	opt.Page = 0
+
+	var all []*github.Repository
+	for {
+		repos, resp, err := client.ListRepos(ctx, owner, opt)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, repos...)
+		if resp == nil || resp.NextPage == 0 {
+			return all, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// SplitFullName splits a repository full name of the form "owner/name".
+//
+// Parameters:
+//   - fullName: The full name of the repository.
+//
+// Returns:
+//   - string: The owner of the repository.
+//   - string: The name of the repository.
+//   - error: An error if fullName is not of the form "owner/name".
+func SplitFullName(fullName string) (string, string, error) {
+	owner, name, ok := strings.Cut(strings.TrimSpace(fullName), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository %q: expected owner/name", fullName)
+	}
+	return owner, name, nil
 }
@@ -0,0 +1,270 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github-api/pkg/scaffold"
+	"github.com/google/go-github/v50/github"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// createRepositoryFields are the JSON fields accepted when creating a repository.
+var createRepositoryFields = jsonFields(reflect.TypeOf(CreateRepositoryRequest{}))
+
+// ErrPartiallyCreated is returned when a repository was created but a follow-up step failed.
+var ErrPartiallyCreated = errors.New("repository created but not fully set up")
+
+// TemplateSource is the template repository a new repository is generated from.
+type TemplateSource struct {
+	Repository         string `json:"repository" binding:"required,fullname"`
+	IncludeAllBranches bool   `json:"include_all_branches"`
+}
+
+// ScaffoldRequest selects the skeleton pushed as the initial commit of a new repository.
+// Variables are substituted in the templates of the skeleton; Message defaults to "Initial commit".
+type ScaffoldRequest struct {
+	Skeleton  string            `json:"skeleton" binding:"required"`
+	Variables map[string]string `json:"variables"`
+	Message   string            `json:"message"`
+}
+
+// CreateRepositoryRequest is the payload accepted when creating a repository.
+// Its fields are checked by their binding tags when it is bound, and their combinations by
+// Validate. Visibility, which also allows internal repositories, may replace Private. Unset
+// feature and merge settings take GitHub's defaults. Topics, the default branch and the
+// settings GitHub does not accept when creating or generating a repository are applied once it
+// exists. The initial content comes from at most one of auto_init, gitignore_template and
+// license_template, template, or scaffold. Fields not listed here, such as the read-only fields
+// of a repository, are rejected rather than silently ignored.
+type CreateRepositoryRequest struct {
+	Name                string           `json:"name" binding:"required,reponame"`
+	Description         string           `json:"description" binding:"max=350"`
+	Homepage            string           `json:"homepage" binding:"omitempty,http_url"`
+	Private             *bool            `json:"private"`
+	Visibility          string           `json:"visibility" binding:"omitempty,oneof=public private internal"`
+	HasIssues           *bool            `json:"has_issues"`
+	HasProjects         *bool            `json:"has_projects"`
+	HasWiki             *bool            `json:"has_wiki"`
+	HasDiscussions      *bool            `json:"has_discussions"`
+	IsTemplate          bool             `json:"is_template"`
+	Topics              []string         `json:"topics" binding:"max=20,unique,dive,topic"`
+	DefaultBranch       string           `json:"default_branch" binding:"omitempty,branch"`
+	AllowSquashMerge    *bool            `json:"allow_squash_merge"`
+	AllowMergeCommit    *bool            `json:"allow_merge_commit"`
+	AllowRebaseMerge    *bool            `json:"allow_rebase_merge"`
+	AllowAutoMerge      *bool            `json:"allow_auto_merge"`
+	AllowUpdateBranch   *bool            `json:"allow_update_branch"`
+	DeleteBranchOnMerge *bool            `json:"delete_branch_on_merge"`
+	AutoInit            bool             `json:"auto_init"`
+	GitignoreTemplate   string           `json:"gitignore_template"`
+	LicenseTemplate     string           `json:"license_template"`
+	Template            *TemplateSource  `json:"template"`
+	Scaffold            *ScaffoldRequest `json:"scaffold"`
+
+	unsupported []string
+}
+
+// UnmarshalJSON decodes the request and records the fields it does not support.
+func (r *CreateRepositoryRequest) UnmarshalJSON(data []byte) error {
+	type plain CreateRepositoryRequest
+	if err := json.Unmarshal(data, (*plain)(r)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	r.unsupported = nil
+	for field := range fields {
+		if !createRepositoryFields[field] {
+			r.unsupported = append(r.unsupported, field)
+		}
+	}
+	sort.Strings(r.unsupported)
+	return nil
+}
+
+// Validate checks the fields the binding tags cannot: the unsupported fields and the
+// combinations of fields.
+//
+// Returns:
+//   - error: An error describing the first problem, or nil if the request is valid.
+func (r CreateRepositoryRequest) Validate() error {
+	if len(r.unsupported) > 0 {
+		return fmt.Errorf("unsupported fields: %s", strings.Join(r.unsupported, ", "))
+	}
+	if r.Visibility != "" && r.Private != nil && *r.Private != (r.Visibility == "private") {
+		return fmt.Errorf("private is %t but visibility is %s", *r.Private, r.Visibility)
+	}
+	if r.AllowMergeCommit != nil && r.AllowSquashMerge != nil && r.AllowRebaseMerge != nil &&
+		!*r.AllowMergeCommit && !*r.AllowSquashMerge && !*r.AllowRebaseMerge {
+		return errors.New("at least one of allow_merge_commit, allow_squash_merge and allow_rebase_merge must be true")
+	}
+
+	autoInit := r.AutoInit || r.GitignoreTemplate != "" || r.LicenseTemplate != ""
+	if r.Template != nil && (autoInit || r.Scaffold != nil) {
+		return errors.New("a template cannot be combined with auto_init, gitignore_template, license_template or scaffold")
+	}
+	if r.Scaffold != nil && autoInit {
+		return errors.New("scaffold cannot be combined with auto_init, gitignore_template or license_template")
+	}
+	if r.DefaultBranch != "" && !autoInit && r.Template == nil && r.Scaffold == nil {
+		return errors.New("default_branch requires an initial commit from auto_init, template or scaffold")
+	}
+	return nil
+}
+
+// Create creates the repository in the authenticated user's account and applies the follow-up
+// steps: pushing the skeleton, renaming the initial branch to the default branch, applying the
+// settings GitHub does not accept on creation and setting the topics.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - s: The scaffolder holding the skeletons, used only if Scaffold is set.
+//   - token: The access token used to push the skeleton.
+//
+// Returns:
+//   - *github.Repository: The created repository, also returned when a follow-up step failed.
+//   - error: The GitHub error if the repository cannot be created, or an error wrapping
+//     ErrPartiallyCreated if a follow-up step failed.
+func (r CreateRepositoryRequest) Create(ctx context.Context, client interfaces.GitHubClient, s *scaffold.Scaffolder, token string) (*github.Repository, error) {
+	created, err := r.create(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	owner, name := created.GetOwner().GetLogin(), created.GetName()
+
+	if r.Scaffold != nil {
+		branch := r.DefaultBranch
+		if branch == "" {
+			branch = created.GetDefaultBranch()
+		}
+		if branch == "" {
+			branch = "main"
+		}
+		message := r.Scaffold.Message
+		if message == "" {
+			message = "Initial commit"
+		}
+		_, err := s.Push(ctx, scaffold.Options{
+			Skeleton:  r.Scaffold.Skeleton,
+			Owner:     owner,
+			Repo:      name,
+			Branch:    branch,
+			Token:     token,
+			Message:   message,
+			Variables: r.Scaffold.Variables,
+		})
+		if err != nil {
+			return created, fmt.Errorf("%w: pushing the skeleton: %w", ErrPartiallyCreated, err)
+		}
+		created.DefaultBranch = github.String(branch)
+	} else if r.DefaultBranch != "" && r.DefaultBranch != created.GetDefaultBranch() {
+		if _, _, err := client.RenameBranch(ctx, owner, name, created.GetDefaultBranch(), r.DefaultBranch); err != nil {
+			return created, fmt.Errorf("%w: renaming the default branch: %w", ErrPartiallyCreated, err)
+		}
+		created.DefaultBranch = github.String(r.DefaultBranch)
+	}
+
+	if r.Template != nil || r.AllowUpdateBranch != nil {
+		edited, _, err := client.EditRepository(ctx, owner, name, r.settings())
+		if err != nil {
+			return created, fmt.Errorf("%w: applying the settings: %w", ErrPartiallyCreated, err)
+		}
+		created = edited
+	}
+
+	if len(r.Topics) > 0 {
+		topics, _, err := client.ReplaceAllTopics(ctx, owner, name, r.Topics)
+		if err != nil {
+			return created, fmt.Errorf("%w: setting the topics: %w", ErrPartiallyCreated, err)
+		}
+		created.Topics = topics
+	}
+	return created, nil
+}
+
+// create creates the repository, from the template if one is set.
+func (r CreateRepositoryRequest) create(ctx context.Context, client interfaces.GitHubClient) (*github.Repository, error) {
+	if r.Template != nil {
+		owner, name, err := SplitFullName(r.Template.Repository)
+		if err != nil {
+			return nil, err
+		}
+		created, _, err := client.CreateFromTemplate(ctx, owner, name, &github.TemplateRepoRequest{
+			Name:               github.String(r.Name),
+			Description:        github.String(r.Description),
+			IncludeAllBranches: github.Bool(r.Template.IncludeAllBranches),
+			Private:            github.Bool(r.private()),
+		})
+		return created, err
+	}
+	repo := r.settings()
+	repo.Name = github.String(r.Name)
+	repo.Private = github.Bool(r.private())
+	repo.AllowUpdateBranch = nil
+	repo.AutoInit = github.Bool(r.AutoInit)
+	if r.GitignoreTemplate != "" {
+		repo.GitignoreTemplate = github.String(r.GitignoreTemplate)
+	}
+	if r.LicenseTemplate != "" {
+		repo.LicenseTemplate = github.String(r.LicenseTemplate)
+	}
+	created, _, err := client.CreateRepository(ctx, "", repo)
+	return created, err
+}
+
+// settings returns the settings of the repository, with GitHub's defaults for unset ones.
+func (r CreateRepositoryRequest) settings() *github.Repository {
+	withDefault := func(value *bool, def bool) *bool {
+		if value == nil {
+			return github.Bool(def)
+		}
+		return value
+	}
+	settings := &github.Repository{
+		Description:         github.String(r.Description),
+		Homepage:            github.String(r.Homepage),
+		HasIssues:           withDefault(r.HasIssues, true),
+		HasProjects:         withDefault(r.HasProjects, true),
+		HasWiki:             withDefault(r.HasWiki, true),
+		HasDiscussions:      withDefault(r.HasDiscussions, false),
+		IsTemplate:          github.Bool(r.IsTemplate),
+		AllowSquashMerge:    withDefault(r.AllowSquashMerge, true),
+		AllowMergeCommit:    withDefault(r.AllowMergeCommit, true),
+		AllowRebaseMerge:    withDefault(r.AllowRebaseMerge, true),
+		AllowAutoMerge:      withDefault(r.AllowAutoMerge, false),
+		AllowUpdateBranch:   withDefault(r.AllowUpdateBranch, false),
+		DeleteBranchOnMerge: withDefault(r.DeleteBranchOnMerge, false),
+	}
+	if r.Visibility != "" {
+		settings.Visibility = github.String(r.Visibility)
+	}
+	return settings
+}
+
+// private reports whether the repository is private, a missing Private meaning a public repository.
+func (r CreateRepositoryRequest) private() bool {
+	if r.Visibility != "" {
+		return r.Visibility == "private"
+	}
+	return r.Private != nil && *r.Private
+}
+
+// jsonFields returns the names of the JSON fields of a struct type.
+func jsonFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
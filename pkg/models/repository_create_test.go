@@ -0,0 +1,177 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"github-api/pkg/mocks"
+	"github-api/pkg/validation"
+	"testing"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// decodeCreateRequest decodes a repository creation request.
+func decodeCreateRequest(t *testing.T, body string) CreateRepositoryRequest {
+	var req CreateRepositoryRequest
+	require.NoError(t, json.Unmarshal([]byte(body), &req))
+	return req
+}
+
+// TestCreateRepositoryRequestFields tests the binding tags of the fields.
+func TestCreateRepositoryRequestFields(t *testing.T) {
+	valid := []string{
+		`{"name": "hello", "private": true, "topics": ["go", "github-api"], "homepage": "https://example.com"}`,
+		`{"name": "hello.go_v2", "visibility": "internal", "default_branch": "release/v1"}`,
+		`{"name": "hello", "template": {"repository": "octocat/template"}, "scaffold": {"skeleton": "service"}}`,
+	}
+	for _, body := range valid {
+		assert.NoError(t, validation.Struct(decodeCreateRequest(t, body)), body)
+	}
+
+	invalid := map[string][]validation.FieldError{
+		`{}`: {{Field: "name", Rule: "required", Message: "is required"}},
+		`{"name": "hello world", "visibility": "secret"}`: {
+			{Field: "name", Rule: "reponame", Message: "must be 1 to 100 letters, digits, '.', '-' or '_', and not '.' or '..'"},
+			{Field: "visibility", Rule: "oneof", Message: "must be one of public, private, internal"},
+		},
+		`{"name": "..", "homepage": "example.com"}`: {
+			{Field: "name", Rule: "reponame", Message: "must be 1 to 100 letters, digits, '.', '-' or '_', and not '.' or '..'"},
+			{Field: "homepage", Rule: "http_url", Message: "must be an http or https URL"},
+		},
+		`{"name": "hello", "topics": ["go", "Go"], "default_branch": "a..b"}`: {
+			{Field: "topics[1]", Rule: "topic", Message: "must be 1 to 50 lowercase letters, digits or '-', starting with a letter or digit"},
+			{Field: "default_branch", Rule: "branch", Message: "must be a valid branch name"},
+		},
+		`{"name": "hello", "topics": ["go", "go"]}`: {{Field: "topics", Rule: "unique", Message: "must not contain duplicates"}},
+		`{"name": "hello", "template": {"repository": "template"}, "scaffold": {}}`: {
+			{Field: "template.repository", Rule: "fullname", Message: "must be of the form owner/name"},
+			{Field: "scaffold.skeleton", Rule: "required", Message: "is required"},
+		},
+	}
+	for body, expected := range invalid {
+		fields, ok := validation.Fields(validation.Struct(decodeCreateRequest(t, body)))
+		assert.True(t, ok, body)
+		assert.Equal(t, expected, fields, body)
+	}
+}
+
+// TestCreateRepositoryRequestValidate tests the unsupported fields and the combinations of fields.
+func TestCreateRepositoryRequestValidate(t *testing.T) {
+	valid := []string{
+		`{"name": "hello", "auto_init": true, "license_template": "mit", "default_branch": "trunk"}`,
+		`{"name": "hello", "template": {"repository": "octocat/template"}}`,
+		`{"name": "hello", "scaffold": {"skeleton": "service"}, "default_branch": "main"}`,
+		`{"name": "hello", "allow_merge_commit": false, "allow_rebase_merge": false}`,
+		`{"name": "hello", "private": true, "visibility": "private"}`,
+	}
+	for _, body := range valid {
+		assert.NoError(t, decodeCreateRequest(t, body).Validate(), body)
+	}
+
+	invalid := map[string]string{
+		`{"name": "hello", "url": "https://github.com/octocat/hello", "owner": {"login": "octocat"}}`:              "unsupported fields: owner, url",
+		`{"name": "hello", "private": true, "visibility": "internal"}`:                                             "private is true but visibility is internal",
+		`{"name": "hello", "default_branch": "main"}`:                                                              "default_branch requires",
+		`{"name": "hello", "auto_init": true, "template": {"repository": "octocat/template"}}`:                     "a template cannot",
+		`{"name": "hello", "gitignore_template": "Go", "scaffold": {"skeleton": "service"}}`:                       "scaffold cannot",
+		`{"name": "hello", "allow_merge_commit": false, "allow_squash_merge": false, "allow_rebase_merge": false}`: "at least one",
+	}
+	for body, message := range invalid {
+		err := decodeCreateRequest(t, body).Validate()
+		if assert.Error(t, err, body) {
+			assert.Contains(t, err.Error(), message, body)
+		}
+	}
+}
+
+// TestCreateRepositoryRequestCreate tests that the settings are sent on creation with their defaults and the topics are set afterwards.
+func TestCreateRepositoryRequestCreate(t *testing.T) {
+	req := decodeCreateRequest(t, `{"name": "hello", "description": "Hi", "has_wiki": false,
+		"delete_branch_on_merge": true, "auto_init": true, "default_branch": "trunk", "topics": ["go"]}`)
+	require.NoError(t, req.Validate())
+
+	mockClient := new(mocks.MockGitHubClient)
+	owner := &github.User{Login: github.String("octocat")}
+	mockClient.On("CreateRepository", mock.Anything, "", &github.Repository{
+		Name:                github.String("hello"),
+		Description:         github.String("Hi"),
+		Homepage:            github.String(""),
+		Private:             github.Bool(false),
+		HasIssues:           github.Bool(true),
+		HasProjects:         github.Bool(true),
+		HasWiki:             github.Bool(false),
+		HasDiscussions:      github.Bool(false),
+		IsTemplate:          github.Bool(false),
+		AllowSquashMerge:    github.Bool(true),
+		AllowMergeCommit:    github.Bool(true),
+		AllowRebaseMerge:    github.Bool(true),
+		AllowAutoMerge:      github.Bool(false),
+		DeleteBranchOnMerge: github.Bool(true),
+		AutoInit:            github.Bool(true),
+	}).Return(&github.Repository{Name: github.String("hello"), Owner: owner, DefaultBranch: github.String("main")}, &github.Response{}, nil)
+	mockClient.On("RenameBranch", mock.Anything, "octocat", "hello", "main", "trunk").Return(&github.Branch{}, &github.Response{}, nil)
+	mockClient.On("ReplaceAllTopics", mock.Anything, "octocat", "hello", []string{"go"}).Return([]string{"go"}, &github.Response{}, nil)
+
+	created, err := req.Create(context.Background(), mockClient, nil, "token")
+	require.NoError(t, err)
+	assert.Equal(t, "trunk", created.GetDefaultBranch())
+	assert.Equal(t, []string{"go"}, created.Topics)
+	mockClient.AssertNotCalled(t, "EditRepository", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateRepositoryRequestTemplates tests that the gitignore and license templates are sent on creation.
+func TestCreateRepositoryRequestTemplates(t *testing.T) {
+	req := decodeCreateRequest(t, `{"name": "hello", "gitignore_template": "Go", "license_template": "mit"}`)
+	require.NoError(t, req.Validate())
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("CreateRepository", mock.Anything, "", mock.MatchedBy(func(repo *github.Repository) bool {
+		return repo.GetGitignoreTemplate() == "Go" && repo.GetLicenseTemplate() == "mit" && !repo.GetAutoInit() && !repo.GetPrivate()
+	})).Return(&github.Repository{Name: github.String("hello")}, &github.Response{}, nil)
+
+	_, err := req.Create(context.Background(), mockClient, nil, "token")
+	require.NoError(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+// TestCreateRepositoryRequestFromTemplate tests that the settings of a generated repository are applied afterwards.
+func TestCreateRepositoryRequestFromTemplate(t *testing.T) {
+	req := decodeCreateRequest(t, `{"name": "hello", "private": true, "allow_rebase_merge": false,
+		"template": {"repository": "octocat/template", "include_all_branches": true}}`)
+	require.NoError(t, req.Validate())
+
+	mockClient := new(mocks.MockGitHubClient)
+	owner := &github.User{Login: github.String("octocat")}
+	mockClient.On("CreateFromTemplate", mock.Anything, "octocat", "template", &github.TemplateRepoRequest{
+		Name:               github.String("hello"),
+		Description:        github.String(""),
+		IncludeAllBranches: github.Bool(true),
+		Private:            github.Bool(true),
+	}).Return(&github.Repository{Name: github.String("hello"), Owner: owner}, &github.Response{}, nil)
+	edited := &github.Repository{Name: github.String("hello"), Owner: owner, AllowRebaseMerge: github.Bool(false)}
+	mockClient.On("EditRepository", mock.Anything, "octocat", "hello", mock.MatchedBy(func(repo *github.Repository) bool {
+		return !repo.GetAllowRebaseMerge() && repo.GetAllowSquashMerge() && repo.GetHasIssues() && repo.Name == nil
+	})).Return(edited, &github.Response{}, nil)
+
+	created, err := req.Create(context.Background(), mockClient, nil, "token")
+	require.NoError(t, err)
+	assert.Equal(t, edited, created)
+	mockClient.AssertNotCalled(t, "CreateRepository", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// TestCreateRepositoryRequestPartiallyCreated tests that a failed follow-up step still returns the created repository.
+func TestCreateRepositoryRequestPartiallyCreated(t *testing.T) {
+	req := decodeCreateRequest(t, `{"name": "hello", "topics": ["go"]}`)
+	mockClient := new(mocks.MockGitHubClient)
+	repo := &github.Repository{Name: github.String("hello"), Owner: &github.User{Login: github.String("octocat")}}
+	mockClient.On("CreateRepository", mock.Anything, "", mock.Anything).Return(repo, &github.Response{}, nil)
+	mockClient.On("ReplaceAllTopics", mock.Anything, "octocat", "hello", []string{"go"}).Return(([]string)(nil), (*github.Response)(nil), assert.AnError)
+
+	created, err := req.Create(context.Background(), mockClient, nil, "token")
+	assert.ErrorIs(t, err, ErrPartiallyCreated)
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, repo, created)
+}
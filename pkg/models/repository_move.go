@@ -0,0 +1,120 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"strings"
+	"time"
+)
+
+// The transfer of a repository runs in the background on GitHub; the repository is polled at its
+// new location every moveInterval until it is reachable, for at most moveTimeout. A move still
+// pending then, such as a transfer awaiting the acceptance of the new owner, is followed by
+// AwaitMove every pendingMoveInterval for at most pendingMoveTimeout, the lifetime of a transfer
+// invitation.
+var (
+	moveInterval        = time.Second
+	moveTimeout         = 30 * time.Second
+	pendingMoveInterval = time.Minute
+	pendingMoveTimeout  = 24 * time.Hour
+)
+
+// ErrMovePending is returned when a renamed or transferred repository is not reachable at its new
+// location yet, typically because the transfer to another user awaits their acceptance.
+var ErrMovePending = errors.New("repository not reachable at its new location yet")
+
+// TransferRequest is the payload accepted when transferring a repository to another user or
+// organization. TeamIDs are the teams of the new organization given access to the repository.
+type TransferRequest struct {
+	NewOwner string  `json:"new_owner" binding:"required,login"`
+	TeamIDs  []int64 `json:"team_ids" binding:"dive,min=1"`
+}
+
+// RenameRequest is the payload accepted when renaming a repository.
+type RenameRequest struct {
+	Name string `json:"name" binding:"required,reponame"`
+}
+
+// TransferRepository transfers a repository to another user or organization and waits until it
+// is reachable under the new owner.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The current owner of the repository.
+//   - repo: The name of the repository.
+//   - req: The new owner and the teams given access.
+//
+// Returns:
+//   - *github.Repository: The repository at its new location.
+//   - error: An error wrapping ErrMovePending if the transfer is not complete in time, or the GitHub error.
+func TransferRepository(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req TransferRequest) (*github.Repository, error) {
+	_, _, err := client.TransferRepository(ctx, owner, repo, github.TransferRequest{NewOwner: req.NewOwner, TeamID: req.TeamIDs})
+	var accepted *github.AcceptedError
+	if err != nil && !errors.As(err, &accepted) {
+		return nil, err
+	}
+	return waitForRepository(ctx, client, req.NewOwner, repo, moveInterval, moveTimeout)
+}
+
+// RenameRepository renames a repository and waits until it is reachable under the new name.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The current name of the repository.
+//   - req: The new name of the repository.
+//
+// Returns:
+//   - *github.Repository: The repository at its new location.
+//   - error: An error wrapping ErrMovePending if the rename is not visible in time, or the GitHub error.
+func RenameRepository(ctx context.Context, client interfaces.GitHubClient, owner, repo string, req RenameRequest) (*github.Repository, error) {
+	if _, _, err := client.EditRepository(ctx, owner, repo, &github.Repository{Name: github.String(req.Name)}); err != nil {
+		return nil, err
+	}
+	return waitForRepository(ctx, client, owner, req.Name, moveInterval, moveTimeout)
+}
+
+// AwaitMove waits for a repository whose transfer or rename is pending until it is reachable at
+// its new location, polling it every minute for at most a day.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The new owner of the repository.
+//   - repo: The new name of the repository.
+//
+// Returns:
+//   - *github.Repository: The repository at its new location.
+//   - error: An error wrapping ErrMovePending if the move is still not complete, or the GitHub error.
+func AwaitMove(ctx context.Context, client interfaces.GitHubClient, owner, repo string) (*github.Repository, error) {
+	return waitForRepository(ctx, client, owner, repo, pendingMoveInterval, pendingMoveTimeout)
+}
+
+// waitForRepository polls a repository every interval, for at most timeout, until GetRepositories
+// finds it at exactly that location, rather than through the redirect GitHub keeps from its former
+// location.
+func waitForRepository(ctx context.Context, client interfaces.GitHubClient, owner, repo string, interval, timeout time.Duration) (*github.Repository, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		found, _, err := client.GetRepositories(ctx, owner, repo)
+		if err == nil && strings.EqualFold(found.GetOwner().GetLogin(), owner) && strings.EqualFold(found.GetName(), repo) {
+			return found, nil
+		}
+		if err != nil && !isNotFound(err) {
+			return nil, err
+		}
+		if time.Now().Add(interval).After(deadline) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrMovePending, owner, repo)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"context"
+	"github-api/pkg/mocks"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// repoAt returns a repository at the given location.
+func repoAt(owner, name string) *github.Repository {
+	return &github.Repository{Name: github.String(name), Owner: &github.User{Login: github.String(owner)}}
+}
+
+// notFound is the error GitHub answers for a repository that does not exist.
+var notFound = &github.ErrorResponse{Response: &http.Response{StatusCode: http.StatusNotFound}, Message: "Not Found"}
+
+// fastMoves shortens the polling of moved and pending repositories for the duration of a test.
+func fastMoves(t *testing.T, timeout time.Duration) {
+	interval, previous := moveInterval, moveTimeout
+	pendingInterval, pendingPrevious := pendingMoveInterval, pendingMoveTimeout
+	moveInterval, moveTimeout = time.Millisecond, timeout
+	pendingMoveInterval, pendingMoveTimeout = time.Millisecond, timeout
+	t.Cleanup(func() {
+		moveInterval, moveTimeout = interval, previous
+		pendingMoveInterval, pendingMoveTimeout = pendingInterval, pendingPrevious
+	})
+}
+
+// TestTransferRepositoryWaits tests that a transfer scheduled by GitHub is awaited at the new location.
+func TestTransferRepositoryWaits(t *testing.T) {
+	fastMoves(t, time.Second)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("TransferRepository", mock.Anything, "octocat", "hello", github.TransferRequest{NewOwner: "octo-org", TeamID: []int64{7}}).
+		Return((*github.Repository)(nil), &github.Response{}, &github.AcceptedError{}).Once()
+	mockClient.On("GetRepositories", mock.Anything, "octo-org", "hello").Return((*github.Repository)(nil), &github.Response{}, notFound).Once()
+	mockClient.On("GetRepositories", mock.Anything, "octo-org", "hello").Return(repoAt("octo-org", "hello"), &github.Response{}, nil).Once()
+
+	moved, err := TransferRepository(context.Background(), mockClient, "octocat", "hello", TransferRequest{NewOwner: "octo-org", TeamIDs: []int64{7}})
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org", moved.GetOwner().GetLogin())
+	mockClient.AssertExpectations(t)
+}
+
+// TestRenameRepositoryPending tests that a repository only reachable through the redirect of its
+// former location is reported as pending.
+func TestRenameRepositoryPending(t *testing.T) {
+	fastMoves(t, 20*time.Millisecond)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("EditRepository", mock.Anything, "octocat", "hello", &github.Repository{Name: github.String("world")}).
+		Return(repoAt("octocat", "world"), &github.Response{}, nil).Once()
+	mockClient.On("GetRepositories", mock.Anything, "octocat", "world").Return(repoAt("octocat", "hello"), &github.Response{}, nil)
+
+	_, err := RenameRepository(context.Background(), mockClient, "octocat", "hello", RenameRequest{Name: "world"})
+	assert.ErrorIs(t, err, ErrMovePending)
+}
+
+// TestAwaitMove tests that a pending transfer is followed until the repository is reachable at its new location.
+func TestAwaitMove(t *testing.T) {
+	fastMoves(t, time.Second)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetRepositories", mock.Anything, "octo-org", "hello").Return((*github.Repository)(nil), &github.Response{}, notFound).Times(3)
+	mockClient.On("GetRepositories", mock.Anything, "octo-org", "hello").Return(repoAt("octo-org", "hello"), &github.Response{}, nil).Once()
+
+	moved, err := AwaitMove(context.Background(), mockClient, "octo-org", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, "octo-org", moved.GetOwner().GetLogin())
+	mockClient.AssertExpectations(t)
+}
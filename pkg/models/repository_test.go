@@ -3,6 +3,7 @@ package models
 import (
 	"bytes"
 	"github-api/pkg/mocks"
+	"github-api/pkg/validation"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -30,6 +31,26 @@ func TestConvertFromContext(t *testing.T) {
 	assert.True(t, *repo.Private)
 }
 
+// TestConvertFromContextInvalid tests that an invalid repository model is rejected with its invalid fields.
+func TestConvertFromContextInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	body := `{"name": "test repo", "url": "not a url", "visibility": "secret"}`
+	c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+
+	repo, err := ConvertFromContext(c)
+	assert.Equal(t, RepositoryModel{}, repo)
+	fields, ok := validation.Fields(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"name", "visibility", "url"}, []string{fields[0].Field, fields[1].Field, fields[2].Field})
+
+	fields, ok = validation.Fields(RepositoryModel{}.Validate())
+	assert.True(t, ok)
+	assert.Equal(t, []validation.FieldError{{Field: "name", Rule: "required", Message: "is required"}}, fields)
+}
+
 // TestRepoExists tests the RepoExists method of the RepositoryModel struct.
 // It verifies that the method correctly checks if a repository exists using a mocked GitHub client.
 func TestRepoExists(t *testing.T) {
@@ -59,21 +80,6 @@ func TestCloneRepo(t *testing.T) {
 	assert.Error(t, err) // Expecting an error since the URL is not accessible in the test environment
 }
 
-// TestCreateNew tests the CreateNew method of the RepositoryModel struct.
-// It verifies that the method successfully creates a new repository using a mocked GitHub client.
-func TestCreateNew(t *testing.T) {
-	mockClient := new(mocks.MockGitHubClient)
-	repo := RepositoryModel{Repository: &github.Repository{Name: github.String("test-repo"), Private: github.Bool(true)}}
-
-	mockClient.On("CreateRepository", mock.Anything, "", mock.Anything).Return(
-		&github.Repository{},
-		&github.Response{},
-		nil)
-
-	err := repo.CreateNew(mockClient)
-	assert.NoError(t, err)
-}
-
 // TestDeleteRepo tests the DeleteRepo method of the RepositoryModel struct.
 // It verifies that the method successfully deletes a repository using a mocked GitHub client.
 func TestDeleteRepo(t *testing.T) {
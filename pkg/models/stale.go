@@ -0,0 +1,458 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"github-api/pkg/interfaces"
+	"github.com/google/go-github/v50/github"
+	"strings"
+	"time"
+)
+
+// staleConcurrency is the number of pull requests evaluated concurrently by the stale report.
+const staleConcurrency = 8
+
+// Reasons a pull request is flagged by the stale report.
+const (
+	StaleInactive      = "inactive"
+	StaleFailingChecks = "failing_checks"
+	StalePendingReview = "pending_review"
+)
+
+// Actions a stale policy can take on an inactive pull request.
+const (
+	StaleComment = "comment"
+	StaleLabel   = "label"
+	StaleUnlabel = "unlabel"
+	StaleClose   = "close"
+)
+
+// Outcomes of a stale action.
+const (
+	StaleActionPlanned = "planned"
+	StaleActionApplied = "applied"
+	StaleActionFailed  = "failed"
+)
+
+// labelUpdateGrace is the delay after the stale label is added within which updates of the
+// pull request are attributed to the labeling rather than to new activity.
+const labelUpdateGrace = time.Minute
+
+// Defaults of a stale policy.
+const (
+	DefaultStaleDays    = 14
+	DefaultStaleLabel   = "stale"
+	DefaultStaleComment = "This pull request has had no activity for %d days."
+	DefaultCloseComment = "Closing this pull request after %d days without activity. Feel free to reopen it."
+)
+
+// StalePolicy configures when a pull request is stale and what is done about it.
+// A pull request is inactive when it has not been updated for Days days. Actions selects
+// among comment, label and close: inactive pull requests are labeled and commented, and
+// pull requests already labeled are closed once inactive for CloseDays days. With the label
+// action, the label is also removed from pull requests with new activity.
+type StalePolicy struct {
+	Days          int      `json:"days"`
+	CloseDays     int      `json:"close_days"`
+	Label         string   `json:"label"`
+	Comment       string   `json:"comment"`
+	CloseComment  string   `json:"close_comment"`
+	ExemptLabels  []string `json:"exempt_labels"`
+	IncludeDrafts bool     `json:"include_drafts"`
+	Actions       []string `json:"actions"`
+}
+
+// Validate checks the policy and fills in its defaults.
+//
+// Returns:
+//   - error: An error describing the first invalid field.
+func (p *StalePolicy) Validate() error {
+	if p.Days < 0 || p.CloseDays < 0 {
+		return fmt.Errorf("invalid days: must not be negative")
+	}
+	if p.Days == 0 {
+		p.Days = DefaultStaleDays
+	}
+	if p.Label == "" {
+		p.Label = DefaultStaleLabel
+	}
+	if p.Comment == "" {
+		p.Comment = fmt.Sprintf(DefaultStaleComment, p.Days)
+	}
+	if p.CloseComment == "" {
+		p.CloseComment = fmt.Sprintf(DefaultCloseComment, p.Days+p.CloseDays)
+	}
+	for _, action := range p.Actions {
+		if err := oneOf("action", action, StaleComment, StaleLabel, StaleClose); err != nil {
+			return err
+		}
+	}
+	if p.has(StaleClose) && p.CloseDays == 0 {
+		return fmt.Errorf("invalid close_days: required by the close action")
+	}
+	if p.has(StaleClose) && !p.has(StaleLabel) {
+		return fmt.Errorf("invalid actions: close requires the label action to mark pull requests first")
+	}
+	return nil
+}
+
+// has reports whether the policy includes an action.
+func (p StalePolicy) has(action string) bool {
+	for _, a := range p.Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// StalePullRequest is an open pull request flagged by the stale report.
+type StalePullRequest struct {
+	Repository         string     `json:"repository"`
+	Number             int        `json:"number"`
+	Title              string     `json:"title"`
+	Author             string     `json:"author"`
+	URL                string     `json:"url"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+	IdleDays           int        `json:"idle_days"`
+	Reasons            []string   `json:"reasons"`
+	RequestedReviewers []string   `json:"requested_reviewers,omitempty"`
+	FailingChecks      []string   `json:"failing_checks,omitempty"`
+	Labeled            bool       `json:"labeled"`
+	LabeledAt          *time.Time `json:"labeled_at,omitempty"`
+}
+
+// inactive reports whether the pull request was flagged for inactivity.
+func (p StalePullRequest) inactive() bool {
+	for _, reason := range p.Reasons {
+		if reason == StaleInactive {
+			return true
+		}
+	}
+	return false
+}
+
+// StaleAction records an action taken, or planned in a dry run, on a pull request.
+type StaleAction struct {
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Action     string    `json:"action"`
+	Detail     string    `json:"detail,omitempty"`
+	Status     string    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	At         time.Time `json:"at"`
+}
+
+// StaleReport lists the flagged pull requests of a set of repositories and the actions taken on them.
+type StaleReport struct {
+	DryRun       bool               `json:"dry_run"`
+	Policy       StalePolicy        `json:"policy"`
+	PullRequests []StalePullRequest `json:"pull_requests"`
+	Actions      []StaleAction      `json:"actions"`
+	Errors       []RepositoryError  `json:"errors,omitempty"`
+}
+
+// StaleRequest is the payload accepted when applying a stale policy.
+type StaleRequest struct {
+	Repos  []string    `json:"repos"`
+	Policy StalePolicy `json:"policy"`
+	DryRun bool        `json:"dry_run"`
+}
+
+// FindStalePullRequests flags the open pull requests of each repository that have been inactive
+// for the policy days, have failing checks on their head commit or wait for requested reviews.
+// Drafts and pull requests carrying an exempt label are skipped unless the policy includes drafts.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - repos: The repositories to report on, as "owner/name".
+//   - policy: The validated stale policy.
+//   - now: The time against which inactivity is measured.
+//
+// Returns:
+//   - StaleReport: The flagged pull requests, with the repositories that could not be read listed in Errors.
+func FindStalePullRequests(ctx context.Context, client interfaces.GitHubClient, repos []string, policy StalePolicy, now time.Time) StaleReport {
+	report := StaleReport{DryRun: true, Policy: policy, PullRequests: []StalePullRequest{}, Actions: []StaleAction{}}
+	for _, fullName := range repos {
+		owner, name, err := SplitFullName(fullName)
+		if err == nil {
+			var stale []StalePullRequest
+			stale, err = repositoryStalePullRequests(ctx, client, owner, name, policy, now)
+			report.PullRequests = append(report.PullRequests, stale...)
+		}
+		if err != nil {
+			report.Errors = append(report.Errors, RepositoryError{Repository: fullName, Error: err.Error()})
+		}
+	}
+	return report
+}
+
+// Apply finds the stale pull requests of the requested repositories and plans the policy actions.
+// Unless the request is a dry run, the actions are then applied in order, and the outcome of each
+// is recorded in the report. The actions of a pull request stop at its first failure.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - now: The time against which inactivity is measured.
+//
+// Returns:
+//   - StaleReport: The flagged pull requests and the actions planned or taken.
+func (r StaleRequest) Apply(ctx context.Context, client interfaces.GitHubClient, now time.Time) StaleReport {
+	report := FindStalePullRequests(ctx, client, r.Repos, r.Policy, now)
+	report.DryRun = r.DryRun
+	for _, pull := range report.PullRequests {
+		for _, action := range PlanStaleActions(pull, r.Policy) {
+			action.At = now
+			action.Status = StaleActionPlanned
+			if !r.DryRun {
+				action.Status = StaleActionApplied
+				if err := applyStaleAction(ctx, client, action, r.Policy); err != nil {
+					action.Status = StaleActionFailed
+					action.Error = err.Error()
+					report.Actions = append(report.Actions, action)
+					break
+				}
+			}
+			report.Actions = append(report.Actions, action)
+		}
+	}
+	return report
+}
+
+// PlanStaleActions returns the actions the policy takes on a flagged pull request, in order.
+// An inactive pull request without the stale label is commented and labeled; one already labeled
+// and inactive for the policy close days is commented and closed. A labeled pull request that is
+// no longer inactive has its label removed.
+//
+// Parameters:
+//   - pull: The flagged pull request.
+//   - policy: The validated stale policy.
+//
+// Returns:
+//   - []StaleAction: The actions to take, without outcome.
+func PlanStaleActions(pull StalePullRequest, policy StalePolicy) []StaleAction {
+	action := func(name, detail string) StaleAction {
+		return StaleAction{Repository: pull.Repository, Number: pull.Number, Action: name, Detail: detail}
+	}
+	var actions []StaleAction
+	switch {
+	case !pull.inactive():
+		if pull.Labeled && policy.has(StaleLabel) {
+			actions = append(actions, action(StaleUnlabel, policy.Label))
+		}
+	case !pull.Labeled:
+		if policy.has(StaleComment) {
+			actions = append(actions, action(StaleComment, staleComment(pull, policy.Comment)))
+		}
+		if policy.has(StaleLabel) {
+			actions = append(actions, action(StaleLabel, policy.Label))
+		}
+	case policy.has(StaleClose) && pull.IdleDays >= policy.CloseDays:
+		if policy.has(StaleComment) {
+			actions = append(actions, action(StaleComment, policy.CloseComment))
+		}
+		actions = append(actions, action(StaleClose, ""))
+	}
+	return actions
+}
+
+// staleComment appends a mention of the pending reviewers to the stale comment.
+func staleComment(pull StalePullRequest, comment string) string {
+	if len(pull.RequestedReviewers) == 0 {
+		return comment
+	}
+	mentions := make([]string, len(pull.RequestedReviewers))
+	for i, reviewer := range pull.RequestedReviewers {
+		mentions[i] = "@" + reviewer
+	}
+	return comment + "\n\nWaiting for a review from " + strings.Join(mentions, ", ") + "."
+}
+
+// applyStaleAction takes a planned action on its pull request.
+func applyStaleAction(ctx context.Context, client interfaces.GitHubClient, action StaleAction, policy StalePolicy) error {
+	owner, repo, err := SplitFullName(action.Repository)
+	if err != nil {
+		return err
+	}
+	switch action.Action {
+	case StaleComment:
+		_, _, err = client.CreateIssueComment(ctx, owner, repo, action.Number, &github.IssueComment{Body: github.String(action.Detail)})
+	case StaleLabel:
+		_, _, err = client.AddLabelsToIssue(ctx, owner, repo, action.Number, []string{policy.Label})
+	case StaleUnlabel:
+		_, err = client.RemoveLabelForIssue(ctx, owner, repo, action.Number, policy.Label)
+	case StaleClose:
+		_, _, err = client.EditPullRequest(ctx, owner, repo, action.Number, &github.PullRequest{State: github.String("closed")})
+	default:
+		err = fmt.Errorf("unknown action %q", action.Action)
+	}
+	return err
+}
+
+// repositoryStalePullRequests flags the open pull requests of a repository.
+func repositoryStalePullRequests(ctx context.Context, client interfaces.GitHubClient, owner, repo string, policy StalePolicy, now time.Time) ([]StalePullRequest, error) {
+	var candidates []*github.PullRequest
+	opt := &github.PullRequestListOptions{State: "open", Sort: "updated", Direction: "asc", ListOptions: github.ListOptions{PerPage: 100}}
+	for {
+		pulls, resp, err := client.ListPullRequests(ctx, owner, repo, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, pull := range pulls {
+			if (pull.GetDraft() && !policy.IncludeDrafts) || hasLabel(pull.Labels, policy.ExemptLabels...) {
+				continue
+			}
+			candidates = append(candidates, pull)
+		}
+		if resp == nil || resp.NextPage == 0 {
+			break
+		}
+		opt.Page = resp.NextPage
+	}
+
+	flagged := make([]*StalePullRequest, len(candidates))
+	err := forEachLimit(len(candidates), staleConcurrency, func(i int) (err error) {
+		flagged[i], err = stalePullRequest(ctx, client, owner, repo, candidates[i], policy, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	stale := []StalePullRequest{}
+	for _, pull := range flagged {
+		if pull != nil {
+			stale = append(stale, *pull)
+		}
+	}
+	return stale, nil
+}
+
+// stalePullRequest checks an open pull request, returning nil if it is not flagged.
+// A pull request carrying the stale label remains inactive until it is updated after the label
+// was added, and is always returned so the label can be removed once it has new activity.
+func stalePullRequest(ctx context.Context, client interfaces.GitHubClient, owner, repo string, pull *github.PullRequest, policy StalePolicy, now time.Time) (*StalePullRequest, error) {
+	updated := pull.GetUpdatedAt().Time
+	stale := &StalePullRequest{
+		Repository: owner + "/" + repo,
+		Number:     pull.GetNumber(),
+		Title:      pull.GetTitle(),
+		Author:     pull.GetUser().GetLogin(),
+		URL:        pull.GetHTMLURL(),
+		UpdatedAt:  updated,
+		IdleDays:   int(now.Sub(updated).Hours() / 24),
+		Reasons:    []string{},
+		Labeled:    hasLabel(pull.Labels, policy.Label),
+	}
+	inactive := stale.IdleDays >= policy.Days
+	if stale.Labeled {
+		labeledAt, err := LabeledAt(ctx, client, owner, repo, stale.Number, policy.Label)
+		if err != nil {
+			return nil, err
+		}
+		// Labeling updates the pull request, so it is only active if updated after the label.
+		stale.LabeledAt = labeledAt
+		if labeledAt != nil {
+			inactive = updated.Sub(*labeledAt) <= labelUpdateGrace
+		}
+	}
+	if inactive {
+		stale.Reasons = append(stale.Reasons, StaleInactive)
+	}
+
+	failing, err := failingChecks(ctx, client, owner, repo, pull.GetHead().GetSHA())
+	if err != nil {
+		return nil, err
+	}
+	if len(failing) > 0 {
+		stale.FailingChecks = failing
+		stale.Reasons = append(stale.Reasons, StaleFailingChecks)
+	}
+
+	for _, user := range pull.RequestedReviewers {
+		stale.RequestedReviewers = append(stale.RequestedReviewers, user.GetLogin())
+	}
+	for _, team := range pull.RequestedTeams {
+		stale.RequestedReviewers = append(stale.RequestedReviewers, owner+"/"+team.GetSlug())
+	}
+	if len(stale.RequestedReviewers) > 0 {
+		stale.Reasons = append(stale.Reasons, StalePendingReview)
+	}
+
+	if len(stale.Reasons) == 0 && !stale.Labeled {
+		return nil, nil
+	}
+	return stale, nil
+}
+
+// LabeledAt returns the time a label was last added to an issue or pull request,
+// or nil if its events do not record it.
+//
+// Parameters:
+//   - ctx: The context for the requests.
+//   - client: A GitHub client instance used to interact with the GitHub API.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - number: The number of the issue or pull request.
+//   - label: The name of the label, compared ignoring case.
+//
+// Returns:
+//   - *time.Time: The time the label was last added, or nil.
+//   - error: An error if the events cannot be retrieved.
+func LabeledAt(ctx context.Context, client interfaces.GitHubClient, owner, repo string, number int, label string) (*time.Time, error) {
+	issueEvents, err := allPages(func(opt *github.ListOptions) ([]*github.IssueEvent, *github.Response, error) {
+		return client.ListIssueEvents(ctx, owner, repo, number, opt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var at *time.Time
+	for _, e := range issueEvents {
+		if e.GetEvent() == "labeled" && strings.EqualFold(e.GetLabel().GetName(), label) {
+			created := e.GetCreatedAt().Time
+			if at == nil || created.After(*at) {
+				at = &created
+			}
+		}
+	}
+	return at, nil
+}
+
+// failingChecks returns the names of the failed commit statuses and check runs of a commit.
+func failingChecks(ctx context.Context, client interfaces.GitHubClient, owner, repo, sha string) ([]string, error) {
+	var failing []string
+	status, err := combinedStatus(ctx, client, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range status.Statuses {
+		if s.GetState() == "failure" || s.GetState() == "error" {
+			failing = append(failing, s.GetContext())
+		}
+	}
+
+	runs, err := checkRuns(ctx, client, owner, repo, sha)
+	if err != nil {
+		return nil, err
+	}
+	for _, run := range runs {
+		switch run.GetConclusion() {
+		case "failure", "timed_out", "cancelled", "action_required":
+			failing = append(failing, run.GetName())
+		}
+	}
+	return failing, nil
+}
+
+// hasLabel reports whether one of the labels matches one of the names, ignoring case.
+func hasLabel(labels []*github.Label, names ...string) bool {
+	for _, label := range labels {
+		for _, name := range names {
+			if strings.EqualFold(label.GetName(), name) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,180 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"github-api/pkg/mocks"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v50/github"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stalePolicy returns a validated policy labeling after 14 days and closing 7 days later.
+func stalePolicy(t *testing.T) StalePolicy {
+	policy := StalePolicy{CloseDays: 7, ExemptLabels: []string{"pinned"}, Actions: []string{StaleComment, StaleLabel, StaleClose}}
+	require.NoError(t, policy.Validate())
+	return policy
+}
+
+// TestStalePolicyValidate tests the defaults and the consistency checks of a policy.
+func TestStalePolicyValidate(t *testing.T) {
+	policy := stalePolicy(t)
+	assert.Equal(t, DefaultStaleDays, policy.Days)
+	assert.Equal(t, DefaultStaleLabel, policy.Label)
+	assert.Contains(t, policy.CloseComment, "21 days")
+
+	assert.Error(t, (&StalePolicy{Actions: []string{StaleClose, StaleLabel}}).Validate())
+	assert.Error(t, (&StalePolicy{CloseDays: 7, Actions: []string{StaleClose}}).Validate())
+	assert.Error(t, (&StalePolicy{Actions: []string{"lock"}}).Validate())
+}
+
+// TestPlanStaleActions tests the actions planned for each state of a pull request.
+func TestPlanStaleActions(t *testing.T) {
+	policy := stalePolicy(t)
+	pull := StalePullRequest{Repository: "octocat/api", Number: 1, Reasons: []string{StaleInactive}, IdleDays: 20, RequestedReviewers: []string{"bob"}}
+
+	actions := PlanStaleActions(pull, policy)
+	require.Len(t, actions, 2)
+	assert.Equal(t, StaleComment, actions[0].Action)
+	assert.Contains(t, actions[0].Detail, "@bob")
+	assert.Equal(t, StaleLabel, actions[1].Action)
+
+	pull.Labeled = true
+	pull.IdleDays = 3
+	assert.Empty(t, PlanStaleActions(pull, policy))
+
+	pull.IdleDays = 7
+	actions = PlanStaleActions(pull, policy)
+	require.Len(t, actions, 2)
+	assert.Equal(t, policy.CloseComment, actions[0].Detail)
+	assert.Equal(t, StaleClose, actions[1].Action)
+
+	pull.Reasons = []string{StalePendingReview}
+	actions = PlanStaleActions(pull, policy)
+	require.Len(t, actions, 1)
+	assert.Equal(t, StaleUnlabel, actions[0].Action)
+}
+
+// TestStaleRequestApply tests flagging, dry runs and the audit of applied and failed actions.
+func TestStaleRequestApply(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) *github.Timestamp { return &github.Timestamp{Time: now.AddDate(0, 0, -days)} }
+	head := &github.PullRequestBranch{SHA: github.String(headSHA)}
+
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListPullRequests", mock.Anything, "octocat", "api", mock.Anything).Return(
+		[]*github.PullRequest{
+			{Number: github.Int(1), UpdatedAt: daysAgo(30), Head: head},
+			{Number: github.Int(2), UpdatedAt: daysAgo(30), Head: head, Labels: []*github.Label{{Name: github.String("Pinned")}}},
+			{Number: github.Int(3), UpdatedAt: daysAgo(30), Head: head, Draft: github.Bool(true)},
+			{Number: github.Int(4), UpdatedAt: daysAgo(1), Head: head, RequestedReviewers: []*github.User{{Login: github.String("bob")}}},
+			{Number: github.Int(5), UpdatedAt: daysAgo(1), Head: head},
+		},
+		&github.Response{},
+		nil)
+	mockClient.On("GetCombinedStatus", mock.Anything, "octocat", "api", headSHA, mock.Anything).Return(
+		&github.CombinedStatus{State: github.String("success")},
+		&github.Response{},
+		nil)
+	mockClient.On("ListCheckRunsForRef", mock.Anything, "octocat", "api", headSHA, mock.Anything).Return(
+		&github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{{Name: github.String("lint"), Conclusion: github.String("success")}}},
+		&github.Response{},
+		nil)
+
+	req := StaleRequest{Repos: []string{"octocat/api"}, Policy: stalePolicy(t), DryRun: true}
+	report := req.Apply(context.Background(), mockClient, now)
+
+	require.Empty(t, report.Errors)
+	require.Len(t, report.PullRequests, 2)
+	assert.Equal(t, []string{StaleInactive}, report.PullRequests[0].Reasons)
+	assert.Equal(t, 30, report.PullRequests[0].IdleDays)
+	assert.Equal(t, []string{StalePendingReview}, report.PullRequests[1].Reasons)
+	require.Len(t, report.Actions, 2)
+	assert.Equal(t, StaleActionPlanned, report.Actions[0].Status)
+	mockClient.AssertNotCalled(t, "CreateIssueComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	mockClient.On("CreateIssueComment", mock.Anything, "octocat", "api", 1, mock.Anything).Return(
+		&github.IssueComment{},
+		&github.Response{},
+		nil)
+	mockClient.On("AddLabelsToIssue", mock.Anything, "octocat", "api", 1, []string{"stale"}).Return(
+		([]*github.Label)(nil),
+		&github.Response{},
+		errors.New("forbidden"))
+
+	req.DryRun = false
+	report = req.Apply(context.Background(), mockClient, now)
+
+	require.Len(t, report.Actions, 2)
+	assert.Equal(t, StaleActionApplied, report.Actions[0].Status)
+	assert.Equal(t, StaleActionFailed, report.Actions[1].Status)
+	assert.Equal(t, "forbidden", report.Actions[1].Error)
+}
+
+// TestStalePullRequestLabeled tests that a labeled pull request stays inactive until updated after the label.
+func TestStalePullRequestLabeled(t *testing.T) {
+	now := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+	labeled := now.AddDate(0, 0, -8)
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("ListIssueEvents", mock.Anything, "octocat", "api", 1, mock.Anything).Return(
+		[]*github.IssueEvent{
+			{Event: github.String("labeled"), Label: &github.Label{Name: github.String("stale")}, CreatedAt: &github.Timestamp{Time: labeled}},
+			{Event: github.String("labeled"), Label: &github.Label{Name: github.String("bug")}, CreatedAt: &github.Timestamp{Time: now}},
+		},
+		&github.Response{},
+		nil)
+	mockClient.On("GetCombinedStatus", mock.Anything, "octocat", "api", mock.Anything, mock.Anything).Return(
+		&github.CombinedStatus{},
+		&github.Response{},
+		nil)
+	mockClient.On("ListCheckRunsForRef", mock.Anything, "octocat", "api", mock.Anything, mock.Anything).Return(
+		&github.ListCheckRunsResults{},
+		&github.Response{},
+		nil)
+	policy := stalePolicy(t)
+	pull := &github.PullRequest{
+		Number:    github.Int(1),
+		UpdatedAt: &github.Timestamp{Time: labeled.Add(2 * time.Second)},
+		Labels:    []*github.Label{{Name: github.String("stale")}},
+	}
+
+	stale, err := stalePullRequest(context.Background(), mockClient, "octocat", "api", pull, policy, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{StaleInactive}, stale.Reasons)
+	assert.Equal(t, labeled, *stale.LabeledAt)
+	assert.Equal(t, StaleClose, PlanStaleActions(*stale, policy)[1].Action)
+
+	pull.UpdatedAt = &github.Timestamp{Time: now.AddDate(0, 0, -1)}
+	stale, err = stalePullRequest(context.Background(), mockClient, "octocat", "api", pull, policy, now)
+	require.NoError(t, err)
+	assert.Empty(t, stale.Reasons)
+	assert.Equal(t, StaleUnlabel, PlanStaleActions(*stale, policy)[0].Action)
+}
+
+// TestFailingChecks tests that failed statuses and check runs are both reported.
+func TestFailingChecks(t *testing.T) {
+	mockClient := new(mocks.MockGitHubClient)
+	mockClient.On("GetCombinedStatus", mock.Anything, "octocat", "api", headSHA, mock.Anything).Return(
+		&github.CombinedStatus{Statuses: []*github.RepoStatus{
+			{Context: github.String("ci/jenkins"), State: github.String("error")},
+			{Context: github.String("coverage"), State: github.String("success")},
+		}},
+		&github.Response{},
+		nil)
+	mockClient.On("ListCheckRunsForRef", mock.Anything, "octocat", "api", headSHA, mock.Anything).Return(
+		&github.ListCheckRunsResults{CheckRuns: []*github.CheckRun{
+			{Name: github.String("build"), Conclusion: github.String("timed_out")},
+			{Name: github.String("lint"), Conclusion: github.String("neutral")},
+		}},
+		&github.Response{},
+		nil)
+
+	failing, err := failingChecks(context.Background(), mockClient, "octocat", "api", headSHA)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ci/jenkins", "build"}, failing)
+}
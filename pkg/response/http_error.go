@@ -1,6 +1,7 @@
 package response
 
 import (
+	"github-api/pkg/validation"
 	"github.com/gin-gonic/gin"
 	"net/http"
 )
@@ -23,6 +24,18 @@ func StatusBadRequestMissingParams(c *gin.Context, missingParams []string) {
 	})
 }
 
+// StatusBadRequestFields sends a 400 Bad Request response listing the invalid fields
+// of the payload and why each of them is invalid.
+// Parameters:
+// - c: The Gin context.
+// - fields: The field errors of the payload.
+func StatusBadRequestFields(c *gin.Context, fields []validation.FieldError) {
+	c.JSON(http.StatusBadRequest, gin.H{
+		"error":  "Invalid request payload",
+		"fields": fields,
+	})
+}
+
 // StatusUnauthorized sends a 401 Unauthorized response with a generic error message.
 // This is used when the access token is invalid or missing.
 func StatusUnauthorized(c *gin.Context) {
@@ -37,6 +50,17 @@ func StatusInternalServerError(c *gin.Context, err error) {
 	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error()})
 }
 
+// StatusInternalServerErrorData sends a 500 Internal Server Error response with a detailed error
+// message and the resource the request saved before failing, so that the client can resume the
+// request rather than repeat it.
+// Parameters:
+// - c: The Gin context.
+// - err: The error that occurred.
+// - data: The resource saved before the error.
+func StatusInternalServerErrorData(c *gin.Context, err error, data interface{}) {
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "Internal server error: " + err.Error(), "data": data})
+}
+
 // StatusNotFound sends a 404 Not Found response with a generic error message.
 // This is used when the requested resource cannot be found.
 func StatusNotFound(c *gin.Context) {
@@ -62,3 +86,23 @@ func StatusUnprocessableEntity(c *gin.Context, err error) {
 func StatusConflict(c *gin.Context) {
 	c.JSON(http.StatusConflict, gin.H{"error": "Conflict, repository already exists"})
 }
+
+// StatusConflictError sends a 409 Conflict response with a detailed error message.
+// This is used when the resource changed since the client last read it, such as
+// a pull request head that moved or a merge conflict.
+// Parameters:
+// - c: The Gin context.
+// - err: The error that occurred.
+func StatusConflictError(c *gin.Context, err error) {
+	c.JSON(http.StatusConflict, gin.H{"error": "Conflict: " + err.Error()})
+}
+
+// StatusMethodNotAllowed sends a 405 Method Not Allowed response with a detailed error message.
+// This is used when GitHub refuses an operation in the current state of the resource,
+// such as merging a pull request that is not mergeable.
+// Parameters:
+// - c: The Gin context.
+// - err: The error that occurred.
+func StatusMethodNotAllowed(c *gin.Context, err error) {
+	c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "Method not allowed: " + err.Error()})
+}
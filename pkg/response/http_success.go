@@ -1,7 +1,9 @@
 package response
 
 import (
+	"encoding/csv"
 	"github.com/gin-gonic/gin"
+	"github.com/google/go-github/v50/github"
 	"net/http"
 )
 
@@ -33,3 +35,59 @@ func StatusCreated(c *gin.Context, data interface{}) {
 func StatusOK(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusOK, gin.H{"data": data})
 }
+
+// StatusAccepted sends a HTTP 202 Accepted response with the provided data.
+// This is used when GitHub accepted a request it has not finished processing, such as a
+// repository transfer waiting for the new owner to accept it.
+//
+// Parameters:
+//   - c: The Gin context for the current HTTP request.
+//   - data: The data to include in the response body.
+func StatusAccepted(c *gin.Context, data interface{}) {
+	c.JSON(http.StatusAccepted, gin.H{"data": data})
+}
+
+// StatusOKPage sends a HTTP 200 OK response with a page of results and the pagination
+// links returned by the GitHub API.
+// This is used by list endpoints that accept the page and per_page query parameters.
+//
+// Parameters:
+//   - c: The Gin context for the current HTTP request.
+//   - data: The page of results to include in the response body.
+//   - resp: The GitHub API response the page was read from, or nil.
+func StatusOKPage(c *gin.Context, data interface{}, resp *github.Response) {
+	body := gin.H{"data": data}
+	if resp != nil {
+		body["pagination"] = gin.H{
+			"next_page":  resp.NextPage,
+			"prev_page":  resp.PrevPage,
+			"first_page": resp.FirstPage,
+			"last_page":  resp.LastPage,
+		}
+	}
+	c.JSON(http.StatusOK, body)
+}
+
+// StatusOKCSV sends a HTTP 200 OK response with the provided records encoded as CSV.
+// The response is sent as an attachment so browsers download it under the given file name.
+//
+// Parameters:
+//   - c: The Gin context for the current HTTP request.
+//   - filename: The name of the downloaded file.
+//   - records: The CSV records, starting with the header.
+func StatusOKCSV(c *gin.Context, filename string, records [][]string) {
+	c.Header("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Status(http.StatusOK)
+	w := csv.NewWriter(c.Writer)
+	_ = w.WriteAll(records)
+}
+
+// StatusOKMarkdown sends a HTTP 200 OK response with a Markdown document.
+//
+// Parameters:
+//   - c: The Gin context for the current HTTP request.
+//   - markdown: The Markdown document.
+func StatusOKMarkdown(c *gin.Context, markdown string) {
+	c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(markdown))
+}
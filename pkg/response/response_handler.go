@@ -14,6 +14,8 @@ import (
 // - 401 Unauthorized: invalid token or authentication failed.
 // - 403 Forbidden: user does not have permission to access the repository.
 // - 404 Not Found: repository does not exist for a given owner and repo name.
+// - 405 Method Not Allowed: the operation is not allowed in the current state, e.g. an unmergeable pull request.
+// - 409 Conflict: the resource changed or conflicts, e.g. a moved pull request head.
 // - 422 Unprocessable Entity: if the request is invalid.
 // - 500 Internal Server Error: if an error occurs while retrieving the pull requests.
 //
@@ -37,9 +39,12 @@ func HandleGithubErrors(c *gin.Context, err error) {
 			// 404 Not Found: repository does not exist for a given owner and repo name
 			case 404:
 				StatusNotFound(c)
-			// 409 Conflict: if the repository already exists
+			// 405 Method Not Allowed: if the operation is not allowed in the current state
+			case 405:
+				StatusMethodNotAllowed(c, err)
+			// 409 Conflict: if the resource conflicts with its current state
 			case 409:
-				StatusConflict(c)
+				StatusConflictError(c, err)
 			// 422 Unprocessable Entity: if the request is invalid
 			case 422:
 				StatusUnprocessableEntity(c, err)
@@ -49,6 +54,7 @@ func HandleGithubErrors(c *gin.Context, err error) {
 			default:
 				StatusInternalServerError(c, err)
 			}
+			return
 		}
 		StatusInternalServerError(c, err)
 	}
@@ -0,0 +1,251 @@
+// Package scaffold pushes skeleton directories as the initial commit of new repositories.
+// A skeleton is a subdirectory of the scaffold directory. Its files are copied as is, except
+// files ending in .tmpl, which are rendered with text/template and lose the suffix, so that
+// files using the {{ }} syntax themselves, such as GitHub Actions workflows, need no escaping.
+package scaffold
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github-api/pkg/workspace"
+	"github.com/go-git/go-billy/v5"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// templateSuffix marks the files of a skeleton rendered with the variables.
+const templateSuffix = ".tmpl"
+
+// ErrUnknownSkeleton is returned when a skeleton does not exist in the scaffold directory.
+var ErrUnknownSkeleton = errors.New("unknown skeleton")
+
+// Scaffolder pushes the skeletons found under Dir to repositories cloned from BaseURL.
+type Scaffolder struct {
+	Dir     string
+	BaseURL string
+}
+
+// Options describes the initial commit pushed to a repository.
+// Variables are available to the templates alongside Owner and Repo, which take precedence.
+type Options struct {
+	Skeleton  string
+	Owner     string
+	Repo      string
+	Branch    string
+	Token     string
+	Message   string
+	Variables map[string]string
+	Author    *object.Signature
+}
+
+// New creates a scaffolder reading skeletons from a directory and pushing to GitHub.
+//
+// Parameters:
+//   - dir: The directory holding one subdirectory per skeleton.
+//
+// Returns:
+//   - *Scaffolder: The scaffolder.
+func New(dir string) *Scaffolder {
+	return &Scaffolder{Dir: dir, BaseURL: workspace.DefaultBaseURL}
+}
+
+// FromEnv creates the scaffolder configured by the SCAFFOLD_DIR environment variable,
+// pushing to SCAFFOLD_BASE_URL when set, e.g. for GitHub Enterprise.
+//
+// Returns:
+//   - *Scaffolder: The scaffolder, or nil if SCAFFOLD_DIR is unset.
+func FromEnv() *Scaffolder {
+	dir := os.Getenv("SCAFFOLD_DIR")
+	if dir == "" {
+		return nil
+	}
+	s := New(dir)
+	if baseURL := os.Getenv("SCAFFOLD_BASE_URL"); baseURL != "" {
+		s.BaseURL = strings.TrimSuffix(baseURL, "/") + "/"
+	}
+	return s
+}
+
+var (
+	defaultMu         sync.RWMutex
+	defaultScaffolder *Scaffolder
+)
+
+// Default returns the scaffolder used by the API, or nil if none is configured.
+func Default() *Scaffolder {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultScaffolder
+}
+
+// SetDefault replaces the scaffolder used by the API.
+func SetDefault(s *Scaffolder) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultScaffolder = s
+}
+
+// Check verifies that a skeleton exists and renders with the variables, so that a repository
+// is not created when its initial commit cannot be.
+//
+// Parameters:
+//   - name: The name of the skeleton.
+//   - variables: The variables of the templates, besides Owner and Repo.
+//
+// Returns:
+//   - error: An error wrapping ErrUnknownSkeleton if the skeleton does not exist, or a rendering error.
+func (s *Scaffolder) Check(name string, variables map[string]string) error {
+	dir, err := s.path(name)
+	if err != nil {
+		return err
+	}
+	return render(dir, memfs.New(), templateData(variables, "", ""))
+}
+
+// Push commits a skeleton, rendered with the variables, and pushes it to a branch of an empty repository.
+//
+// Parameters:
+//   - ctx: The context bounding the push.
+//   - opts: The skeleton, repository, branch, credentials and commit details.
+//
+// Returns:
+//   - plumbing.Hash: The hash of the pushed commit.
+//   - error: An error if the skeleton cannot be rendered or the push fails.
+func (s *Scaffolder) Push(ctx context.Context, opts Options) (plumbing.Hash, error) {
+	dir, err := s.path(opts.Skeleton)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	worktree := memfs.New()
+	if err := render(dir, worktree, templateData(opts.Variables, opts.Owner, opts.Repo)); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	branch := plumbing.NewBranchReferenceName(opts.Branch)
+	r, err := git.InitWithOptions(memory.NewStorage(), worktree, git.InitOptions{DefaultBranch: branch})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tree, err := r.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if err := tree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	author := opts.Author
+	if author == nil {
+		author = &object.Signature{Name: opts.Owner, Email: opts.Owner + "@users.noreply.github.com"}
+	}
+	if author.When.IsZero() {
+		author.When = time.Now()
+	}
+	hash, err := tree.Commit(opts.Message, &git.CommitOptions{Author: author})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	remote, err := r.CreateRemote(&config.RemoteConfig{
+		Name: "origin",
+		URLs: []string{s.BaseURL + opts.Owner + "/" + opts.Repo + ".git"},
+	})
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	push := &git.PushOptions{RefSpecs: []config.RefSpec{config.RefSpec(branch + ":" + branch)}}
+	if opts.Token != "" {
+		push.Auth = &http.BasicAuth{Username: "x-access-token", Password: opts.Token}
+	}
+	if err := remote.PushContext(ctx, push); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("pushing to %s/%s: %w", opts.Owner, opts.Repo, err)
+	}
+	return hash, nil
+}
+
+// path returns the directory of a skeleton.
+func (s *Scaffolder) path(name string) (string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("%w %q", ErrUnknownSkeleton, name)
+	}
+	dir := filepath.Join(s.Dir, name)
+	info, err := os.Stat(dir)
+	if err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%w %q", ErrUnknownSkeleton, name)
+	}
+	return dir, nil
+}
+
+// templateData returns the data the templates are rendered with.
+func templateData(variables map[string]string, owner, repo string) map[string]string {
+	data := make(map[string]string, len(variables)+2)
+	for key, value := range variables {
+		data[key] = value
+	}
+	data["Owner"], data["Repo"] = owner, repo
+	return data
+}
+
+// render copies a skeleton into a worktree, rendering its templates with data.
+func render(dir string, worktree billy.Filesystem, data map[string]string) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		if strings.HasSuffix(name, templateSuffix) {
+			name = strings.TrimSuffix(name, templateSuffix)
+			tmpl, err := template.New(name).Option("missingkey=error").Parse(string(content))
+			if err != nil {
+				return fmt.Errorf("parsing %s: %w", rel, err)
+			}
+			var out bytes.Buffer
+			if err := tmpl.Execute(&out, data); err != nil {
+				return fmt.Errorf("rendering %s: %w", rel, err)
+			}
+			content = out.Bytes()
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		file, err := worktree.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+		if err != nil {
+			return err
+		}
+		if _, err := file.Write(content); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+}
@@ -0,0 +1,99 @@
+package scaffold
+
+import (
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeSkeleton creates a skeleton with a template, a workflow using the {{ }} syntax and a script.
+func writeSkeleton(t *testing.T) string {
+	dir := t.TempDir()
+	files := map[string]string{
+		"service/README.md.tmpl":           "# {{.Repo}}\n\nOwned by {{.Owner}}, team {{.Team}}.\n",
+		"service/.github/workflows/ci.yml": "token: ${{ secrets.GITHUB_TOKEN }}\n",
+		"service/scripts/run.sh":           "#!/bin/sh\n",
+		"service/.git/HEAD":                "ref: refs/heads/main\n",
+		"broken/main.go.tmpl":              "package {{.Package}}\n",
+		"not-a-skeleton.txt":               "",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+	require.NoError(t, os.Chmod(filepath.Join(dir, "service/scripts/run.sh"), 0o755))
+	return dir
+}
+
+// TestCheck tests that unknown skeletons and missing variables are reported before pushing.
+func TestCheck(t *testing.T) {
+	s := New(writeSkeleton(t))
+
+	assert.NoError(t, s.Check("service", map[string]string{"Team": "platform"}))
+	assert.Error(t, s.Check("service", nil))
+	assert.ErrorIs(t, s.Check("missing", nil), ErrUnknownSkeleton)
+	assert.ErrorIs(t, s.Check("not-a-skeleton.txt", nil), ErrUnknownSkeleton)
+	assert.ErrorIs(t, s.Check("../service", nil), ErrUnknownSkeleton)
+	assert.Error(t, s.Check("broken", nil))
+}
+
+// TestPush tests that a rendered skeleton is pushed as the initial commit of an empty repository.
+func TestPush(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("the file transport of go-git needs the git binary")
+	}
+	remotes := t.TempDir()
+	remote, err := git.PlainInit(filepath.Join(remotes, "octocat", "hello.git"), true)
+	require.NoError(t, err)
+
+	s := New(writeSkeleton(t))
+	s.BaseURL = remotes + "/"
+	hash, err := s.Push(context.Background(), Options{
+		Skeleton:  "service",
+		Owner:     "octocat",
+		Repo:      "hello",
+		Branch:    "main",
+		Message:   "Initial commit",
+		Variables: map[string]string{"Team": "platform", "Repo": "ignored"},
+	})
+	require.NoError(t, err)
+
+	ref, err := remote.Reference(plumbing.NewBranchReferenceName("main"), true)
+	require.NoError(t, err)
+	assert.Equal(t, hash, ref.Hash())
+	commit, err := remote.CommitObject(hash)
+	require.NoError(t, err)
+	assert.Equal(t, "Initial commit", commit.Message)
+	assert.Equal(t, "octocat", commit.Author.Name)
+	assert.Empty(t, commit.ParentHashes)
+
+	read := func(name string) string {
+		file, err := commit.File(name)
+		require.NoError(t, err, name)
+		reader, err := file.Reader()
+		require.NoError(t, err)
+		defer reader.Close()
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		return string(content)
+	}
+	assert.Equal(t, "# hello\n\nOwned by octocat, team platform.\n", read("README.md"))
+	assert.Equal(t, "token: ${{ secrets.GITHUB_TOKEN }}\n", read(".github/workflows/ci.yml"))
+	script, err := commit.File("scripts/run.sh")
+	require.NoError(t, err)
+	assert.Equal(t, filemode.Executable, script.Mode)
+	_, err = commit.File("README.md.tmpl")
+	assert.Error(t, err)
+	_, err = commit.File(".git/HEAD")
+	assert.Error(t, err)
+}
@@ -0,0 +1,180 @@
+// Package validation registers the validation rules of the API with gin's validator and turns
+// validation failures into field-level errors. Request types declare their rules with binding
+// struct tags, which c.ShouldBindJSON and Struct check.
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-playground/validator/v10"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+var (
+	// repoNamePattern matches the repository names GitHub accepts without rewriting them.
+	repoNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,100}$`)
+	// ownerPattern matches a GitHub user or organization login.
+	ownerPattern = regexp.MustCompile(`^[A-Za-z0-9](?:[A-Za-z0-9-]{0,38})$`)
+	// topicPattern matches a valid repository topic.
+	topicPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,49}$`)
+	// actionsNamePattern matches the name of an Actions secret or variable.
+	actionsNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// messages describe the rules in field errors; %s is replaced by the parameter of the rule.
+var messages = map[string]string{
+	"required":    "is required",
+	"reponame":    "must be 1 to 100 letters, digits, '.', '-' or '_', and not '.' or '..'",
+	"fullname":    "must be of the form owner/name",
+	"login":       "must be a valid user or organization login",
+	"topic":       "must be 1 to 50 lowercase letters, digits or '-', starting with a letter or digit",
+	"branch":      "must be a valid branch name",
+	"actionsname": "must be letters, digits or '_', not starting with a digit or GITHUB_",
+	"oneof":       "must be one of %s",
+	"url":         "must be a valid URL",
+	"http_url":    "must be an http or https URL",
+	"max":         "must be at most %s",
+	"min":         "must be at least %s",
+	"unique":      "must not contain duplicates",
+}
+
+// FieldError describes why the value of a field of a request is invalid.
+// Field is the JSON path of the field, such as "template.repository" or "topics[2]".
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func init() {
+	engine, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		panic("validation: gin's validator is not go-playground/validator")
+	}
+	engine.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+	rules := map[string]validator.Func{
+		"reponame": func(fl validator.FieldLevel) bool {
+			name := fl.Field().String()
+			return repoNamePattern.MatchString(name) && name != "." && name != ".."
+		},
+		"fullname": func(fl validator.FieldLevel) bool {
+			owner, name, ok := strings.Cut(fl.Field().String(), "/")
+			return ok && ownerPattern.MatchString(owner) && repoNamePattern.MatchString(name) && name != "." && name != ".."
+		},
+		"login": func(fl validator.FieldLevel) bool {
+			return ownerPattern.MatchString(fl.Field().String())
+		},
+		"topic": func(fl validator.FieldLevel) bool {
+			return topicPattern.MatchString(fl.Field().String())
+		},
+		"branch": func(fl validator.FieldLevel) bool {
+			return plumbing.NewBranchReferenceName(fl.Field().String()).Validate() == nil
+		},
+		"actionsname": func(fl validator.FieldLevel) bool {
+			name := fl.Field().String()
+			return actionsNamePattern.MatchString(name) && !strings.HasPrefix(strings.ToUpper(name), "GITHUB_")
+		},
+	}
+	for tag, rule := range rules {
+		if err := engine.RegisterValidation(tag, rule); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// Struct validates a struct against its binding tags, as c.ShouldBindJSON does after decoding.
+//
+// Parameters:
+//   - obj: The struct, or a pointer to it.
+//
+// Returns:
+//   - error: The validation errors, which Fields turns into field errors, or nil if the struct is valid.
+func Struct(obj any) error {
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// Fields returns the field errors of a validation failure.
+//
+// Parameters:
+//   - err: The error returned by c.ShouldBindJSON or Struct.
+//
+// Returns:
+//   - []FieldError: The invalid fields, in the order of the struct, or the field of the wrong JSON type.
+//   - bool: False if err is not a validation failure, such as malformed JSON.
+func Fields(err error) ([]FieldError, bool) {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return []FieldError{{Field: typeErr.Field, Rule: "type", Message: "must be " + typeName(typeErr.Type)}}, true
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil, false
+	}
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		// The namespace starts with the name of the validated struct type.
+		_, field, _ := strings.Cut(fe.Namespace(), ".")
+		if field == "" {
+			field = fe.Field()
+		}
+		fields = append(fields, FieldError{Field: field, Rule: fe.Tag(), Message: message(fe)})
+	}
+	return fields, true
+}
+
+// message describes the rule a field failed.
+func message(fe validator.FieldError) string {
+	format, ok := messages[fe.Tag()]
+	if !ok {
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+	if !strings.Contains(format, "%s") {
+		return format
+	}
+	param := fe.Param()
+	switch fe.Tag() {
+	case "oneof":
+		param = strings.Join(strings.Fields(param), ", ")
+	case "max", "min":
+		if kind := fe.Kind(); kind == reflect.Slice || kind == reflect.Map {
+			param += " items"
+		} else if kind == reflect.String {
+			param += " characters"
+		}
+	}
+	return fmt.Sprintf(format, param)
+}
+
+// typeName describes the JSON type of a Go type.
+func typeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.String:
+		return "a string"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
@@ -0,0 +1,38 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// request is a payload validated in the tests.
+type request struct {
+	Name   string   `json:"name" binding:"required,reponame"`
+	Owner  string   `json:"owner" binding:"omitempty,max=5"`
+	Labels []string `json:"labels" binding:"max=1"`
+	Count  int      `json:"count"`
+}
+
+// TestFields tests the field errors of validation failures and JSON type mismatches.
+func TestFields(t *testing.T) {
+	fields, ok := Fields(Struct(request{Owner: "octocat-org", Labels: []string{"a", "b"}}))
+	assert.True(t, ok)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Rule: "required", Message: "is required"},
+		{Field: "owner", Rule: "max", Message: "must be at most 5 characters"},
+		{Field: "labels", Rule: "max", Message: "must be at most 1 items"},
+	}, fields)
+
+	var req request
+	err := json.Unmarshal([]byte(`{"name": "hello", "count": "three"}`), &req)
+	fields, ok = Fields(err)
+	assert.True(t, ok)
+	assert.Equal(t, []FieldError{{Field: "count", Rule: "type", Message: "must be a number"}}, fields)
+
+	_, ok = Fields(errors.New("unexpected EOF"))
+	assert.False(t, ok)
+	assert.NoError(t, Struct(request{Name: "hello"}))
+}
@@ -0,0 +1,218 @@
+// Package workspace keeps local bare clones of GitHub repositories, so that history can be
+// walked with go-git instead of the REST API, whose quota large ranges would exhaust.
+package workspace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// DefaultBaseURL is the URL repositories are cloned from unless configured otherwise.
+const DefaultBaseURL = "https://github.com/"
+
+// fetchRefSpecs mirror the branches and tags of the remote, so both resolve by name.
+var fetchRefSpecs = []config.RefSpec{
+	"+refs/heads/*:refs/heads/*",
+	"+refs/tags/*:refs/tags/*",
+}
+
+// Workspace is a directory of bare clones, one per repository, under Dir/owner/repo.git.
+// Clones are created on first use and fetched again on every later use.
+type Workspace struct {
+	Dir     string
+	BaseURL string
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+// New creates a workspace in a directory, cloning from BaseURL.
+//
+// Parameters:
+//   - dir: The directory holding the clones.
+//
+// Returns:
+//   - *Workspace: The workspace.
+func New(dir string) *Workspace {
+	return &Workspace{Dir: dir, BaseURL: DefaultBaseURL}
+}
+
+// FromEnv creates the workspace configured by the GIT_WORKSPACE_DIR environment variable,
+// cloning from GIT_WORKSPACE_BASE_URL when set, e.g. for GitHub Enterprise.
+//
+// Returns:
+//   - *Workspace: The workspace, or nil if GIT_WORKSPACE_DIR is unset.
+func FromEnv() *Workspace {
+	dir := os.Getenv("GIT_WORKSPACE_DIR")
+	if dir == "" {
+		return nil
+	}
+	w := New(dir)
+	if baseURL := os.Getenv("GIT_WORKSPACE_BASE_URL"); baseURL != "" {
+		w.BaseURL = strings.TrimSuffix(baseURL, "/") + "/"
+	}
+	return w
+}
+
+var (
+	defaultMu        sync.RWMutex
+	defaultWorkspace *Workspace
+)
+
+// Default returns the workspace used by the API, or nil if none is configured.
+func Default() *Workspace {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultWorkspace
+}
+
+// SetDefault replaces the workspace used by the API.
+func SetDefault(w *Workspace) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultWorkspace = w
+}
+
+// Sync clones a repository into the workspace, or fetches it if it was cloned before.
+// Concurrent calls for the same repository are serialized.
+//
+// Parameters:
+//   - ctx: The context bounding the clone or fetch.
+//   - owner: The owner of the repository.
+//   - repo: The name of the repository.
+//   - token: The access token used to authenticate, or an empty string for public repositories.
+//
+// Returns:
+//   - *git.Repository: The up-to-date clone.
+//   - error: An error if the repository cannot be cloned or fetched.
+func (w *Workspace) Sync(ctx context.Context, owner, repo, token string) (*git.Repository, error) {
+	if !valid(owner, repo) {
+		return nil, fmt.Errorf("invalid repository %q", owner+"/"+repo)
+	}
+	path := filepath.Join(w.Dir, owner, repo+".git")
+	lock := w.lock(path)
+	lock.Lock()
+	defer lock.Unlock()
+
+	r, err := git.PlainOpen(path)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		r, err = w.init(path, owner, repo)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &git.FetchOptions{RemoteName: "origin", RefSpecs: fetchRefSpecs, Tags: git.NoTags, Force: true}
+	if token != "" {
+		opts.Auth = &http.BasicAuth{Username: "x-access-token", Password: token}
+	}
+	if err := r.FetchContext(ctx, opts); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil, fmt.Errorf("fetching %s/%s: %w", owner, repo, err)
+	}
+	return r, nil
+}
+
+// Move relocates the clone of a repository that was renamed or transferred, and points its
+// origin to the new location, so that the next Sync fetches instead of cloning again.
+//
+// Parameters:
+//   - owner: The former owner of the repository.
+//   - repo: The former name of the repository.
+//   - newOwner: The new owner of the repository.
+//   - newRepo: The new name of the repository.
+//
+// Returns:
+//   - bool: True if the repository had been cloned and was moved.
+//   - error: An error if the clone cannot be moved, or if a clone already exists at the new location.
+func (w *Workspace) Move(owner, repo, newOwner, newRepo string) (bool, error) {
+	for _, r := range [][2]string{{owner, repo}, {newOwner, newRepo}} {
+		if !valid(r[0], r[1]) {
+			return false, fmt.Errorf("invalid repository %q", r[0]+"/"+r[1])
+		}
+	}
+	from := filepath.Join(w.Dir, owner, repo+".git")
+	to := filepath.Join(w.Dir, newOwner, newRepo+".git")
+	if from == to {
+		return false, nil
+	}
+	// Locks are taken in path order, so that concurrent moves cannot deadlock.
+	first, second := w.lock(from), w.lock(to)
+	if to < from {
+		first, second = second, first
+	}
+	first.Lock()
+	defer first.Unlock()
+	second.Lock()
+	defer second.Unlock()
+
+	if _, err := os.Stat(from); errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if _, err := os.Stat(to); err == nil {
+		return false, fmt.Errorf("%s/%s is already cloned", newOwner, newRepo)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return false, err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return false, err
+	}
+	r, err := git.PlainOpen(to)
+	if err != nil {
+		return true, err
+	}
+	cfg, err := r.Config()
+	if err != nil {
+		return true, err
+	}
+	if origin := cfg.Remotes["origin"]; origin != nil {
+		origin.URLs = []string{w.BaseURL + newOwner + "/" + newRepo + ".git"}
+	}
+	return true, r.SetConfig(cfg)
+}
+
+// init creates an empty bare repository whose origin is the GitHub repository.
+func (w *Workspace) init(path, owner, repo string) (*git.Repository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	r, err := git.PlainInit(path, true)
+	if err != nil {
+		return nil, err
+	}
+	_, err = r.CreateRemote(&config.RemoteConfig{
+		Name:  "origin",
+		URLs:  []string{w.BaseURL + owner + "/" + repo + ".git"},
+		Fetch: fetchRefSpecs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// valid reports whether an owner and a name designate a directory inside the workspace.
+func valid(owner, repo string) bool {
+	return owner != "" && repo != "" && !strings.ContainsAny(owner+repo, `/\`) && !strings.HasPrefix(owner, ".") && !strings.HasPrefix(repo, ".")
+}
+
+// lock returns the mutex serializing the access to a clone.
+func (w *Workspace) lock(path string) *sync.Mutex {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.locks == nil {
+		w.locks = map[string]*sync.Mutex{}
+	}
+	if w.locks[path] == nil {
+		w.locks[path] = &sync.Mutex{}
+	}
+	return w.locks[path]
+}
@@ -0,0 +1,84 @@
+package workspace
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSync tests cloning a repository into the workspace and fetching its new commits later.
+func TestSync(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("the file transport of go-git needs the git binary")
+	}
+	remotes := t.TempDir()
+	source, err := git.PlainInit(filepath.Join(remotes, "octocat", "hello.git"), false)
+	require.NoError(t, err)
+	tree, err := source.Worktree()
+	require.NoError(t, err)
+	commit := func(message string) plumbing.Hash {
+		hash, err := tree.Commit(message, &git.CommitOptions{
+			AllowEmptyCommits: true,
+			Author:            &object.Signature{Name: "octocat", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+	first := commit("Initial commit")
+	_, err = source.CreateTag("v1.0.0", first, nil)
+	require.NoError(t, err)
+
+	w := New(t.TempDir())
+	w.BaseURL = remotes + "/"
+	clone, err := w.Sync(context.Background(), "octocat", "hello", "")
+	require.NoError(t, err)
+	tag, err := clone.ResolveRevision("v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, first, *tag)
+
+	second := commit("Second commit")
+	clone, err = w.Sync(context.Background(), "octocat", "hello", "")
+	require.NoError(t, err)
+	head, err := clone.ResolveRevision("master")
+	require.NoError(t, err)
+	assert.Equal(t, second, *head)
+
+	_, err = w.Sync(context.Background(), "..", "hello", "")
+	assert.Error(t, err)
+}
+
+// TestMove tests relocating a clone after its repository was renamed.
+func TestMove(t *testing.T) {
+	w := New(t.TempDir())
+	_, err := w.init(filepath.Join(w.Dir, "octocat", "hello.git"), "octocat", "hello")
+	require.NoError(t, err)
+
+	moved, err := w.Move("octocat", "hello", "octo-org", "world")
+	require.NoError(t, err)
+	assert.True(t, moved)
+	assert.NoDirExists(t, filepath.Join(w.Dir, "octocat", "hello.git"))
+	clone, err := git.PlainOpen(filepath.Join(w.Dir, "octo-org", "world.git"))
+	require.NoError(t, err)
+	origin, err := clone.Remote("origin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultBaseURL + "octo-org/world.git"}, origin.Config().URLs)
+
+	moved, err = w.Move("octocat", "hello", "octo-org", "world")
+	require.NoError(t, err)
+	assert.False(t, moved)
+
+	_, err = w.init(filepath.Join(w.Dir, "octocat", "hello.git"), "octocat", "hello")
+	require.NoError(t, err)
+	_, err = w.Move("octocat", "hello", "octo-org", "world")
+	assert.Error(t, err)
+	_, err = w.Move("octocat", "hello", "..", "world")
+	assert.Error(t, err)
+}